@@ -0,0 +1,170 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	"github.com/oboukili/terraform-provider-argocd/internal/provider"
+)
+
+// checkFeatures returns an error for each attribute of `config` that is
+// guarded by a feature (see `features.FeatureConstraint.Paths`) not supported
+// by the ArgoCD server. The API clients are only initialized if any guarded
+// attribute is set, and nothing is checked while the provider configuration is
+// unknown since the server cannot be reached until apply.
+func checkFeatures(ctx context.Context, si *provider.ServerInterface, resourceType string, config cty.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !si.IsConfigKnown() {
+		return diags
+	}
+
+	initialized := false
+
+	unsupported, err := features.CheckPaths[cty.Path](resourceType, ctyValue{v: config}, func(f features.Feature) (bool, string, error) {
+		if !initialized {
+			if ds := si.InitClients(ctx); ds.HasError() {
+				diags = pluginSDKDiags(ds)
+				return false, "", errors.New("failed to initialize API clients")
+			}
+
+			initialized = true
+		}
+
+		supported, reason, ds := si.FeatureSupport(ctx, f)
+		diags = append(diags, pluginSDKDiags(ds)...)
+
+		return supported, reason, nil
+	})
+	if err != nil {
+		return diags
+	}
+
+	for _, u := range unsupported {
+		d := featureNotSupported(u.Feature, u.Reason)
+		d[0].AttributePath = u.Path
+
+		diags = append(diags, d...)
+	}
+
+	return diags
+}
+
+// validateFeatures returns a CustomizeDiffFunc reporting the attributes of
+// `resourceType` guarded by a feature not supported by the ArgoCD server at plan
+// time rather than halfway through an apply (see checkFeatures).
+func validateFeatures(resourceType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		si, ok := m.(*provider.ServerInterface)
+		if !ok {
+			return nil
+		}
+
+		var es []error
+
+		// CustomizeDiff cannot return diagnostics, so the attribute path is
+		// part of the error message instead. Warnings are only logged, they
+		// are reported by Create and Update.
+		for _, diagnostic := range checkFeatures(ctx, si, resourceType, d.GetRawConfig()) {
+			if diagnostic.Severity != diag.Error {
+				tflog.Warn(ctx, fmt.Sprintf("%s: %s", diagnostic.Summary, diagnostic.Detail))
+				continue
+			}
+
+			msg := diagnostic.Summary
+			if diagnostic.Detail != "" {
+				msg = fmt.Sprintf("%s: %s", msg, diagnostic.Detail)
+			}
+
+			if len(diagnostic.AttributePath) > 0 {
+				msg = fmt.Sprintf("%s: %s", formatAttributePath(diagnostic.AttributePath), msg)
+			}
+
+			es = append(es, errors.New(msg))
+		}
+
+		return errors.Join(es...)
+	}
+}
+
+// ctyValue implements `features.Value` for the configuration of resources
+// implemented using the plugin SDK.
+type ctyValue struct {
+	v cty.Value
+	p cty.Path
+}
+
+func (c ctyValue) IsSet() bool {
+	return c.v.IsKnown() && !c.v.IsNull()
+}
+
+func (c ctyValue) IsEmpty() bool {
+	t := c.v.Type()
+
+	switch {
+	case t == cty.String:
+		return c.v.AsString() == ""
+	case t == cty.Bool:
+		return c.v.False()
+	case t.IsCollectionType(), t.IsTupleType():
+		return c.v.LengthInt() == 0
+	}
+
+	return false
+}
+
+func (c ctyValue) Path() cty.Path {
+	return c.p
+}
+
+func (c ctyValue) Attribute(name string) (features.Value[cty.Path], bool) {
+	if t := c.v.Type(); !t.IsObjectType() || !t.HasAttribute(name) {
+		return nil, false
+	}
+
+	return ctyValue{v: c.v.GetAttr(name), p: c.p.GetAttr(name)}, true
+}
+
+func (c ctyValue) Elements() ([]features.Value[cty.Path], bool) {
+	t := c.v.Type()
+	if !t.IsListType() && !t.IsSetType() && !t.IsTupleType() {
+		return nil, false
+	}
+
+	var elems []features.Value[cty.Path]
+
+	for it := c.v.ElementIterator(); it.Next(); {
+		k, e := it.Element()
+		elems = append(elems, ctyValue{v: e, p: c.p.Index(k)})
+	}
+
+	return elems, !t.IsSetType()
+}
+
+// formatAttributePath formats `p` the way attributes are addressed by
+// `schema.ResourceData` (e.g. `spec.0.strategy`).
+func formatAttributePath(p cty.Path) string {
+	steps := make([]string, 0, len(p))
+
+	for _, s := range p {
+		switch s := s.(type) {
+		case cty.GetAttrStep:
+			steps = append(steps, s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.Number {
+				steps = append(steps, s.Key.AsBigFloat().Text('f', 0))
+			} else {
+				steps = append(steps, "*")
+			}
+		}
+	}
+
+	return strings.Join(steps, ".")
+}
@@ -0,0 +1,65 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCtyValue_UsedPaths(t *testing.T) {
+	t.Parallel()
+
+	source := func(helm cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"repo_url": cty.StringVal("https://charts.example"),
+			"helm":     helm,
+		})
+	}
+
+	helmType := cty.Object(map[string]cty.Type{"values_object": cty.String})
+
+	config := cty.ObjectVal(map[string]cty.Value{
+		"spec": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"strategy": cty.ListValEmpty(cty.String),
+			"template": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"spec": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"source": cty.ListVal([]cty.Value{
+						source(cty.ListValEmpty(helmType)),
+						source(cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"values_object": cty.StringVal(`{"foo":"bar"}`)})})),
+						source(cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"values_object": cty.UnknownVal(cty.String)})})),
+					}),
+				})}),
+			})}),
+		})}),
+	})
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"spec", []string{"spec"}},
+		{"spec.strategy", nil},
+		{"spec.ignore_application_differences", nil},
+		{"spec.template.spec.source.1", []string{"spec.0.template.0.spec.0.source.1"}},
+		{"spec.template.spec.source.3", nil},
+		{"spec.template.spec.source.helm.values_object", []string{"spec.0.template.0.spec.0.source.1.helm.0.values_object"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+
+			for _, p := range features.UsedPaths[cty.Path](ctyValue{v: config}, tt.path) {
+				got = append(got, formatAttributePath(p))
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,12 +2,14 @@ package argocd
 
 import (
 	"context"
-	"sync"
+	"fmt"
 
 	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 
 	// Import to initialize client auth plugins.
@@ -15,20 +17,8 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
 
-// Used to handle concurrent access to ArgoCD common configuration
-var tokenMutexConfiguration = &sync.RWMutex{}
-
-// Used to handle concurrent access to ArgoCD clusters
-var tokenMutexClusters = &sync.RWMutex{}
-
-// Used to handle concurrent access to each ArgoCD project
-var tokenMutexProjectMap = make(map[string]*sync.RWMutex, 0)
-
-// Used to handle concurrent access to ArgoCD secrets
-var tokenMutexSecrets = &sync.RWMutex{}
-
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"server_addr": {
 				Type:        schema.TypeString,
@@ -52,11 +42,21 @@ func Provider() *schema.Provider {
 				Description: "Authentication password. Can be set through the `ARGOCD_AUTH_PASSWORD` environment variable.",
 				Sensitive:   true,
 			},
+			"cert_data": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded additional root CA certificates to add to the client TLS connection pool. Conflicts with `cert_file`. Can be set through the `ARGOCD_CERT_DATA` environment variable.",
+			},
 			"cert_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Additional root CA certificates file to add to the client TLS connection pool.",
 			},
+			"client_cert_data": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded client certificate. Must be specified alongside `client_key_data`. Conflicts with `client_cert_file`. Can be set through the `ARGOCD_CLIENT_CERT_DATA` environment variable.",
+			},
 			"client_cert_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -67,32 +67,63 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "Client certificate key.",
 			},
+			"client_key_data": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded client certificate key. Must be specified alongside `client_cert_data`. Conflicts with `client_cert_key`. Can be set through the `ARGOCD_CLIENT_KEY_DATA` environment variable.",
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma-separated list of hosts, domains (e.g. `.example.com`) and CIDR ranges of ArgoCD API servers that are reached without going through the proxy. Not applied to the Kubernetes API when `proxy_url` is set. Can be set through the `NO_PROXY` (or `no_proxy`) environment variable.",
+			},
 			"plain_text": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "Whether to initiate an unencrypted connection to ArgoCD server.",
 			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the HTTP(S) or SOCKS5 proxy through which the ArgoCD API server (using either gRPC or gRPC-Web) and the OIDC issuer are reached, as well as the Kubernetes API when `core` or port forwarding is enabled, e.g. `http://proxy.local:3128`. Can be set through the `HTTPS_PROXY` (or `https_proxy`) environment variable.",
+			},
 			"context": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Context to choose when using a local ArgoCD config file. Only relevant when `use_local_config`. Can be set through `ARGOCD_CONTEXT` environment variable.",
 			},
+			"read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to serve the reads of applications, projects, repositories and clusters performed when refreshing resources from a snapshot of all such objects, listed at most once every 30 seconds and discarded whenever the provider modifies an object of the same kind. Reduces the number of requests sent to the ArgoCD API server when managing many objects. Objects missing from a snapshot are read directly.",
+			},
 			"user_agent": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "User-Agent request header override.",
 			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of requests sent concurrently to the ArgoCD API server. A quarter of this budget (and at least one request) is reserved to heavy requests (i.e. requests that generate manifests, trigger syncs or list all applications), which are limited separately. Unlimited by default.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of requests sent to the ArgoCD API server per second. A quarter of this budget (and at least one request per second) is reserved to heavy requests (i.e. requests that generate manifests, trigger syncs or list all applications), which are limited separately. Unlimited by default.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"core": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Description: "Configure direct access using Kubernetes API server.\n\n  " +
-					"**Warning**: this feature works by starting a local ArgoCD API server that talks directly to the Kubernetes API using the **current context " +
-					"in the default kubeconfig** (`~/.kube/config`). This behavior cannot be overridden using either environment variables or the `kubernetes` block " +
-					"in the provider configuration at present).\n\n  If the server fails to start (e.g. your kubeconfig is misconfigured) then the provider will " +
-					"fail as a result of the `argocd` module forcing it to exit and no logs will be available to help you debug this. The error message will be " +
-					"similar to\n  > `The plugin encountered an error, and failed to respond to the plugin.(*GRPCProvider).ReadResource call. The plugin logs may " +
-					"contain more details.`\n\n  To debug this, you will need to login via the ArgoCD CLI using `argocd login --core` and then running an operation. " +
-					"E.g. `argocd app list`.",
+					"**Note**: this feature works by starting a local ArgoCD API server that talks directly to the Kubernetes API using the current context " +
+					"in the default kubeconfig (`~/.kube/config`, which can be overridden using the `KUBECONFIG` environment variable) or, if there is none, the " +
+					"in-cluster configuration. Both can be overridden using the `kubernetes` block.\n\n  If the server fails to start (e.g. your kubeconfig is " +
+					"misconfigured), the error is reported by the provider. To debug this further, you can login via the ArgoCD CLI using `argocd login --core` and " +
+					"then run an operation. E.g. `argocd app list`.",
 			},
 			"grpc_web": {
 				Type:        schema.TypeBool,
@@ -139,18 +170,143 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeList,
 				MaxItems:    1,
 				Optional:    true,
-				Description: "Kubernetes configuration overrides.  Only relevant when `port_forward = true` or `port_forward_with_namespace = \"foo\"`. The kubeconfig file that is used can be overridden using the [`KUBECONFIG` environment variable](https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/#the-kubeconfig-environment-variable)).",
+				Description: "Kubernetes configuration overrides.  Only relevant when `core = true`, `port_forward = true` or `port_forward_with_namespace = \"foo\"`. The kubeconfig file that is used can be overridden using the [`KUBECONFIG` environment variable](https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/#the-kubeconfig-environment-variable)).",
 				Elem:        kubernetesResource(),
 			},
+			"auth_exec": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block to obtain the ArgoCD authentication token by executing an external command, e.g. to fetch a short-lived token from a secret broker. The token is renewed when it expires or, if its expiry is unknown, once rejected by the ArgoCD API server. Conflicts with `auth_token`, `oidc`, `username`, `password`, `core` and `use_local_config`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Command to execute.",
+						},
+						"args": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Arguments to pass to the command.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"env": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Environment variables to set, in addition to those of the provider, when executing the command.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"output_format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Format of the standard output of the command, one of `plain` (the token only) or `json` (an object holding the token in its `token` key and, optionally, its expiry time in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format in its `expiration_timestamp` key). Defaults to `plain`.",
+							ValidateFunc: validation.StringInSlice([]string{"plain", "json"}, false),
+						},
+					},
+				},
+			},
+			"oidc": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Configuration block to obtain the ArgoCD authentication token from an OIDC issuer, using the OAuth2 client credentials flow or, if `subject_token_file` is set, the token exchange flow (e.g. to exchange the federated identity token of a CI runner). The resulting ID token (or access token, if the issuer does not return an ID token) must be accepted by the OIDC configuration of the ArgoCD API server. The token is renewed before it expires. Conflicts with `auth_exec`, `auth_token`, `username`, `password`, `core` and `use_local_config`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"issuer_url": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "URL of the OIDC issuer, used to discover its token endpoint.",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "OAuth2 client ID.",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "OAuth2 client secret, sent using HTTP basic authentication.",
+						},
+						"scopes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Scopes to request, e.g. `openid`.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"audience": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Audience of the requested token.",
+						},
+						"subject_token_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to a file holding a JWT (e.g. a workload identity token) to exchange for the ArgoCD authentication token using the [OAuth 2.0 token exchange](https://datatracker.ietf.org/doc/html/rfc8693) flow. The file is read each time a token is requested.",
+						},
+					},
+				},
+			},
+			"default_metadata": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Configuration block of the annotations and labels merged into the `metadata` of every `argocd_application`, `argocd_application_set`, `argocd_cluster` and `argocd_project`, similarly to the `default_tags` of other providers. Annotations and labels set on a resource take precedence. Default annotations and labels are only tracked by a resource when they are set on it explicitly or when the object holds a different value, e.g. after the default value has changed. **Note**: annotations and labels added to `default_metadata` are only set on existing objects the next time these are updated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"annotations": {
+							Type:         schema.TypeMap,
+							Optional:     true,
+							Description:  "Annotations merged into the annotations of every object.",
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: validateMetadataAnnotations,
+						},
+						"labels": {
+							Type:         schema.TypeMap,
+							Optional:     true,
+							Description:  "Labels merged into the labels of every object.",
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: validateMetadataLabels,
+						},
+					},
+				},
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Configuration for retrying requests that fail with a transient error, i.e. with a gRPC status code of `Unavailable`, `ResourceExhausted` or `Aborted`. Only requests that do not modify any object are retried, along with requests that failed before being sent to the server because it was unavailable. Unless configured otherwise, requests are attempted up to 3 times.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Maximum number of attempts made for each request, including the first one. Set to `1` to disable retries. Defaults to `3`.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"initial_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Amount of time to wait before retrying a request for the first time, doubled after each subsequent attempt (e.g. `500ms`). Defaults to `1s`.",
+							ValidateFunc: validateDuration,
+						},
+						"max_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Maximum amount of time to wait between two attempts (e.g. `1m`). Defaults to `30s`.",
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"argocd_account_token":          resourceArgoCDAccountToken(),
-			"argocd_application":            resourceArgoCDApplication(),
 			"argocd_application_set":        resourceArgoCDApplicationSet(),
 			"argocd_repository_certificate": resourceArgoCDRepositoryCertificates(),
 			"argocd_cluster":                resourceArgoCDCluster(),
-			"argocd_project":                resourceArgoCDProject(),
 			"argocd_project_token":          resourceArgoCDProjectToken(),
 			"argocd_repository":             resourceArgoCDRepository(),
 			"argocd_repository_credentials": resourceArgoCDRepositoryCredentials(),
@@ -158,11 +314,36 @@ func Provider() *schema.Provider {
 		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			config, diags := argoCDProviderConfigFromResourceData(ctx, d)
 
+			// The configuration may depend on resources that are yet to be
+			// created (e.g. those installing ArgoCD), in which case the
+			// clients are only initialized at apply time.
+			config.Unknown = !d.GetRawConfig().IsWhollyKnown()
+
 			server := provider.NewServerInterface(config)
 
 			return server, diags
 		},
 	}
+
+	for _, r := range p.ResourcesMap {
+		r.ReadContext = readWithKnownConfig(r.ReadContext)
+	}
+
+	return p
+}
+
+// readWithKnownConfig wraps the read function of a resource so that resources
+// are not refreshed, and retain their prior state, while the provider
+// configuration is unknown.
+func readWithKnownConfig(read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if si, ok := meta.(*provider.ServerInterface); ok && !si.IsConfigKnown() {
+			tflog.Debug(ctx, fmt.Sprintf("provider configuration is unknown, skipping refresh of %s", d.Id()))
+			return nil
+		}
+
+		return read(ctx, d, meta)
+	}
 }
 
 func kubernetesResource() *schema.Resource {
@@ -265,19 +446,27 @@ func kubernetesResource() *schema.Resource {
 func argoCDProviderConfigFromResourceData(ctx context.Context, d *schema.ResourceData) (provider.ArgoCDProviderConfig, diag.Diagnostics) {
 	c := provider.ArgoCDProviderConfig{
 		AuthToken:                getStringFromResourceData(d, "auth_token"),
+		CertData:                 getStringFromResourceData(d, "cert_data"),
 		CertFile:                 getStringFromResourceData(d, "cert_file"),
+		ClientCertData:           getStringFromResourceData(d, "client_cert_data"),
 		ClientCertFile:           getStringFromResourceData(d, "client_cert_file"),
 		ClientCertKey:            getStringFromResourceData(d, "client_cert_key"),
+		ClientKeyData:            getStringFromResourceData(d, "client_key_data"),
 		ConfigPath:               getStringFromResourceData(d, "config_path"),
 		Context:                  getStringFromResourceData(d, "context"),
 		Core:                     getBoolFromResourceData(d, "core"),
 		GRPCWeb:                  getBoolFromResourceData(d, "grpc_web"),
 		GRPCWebRootPath:          getStringFromResourceData(d, "grpc_web_root_path"),
 		Insecure:                 getBoolFromResourceData(d, "insecure"),
+		MaxConcurrentRequests:    getInt64FromResourceData(d, "max_concurrent_requests"),
 		Password:                 getStringFromResourceData(d, "password"),
+		NoProxy:                  getStringFromResourceData(d, "no_proxy"),
 		PlainText:                getBoolFromResourceData(d, "plain_text"),
 		PortForward:              getBoolFromResourceData(d, "port_forward"),
 		PortForwardWithNamespace: getStringFromResourceData(d, "port_forward_with_namespace"),
+		ProxyURL:                 getStringFromResourceData(d, "proxy_url"),
+		ReadCache:                getBoolFromResourceData(d, "read_cache"),
+		RequestsPerSecond:        getInt64FromResourceData(d, "requests_per_second"),
 		ServerAddr:               getStringFromResourceData(d, "server_addr"),
 		UseLocalConfig:           getBoolFromResourceData(d, "use_local_config"),
 		UserAgent:                getStringFromResourceData(d, "user_agent"),
@@ -292,6 +481,23 @@ func argoCDProviderConfigFromResourceData(ctx context.Context, d *schema.Resourc
 
 	diags.Append(ds...)
 
+	authExec, ds := authExecConfigFromResourceData(ctx, d)
+	c.AuthExec = authExec
+
+	diags.Append(ds...)
+
+	oidc, ds := oidcConfigFromResourceData(ctx, d)
+	c.OIDC = oidc
+
+	diags.Append(ds...)
+
+	defaultMetadata, ds := defaultMetadataConfigFromResourceData(ctx, d)
+	c.DefaultMetadata = defaultMetadata
+
+	diags.Append(ds...)
+
+	c.Retry = retryConfigFromResourceData(d)
+
 	return c, pluginSDKDiags(diags)
 }
 
@@ -342,6 +548,81 @@ func kubernetesExecConfigFromResourceData(ctx context.Context, d *schema.Resourc
 	return []provider.KubernetesExec{exec}, diags
 }
 
+func authExecConfigFromResourceData(ctx context.Context, d *schema.ResourceData) ([]provider.AuthExec, fwdiag.Diagnostics) {
+	if _, ok := d.GetOk("auth_exec"); !ok {
+		return nil, nil
+	}
+
+	exec := provider.AuthExec{
+		Command:      getStringFromResourceData(d, "auth_exec.0.command"),
+		OutputFormat: getStringFromResourceData(d, "auth_exec.0.output_format"),
+	}
+
+	args, diags := getStringListFromResourceData(ctx, d, "auth_exec.0.args")
+	exec.Args = args
+
+	env, ds := getStringMapFromResourceData(ctx, d, "auth_exec.0.env")
+	exec.Env = env
+
+	diags.Append(ds...)
+
+	return []provider.AuthExec{exec}, diags
+}
+
+func oidcConfigFromResourceData(ctx context.Context, d *schema.ResourceData) ([]provider.OIDC, fwdiag.Diagnostics) {
+	if _, ok := d.GetOk("oidc"); !ok {
+		return nil, nil
+	}
+
+	oidc := provider.OIDC{
+		Audience:         getStringFromResourceData(d, "oidc.0.audience"),
+		ClientID:         getStringFromResourceData(d, "oidc.0.client_id"),
+		ClientSecret:     getStringFromResourceData(d, "oidc.0.client_secret"),
+		IssuerURL:        getStringFromResourceData(d, "oidc.0.issuer_url"),
+		SubjectTokenFile: getStringFromResourceData(d, "oidc.0.subject_token_file"),
+	}
+
+	scopes, diags := getStringListFromResourceData(ctx, d, "oidc.0.scopes")
+	oidc.Scopes = scopes
+
+	return []provider.OIDC{oidc}, diags
+}
+
+func defaultMetadataConfigFromResourceData(ctx context.Context, d *schema.ResourceData) ([]provider.DefaultMetadata, fwdiag.Diagnostics) {
+	if _, ok := d.GetOk("default_metadata"); !ok {
+		return nil, nil
+	}
+
+	var diags fwdiag.Diagnostics
+
+	annotations, ds := getStringMapFromResourceData(ctx, d, "default_metadata.0.annotations")
+	diags.Append(ds...)
+
+	labels, ds := getStringMapFromResourceData(ctx, d, "default_metadata.0.labels")
+	diags.Append(ds...)
+
+	return []provider.DefaultMetadata{
+		{
+			Annotations: annotations,
+			Labels:      labels,
+		},
+	}, diags
+}
+
+func retryConfigFromResourceData(d *schema.ResourceData) []provider.Retry {
+	if _, ok := d.GetOk("retry"); !ok {
+		return nil
+	}
+
+	return []provider.Retry{
+		{
+			MaxAttempts:    getInt64FromResourceData(d, "retry.0.max_attempts"),
+			InitialBackoff: getStringFromResourceData(d, "retry.0.initial_backoff"),
+			MaxBackoff:     getStringFromResourceData(d, "retry.0.max_backoff"),
+		},
+	}
+}
+
 func getStringFromResourceData(d *schema.ResourceData, key string) types.String {
 	if v, ok := d.GetOk(key); ok {
 		return types.StringValue(v.(string))
@@ -358,6 +639,14 @@ func getBoolFromResourceData(d *schema.ResourceData, key string) types.Bool {
 	return types.BoolNull()
 }
 
+func getInt64FromResourceData(d *schema.ResourceData, key string) types.Int64 {
+	if v, ok := d.GetOk(key); ok {
+		return types.Int64Value(int64(v.(int)))
+	}
+
+	return types.Int64Null()
+}
+
 func getStringListFromResourceData(ctx context.Context, d *schema.ResourceData, key string) (types.List, fwdiag.Diagnostics) {
 	if v, ok := d.GetOk(key); ok {
 		return types.ListValueFrom(ctx, types.StringType, v.([]interface{}))
@@ -1,19 +1,53 @@
 package argocd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	"github.com/oboukili/terraform-provider-argocd/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testAccProviders map[string]func() (*schema.Provider, error)
 
+// testAccProtoV6ProviderFactories instantiate the provider, muxed from both its
+// SDK and framework implementations, during acceptance testing. These are
+// required by tests using resources implemented using the framework (e.g.
+// `argocd_application`).
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"argocd": func() (tfprotov6.ProviderServer, error) {
+		ctx := context.Background()
+
+		upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, Provider().GRPCProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		muxServer, err := tf6muxserver.NewMuxServer(ctx,
+			providerserver.NewProtocol6(provider.New("test")),
+			func() tfprotov6.ProviderServer { return upgradedSdkServer },
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return muxServer.ProviderServer(), nil
+	},
+}
+
 func init() {
 	testAccProviders = map[string]func() (*schema.Provider, error){
 		"argocd": func() (*schema.Provider, error) { //nolint:unparam
@@ -30,12 +64,66 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+// TestProvider_MuxedSchema ensures that the SDK and framework implementations of
+// the provider declare identical provider schemas, as required to mux them.
+func TestProvider_MuxedSchema(t *testing.T) {
+	t.Parallel()
+
+	server, err := testAccProtoV6ProviderFactories["argocd"]()
+	require.NoError(t, err)
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+}
+
+func TestProvider_ConfigureUnknown(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := Provider()
+	server := schema.NewGRPCProviderServer(p)
+
+	sr, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	typ := sr.Provider.ValueType().(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	// e.g. the address of an ArgoCD API server installed in the same apply
+	vals["server_addr"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	vals["auth_token"] = tftypes.NewValue(tftypes.String, "foo")
+
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+	require.NoError(t, err)
+
+	resp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &config})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	si, ok := p.Meta().(*provider.ServerInterface)
+	require.True(t, ok)
+	assert.False(t, si.IsConfigKnown())
+
+	// Resources retain their prior state rather than being refreshed
+	r := p.ResourcesMap["argocd_repository"]
+	d := r.TestResourceData()
+	d.SetId("https://github.com/example/repo.git")
+
+	require.Empty(t, r.ReadContext(ctx, d, si))
+	assert.Equal(t, "https://github.com/example/repo.git", d.Id())
+}
+
 func TestProvider_headers(t *testing.T) {
 	t.Parallel()
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf("%s %s", `
@@ -43,7 +131,7 @@ func TestProvider_headers(t *testing.T) {
                     headers = [
                         "Hello: HiThere",
                     ]
-                }`, testAccArgoCDApplicationSimple(acctest.RandomWithPrefix("test-acc"), "9.4.1", false),
+                }`, testAccArgoCDRepositorySimple(),
 				),
 			},
 		},
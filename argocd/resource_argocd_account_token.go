@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/account"
@@ -12,6 +11,7 @@ import (
 	"github.com/cristalhq/jwt/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 )
 
@@ -194,9 +194,9 @@ func resourceArgoCDAccountTokenCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	tokenMutexSecrets.Lock()
+	si.Locks.Secret().Lock()
 	resp, err := si.AccountClient.CreateToken(ctx, opts)
-	tokenMutexSecrets.Unlock()
+	si.Locks.Secret().Unlock()
 
 	if err != nil {
 		return argoCDAPIError("create", "token for account", accountName, err)
@@ -252,14 +252,14 @@ func resourceArgoCDAccountTokenRead(ctx context.Context, d *schema.ResourceData,
 		return errorToDiagnostics("failed to get account", err)
 	}
 
-	tokenMutexConfiguration.RLock() // Yes, this is a different mutex - accounts are stored in `argocd-cm` whereas tokens are stored in `argocd-secret`
+	si.Locks.ConfigMap().RLock() // Yes, this is a different mutex - accounts are stored in `argocd-cm` whereas tokens are stored in `argocd-secret`
 	_, err = si.AccountClient.GetAccount(ctx, &account.GetAccountRequest{
 		Name: accountName,
 	})
-	tokenMutexConfiguration.RUnlock()
+	si.Locks.ConfigMap().RUnlock()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			// Delete token from state if account has been deleted in an out-of-band fashion
 			d.SetId("")
 			return nil
@@ -322,14 +322,14 @@ func resourceArgoCDAccountTokenDelete(ctx context.Context, d *schema.ResourceDat
 		return errorToDiagnostics("failed to get account", err)
 	}
 
-	tokenMutexSecrets.Lock()
+	si.Locks.Secret().Lock()
 	_, err = si.AccountClient.DeleteToken(ctx, &account.DeleteTokenRequest{
 		Name: accountName,
 		Id:   d.Id(),
 	})
-	tokenMutexSecrets.Unlock()
+	si.Locks.Secret().Unlock()
 
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+	if err != nil && !diagnostics.IsNotFound(err) {
 		return argoCDAPIError("delete", "token for account", accountName, err)
 	}
 
@@ -3,12 +3,13 @@ package argocd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/applicationset"
 	application "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,6 +25,7 @@ func resourceArgoCDApplicationSet() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(validateDefaultMetadata, validateFeatures("argocd_application_set")),
 		Schema: map[string]*schema.Schema{
 			"metadata": metadataSchema("applicationsets.argoproj.io"),
 			"spec":     applicationSetSpecSchemaV0(),
@@ -37,8 +39,9 @@ func resourceArgoCDApplicationSetCreate(ctx context.Context, d *schema.ResourceD
 		return pluginSDKDiags(diags)
 	}
 
-	if !si.IsFeatureSupported(features.ApplicationSet) {
-		return featureNotSupported(features.ApplicationSet)
+	featureDiags := checkFeatures(ctx, si, "argocd_application_set", d.GetRawConfig())
+	if featureDiags.HasError() {
+		return featureDiags
 	}
 
 	objectMeta, spec, err := expandApplicationSet(d, si.IsFeatureSupported(features.MultipleApplicationSources), si.IsFeatureSupported(features.ApplicationSetIgnoreApplicationDifferences))
@@ -46,17 +49,7 @@ func resourceArgoCDApplicationSetCreate(ctx context.Context, d *schema.ResourceD
 		return errorToDiagnostics("failed to expand application set", err)
 	}
 
-	if !si.IsFeatureSupported(features.ApplicationSetProgressiveSync) && spec.Strategy != nil {
-		return featureNotSupported(features.ApplicationSetProgressiveSync)
-	}
-
-	if !si.IsFeatureSupported(features.ApplicationSetIgnoreApplicationDifferences) && spec.IgnoreApplicationDifferences != nil {
-		return featureNotSupported(features.ApplicationSetIgnoreApplicationDifferences)
-	}
-
-	if !si.IsFeatureSupported(features.ApplicationSetApplicationsSyncPolicy) && spec.SyncPolicy != nil && spec.SyncPolicy.ApplicationsSync != nil {
-		return featureNotSupported(features.ApplicationSetApplicationsSyncPolicy)
-	}
+	objectMeta.Annotations, objectMeta.Labels = mergeDefaultMetadata(si, objectMeta.Annotations, objectMeta.Labels)
 
 	as, err := si.ApplicationSetClient.Create(ctx, &applicationset.ApplicationSetCreateRequest{
 		Applicationset: &application.ApplicationSet{
@@ -81,7 +74,7 @@ func resourceArgoCDApplicationSetCreate(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(as.Name)
 
-	return resourceArgoCDApplicationSetRead(ctx, d, meta)
+	return append(featureDiags, resourceArgoCDApplicationSetRead(ctx, d, meta)...)
 }
 
 func resourceArgoCDApplicationSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -96,7 +89,7 @@ func resourceArgoCDApplicationSetRead(ctx context.Context, d *schema.ResourceDat
 		Name: name,
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			d.SetId("")
 			return diag.Diagnostics{}
 		}
@@ -104,6 +97,8 @@ func resourceArgoCDApplicationSetRead(ctx context.Context, d *schema.ResourceDat
 		return argoCDAPIError("read", "application set", name, err)
 	}
 
+	appSet.Annotations, appSet.Labels = removeDefaultMetadata(si, d, appSet.Annotations, appSet.Labels)
+
 	err = flattenApplicationSet(appSet, d)
 	if err != nil {
 		return errorToDiagnostics(fmt.Sprintf("failed to flatten application set %s", name), err)
@@ -118,12 +113,13 @@ func resourceArgoCDApplicationSetUpdate(ctx context.Context, d *schema.ResourceD
 		return pluginSDKDiags(diags)
 	}
 
-	if !si.IsFeatureSupported(features.ApplicationSet) {
-		return featureNotSupported(features.ApplicationSet)
+	featureDiags := checkFeatures(ctx, si, "argocd_application_set", d.GetRawConfig())
+	if featureDiags.HasError() {
+		return featureDiags
 	}
 
 	if !d.HasChanges("metadata", "spec") {
-		return nil
+		return featureDiags
 	}
 
 	objectMeta, spec, err := expandApplicationSet(d, si.IsFeatureSupported(features.MultipleApplicationSources), si.IsFeatureSupported(features.ApplicationSetIgnoreApplicationDifferences))
@@ -131,17 +127,7 @@ func resourceArgoCDApplicationSetUpdate(ctx context.Context, d *schema.ResourceD
 		return errorToDiagnostics(fmt.Sprintf("failed to expand application set %s", d.Id()), err)
 	}
 
-	if !si.IsFeatureSupported(features.ApplicationSetProgressiveSync) && spec.Strategy != nil {
-		return featureNotSupported(features.ApplicationSetProgressiveSync)
-	}
-
-	if !si.IsFeatureSupported(features.ApplicationSetIgnoreApplicationDifferences) && spec.IgnoreApplicationDifferences != nil {
-		return featureNotSupported(features.ApplicationSetIgnoreApplicationDifferences)
-	}
-
-	if !si.IsFeatureSupported(features.ApplicationSetApplicationsSyncPolicy) && spec.SyncPolicy != nil && spec.SyncPolicy.ApplicationsSync != nil {
-		return featureNotSupported(features.ApplicationSetApplicationsSyncPolicy)
-	}
+	objectMeta.Annotations, objectMeta.Labels = mergeDefaultMetadata(si, objectMeta.Annotations, objectMeta.Labels)
 
 	_, err = si.ApplicationSetClient.Create(ctx, &applicationset.ApplicationSetCreateRequest{
 		Applicationset: &application.ApplicationSet{
@@ -159,7 +145,7 @@ func resourceArgoCDApplicationSetUpdate(ctx context.Context, d *schema.ResourceD
 		return argoCDAPIError("update", "application set", objectMeta.Name, err)
 	}
 
-	return resourceArgoCDApplicationSetRead(ctx, d, meta)
+	return append(featureDiags, resourceArgoCDApplicationSetRead(ctx, d, meta)...)
 }
 
 func resourceArgoCDApplicationSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -172,7 +158,7 @@ func resourceArgoCDApplicationSetDelete(ctx context.Context, d *schema.ResourceD
 		Name: d.Id(),
 	})
 
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+	if err != nil && !diagnostics.IsNotFound(err) {
 		return argoCDAPIError("delete", "application set", d.Id(), err)
 	}
 
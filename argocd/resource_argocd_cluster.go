@@ -8,6 +8,7 @@ import (
 	clusterClient "github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 )
 
@@ -21,7 +22,8 @@ func resourceArgoCDCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: clusterSchema(),
+		CustomizeDiff: validateDefaultMetadata,
+		Schema:        clusterSchema(),
 	}
 }
 
@@ -36,8 +38,10 @@ func resourceArgoCDClusterCreate(ctx context.Context, d *schema.ResourceData, me
 		return errorToDiagnostics("failed to expand cluster", err)
 	}
 
+	cluster.Annotations, cluster.Labels = mergeDefaultMetadata(si, cluster.Annotations, cluster.Labels)
+
 	// Need a full lock here to avoid race conditions between List existing clusters and creating a new one
-	tokenMutexClusters.Lock()
+	si.Locks.Clusters().Lock()
 
 	rtrimmedServer := strings.TrimRight(cluster.Server, "/")
 
@@ -49,14 +53,14 @@ func resourceArgoCDClusterCreate(ctx context.Context, d *schema.ResourceData, me
 		},
 	})
 	if err != nil {
-		tokenMutexClusters.Unlock()
+		si.Locks.Clusters().Unlock()
 		return errorToDiagnostics(fmt.Sprintf("failed to list existing clusters when creating cluster %s", cluster.Server), err)
 	}
 
 	if len(existingClusters.Items) > 0 {
 		for _, existingCluster := range existingClusters.Items {
 			if rtrimmedServer == strings.TrimRight(existingCluster.Server, "/") {
-				tokenMutexClusters.Unlock()
+				si.Locks.Clusters().Unlock()
 
 				return []diag.Diagnostic{
 					{
@@ -71,7 +75,7 @@ func resourceArgoCDClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	c, err := si.ClusterClient.Create(ctx, &clusterClient.ClusterCreateRequest{
 		Cluster: cluster, Upsert: false,
 	})
-	tokenMutexClusters.Unlock()
+	si.Locks.Clusters().Unlock()
 
 	if err != nil {
 		return argoCDAPIError("create", "cluster", cluster.Server, err)
@@ -93,12 +97,21 @@ func resourceArgoCDClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		return pluginSDKDiags(diags)
 	}
 
-	tokenMutexClusters.RLock()
-	c, err := si.ClusterClient.Get(ctx, getClusterQueryFromID(d))
-	tokenMutexClusters.RUnlock()
+	var err error
+
+	q := getClusterQueryFromID(d)
+
+	si.Locks.Clusters().RLock()
+
+	c, ok := si.CachedCluster(ctx, q.Server, q.Name)
+	if !ok {
+		c, err = si.ClusterClient.Get(ctx, q)
+	}
+
+	si.Locks.Clusters().RUnlock()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -106,6 +119,8 @@ func resourceArgoCDClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		return argoCDAPIError("read", "cluster", d.Id(), err)
 	}
 
+	c.Annotations, c.Labels = removeDefaultMetadata(si, d, c.Annotations, c.Labels)
+
 	if err = flattenCluster(c, d); err != nil {
 		return errorToDiagnostics(fmt.Sprintf("failed to flatten cluster %s", d.Id()), err)
 	}
@@ -124,9 +139,11 @@ func resourceArgoCDClusterUpdate(ctx context.Context, d *schema.ResourceData, me
 		return errorToDiagnostics(fmt.Sprintf("failed to expand cluster %s", d.Id()), err)
 	}
 
-	tokenMutexClusters.Lock()
+	cluster.Annotations, cluster.Labels = mergeDefaultMetadata(si, cluster.Annotations, cluster.Labels)
+
+	si.Locks.Clusters().Lock()
 	_, err = si.ClusterClient.Update(ctx, &clusterClient.ClusterUpdateRequest{Cluster: cluster})
-	tokenMutexClusters.Unlock()
+	si.Locks.Clusters().Unlock()
 
 	if err != nil {
 		return argoCDAPIError("update", "cluster", cluster.Server, err)
@@ -141,12 +158,12 @@ func resourceArgoCDClusterDelete(ctx context.Context, d *schema.ResourceData, me
 		return pluginSDKDiags(diags)
 	}
 
-	tokenMutexClusters.Lock()
+	si.Locks.Clusters().Lock()
 	_, err := si.ClusterClient.Delete(ctx, getClusterQueryFromID(d))
-	tokenMutexClusters.Unlock()
+	si.Locks.Clusters().Unlock()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -14,8 +14,8 @@ func TestAccArgoCDProject(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDProjectPolicyError(
@@ -27,13 +27,13 @@ func TestAccArgoCDProject(t *testing.T) {
 				Config: testAccArgoCDProjectRoleNameError(
 					"test-acc-" + acctest.RandString(10),
 				),
-				ExpectError: regexp.MustCompile("invalid role name"),
+				ExpectError: regexp.MustCompile("must consist of alphanumeric characters"),
 			},
 			{
 				Config: testAccArgoCDProjectSyncWindowKindError(
 					"test-acc-" + acctest.RandString(10),
 				),
-				ExpectError: regexp.MustCompile("mismatch: can only be allow or deny"),
+				ExpectError: regexp.MustCompile("value must be one of"),
 			},
 			{
 				Config: testAccArgoCDProjectSyncWindowDurationError(
@@ -57,7 +57,7 @@ func TestAccArgoCDProject(t *testing.T) {
 				Config: testAccArgoCDProjectSimple(name),
 				Check: resource.TestCheckResourceAttrSet(
 					"argocd_project.simple",
-					"metadata.0.uid",
+					"metadata.uid",
 				),
 			},
 			{
@@ -71,7 +71,7 @@ func TestAccArgoCDProject(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 					// TODO: check all possible attributes
 				),
@@ -81,7 +81,7 @@ func TestAccArgoCDProject(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 						// TODO: check all possible attributes
 					),
 				),
@@ -91,7 +91,7 @@ func TestAccArgoCDProject(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 						// TODO: check all possible attributes
 					),
 				),
@@ -102,8 +102,8 @@ func TestAccArgoCDProject(t *testing.T) {
 
 func TestAccArgoCDProject_tokensCoexistence(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDProjectCoexistenceWithTokenResource(
@@ -113,11 +113,11 @@ func TestAccArgoCDProject_tokensCoexistence(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.coexistence",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 					resource.TestCheckNoResourceAttr(
 						"argocd_project.coexistence",
-						"spec.0.role.0.jwt_tokens",
+						"spec.roles.0.jwt_tokens",
 					),
 					resource.TestCheckResourceAttrSet(
 						"argocd_project_token.coexistence_testrole_exp",
@@ -149,15 +149,15 @@ func TestAccArgoCDProjectUpdateAddRole(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDProjectSimpleWithoutRole(name),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 				),
 			},
@@ -171,7 +171,7 @@ func TestAccArgoCDProjectUpdateAddRole(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 				),
 			},
@@ -183,15 +183,15 @@ func TestAccArgoCDProjectWithClustersRepositoriesRolePolicy(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDProjectWithClustersRepositoriesRolePolicy(name),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 				),
 			},
@@ -208,15 +208,15 @@ func TestAccArgoCDProjectWithLogsExecRolePolicy(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ExecLogsPolicy) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ExecLogsPolicy) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDProjectWithExecLogsRolePolicy(name),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 				),
 			},
@@ -233,15 +233,15 @@ func TestAccArgoCDProjectWithSourceNamespaces(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ProjectSourceNamespaces) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ProjectSourceNamespaces) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDProjectWithSourceNamespaces(name),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_project.simple",
-						"metadata.0.uid",
+						"metadata.uid",
 					),
 				),
 			},
@@ -254,10 +254,41 @@ func TestAccArgoCDProjectWithSourceNamespaces(t *testing.T) {
 	})
 }
 
+func TestAccArgoCDProjectWithDefaultMetadata(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccArgoCDProjectWithDefaultMetadata(name, "platform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("argocd_project.simple", "metadata.labels.%", "1"),
+					resource.TestCheckResourceAttr("argocd_project.simple", "metadata.labels.acceptance", "true"),
+					resource.TestCheckResourceAttr("argocd_project.simple", "metadata.annotations.%", "0"),
+				),
+			},
+			{
+				// Changes to the defaults are applied without being tracked
+				Config: testAccArgoCDProjectWithDefaultMetadata(name, "apps"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("argocd_project.simple", "metadata.labels.%", "1"),
+					resource.TestCheckResourceAttr("argocd_project.simple", "metadata.labels.acceptance", "true"),
+				),
+			},
+			{
+				Config:      testAccArgoCDProjectWithDefaultMetadata(name, "not a valid label value"),
+				ExpectError: regexp.MustCompile("Invalid Label Value"),
+			},
+		},
+	})
+}
+
 func testAccArgoCDProjectSimple(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "simple" {
-  metadata {
+  metadata = {
     name      = "%s"
     namespace = "argocd"
     labels = {
@@ -268,78 +299,92 @@ resource "argocd_project" "simple" {
     }
   }
 
-  spec {
+  spec = {
     description  = "simple"
     source_repos = ["*"]
 
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "default"
-    }
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "foo"
-    }
-    cluster_resource_whitelist {
-      group = "rbac.authorization.k8s.io"
-      kind  = "ClusterRoleBinding"
-    }
-    cluster_resource_whitelist {
-      group = "rbac.authorization.k8s.io"
-      kind  = "ClusterRole"
-    }
-    cluster_resource_whitelist {
-      group = ""
-      kind  = "Namespace"
-    }
-    cluster_resource_blacklist {
-      group = ""
-      kind  = "ResourceQuota"
-    }
-    cluster_resource_blacklist {
-      group = "*"
-      kind  = "*"
-    }
-    namespace_resource_blacklist {
-      group = "networking.k8s.io"
-      kind  = "Ingress"
-    }
-    namespace_resource_whitelist {
-      group = "*"
-      kind  = "*"
-    }
-    orphaned_resources {
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "default"
+      },
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "foo"
+      },
+    ]
+    cluster_resource_whitelist = [
+      {
+        group = "rbac.authorization.k8s.io"
+        kind  = "ClusterRoleBinding"
+      },
+      {
+        group = "rbac.authorization.k8s.io"
+        kind  = "ClusterRole"
+      },
+      {
+        group = ""
+        kind  = "Namespace"
+      },
+    ]
+    cluster_resource_blacklist = [
+      {
+        group = ""
+        kind  = "ResourceQuota"
+      },
+      {
+        group = "*"
+        kind  = "*"
+      },
+    ]
+    namespace_resource_blacklist = [
+      {
+        group = "networking.k8s.io"
+        kind  = "Ingress"
+      },
+    ]
+    namespace_resource_whitelist = [
+      {
+        group = "*"
+        kind  = "*"
+      },
+    ]
+    orphaned_resources = {
       warn = true
-      ignore {
-        group = "apps/v1"
-        kind  = "Deployment"
-        name  = "ignored1"
-      }
-      ignore {
-        group = "apps/v1"
-        kind  = "Deployment"
-        name  = "ignored2"
-      }
-    }
-    sync_window {
-      kind = "allow"
-      applications = ["api-*"]
-      clusters = ["*"]
-      namespaces = ["*"]
-      duration = "3600s"
-      schedule = "10 1 * * *"
-      manual_sync = true
-    }
-    sync_window {
-      kind = "deny"
-      applications = ["foo"]
-      clusters = ["in-cluster"]
-      namespaces = ["default"]
-      duration = "12h"
-      schedule = "22 1 5 * *"
-      manual_sync = false
-      timezone = "Europe/London"
+      ignore = [
+        {
+          group = "apps/v1"
+          kind  = "Deployment"
+          name  = "ignored1"
+        },
+        {
+          group = "apps/v1"
+          kind  = "Deployment"
+          name  = "ignored2"
+        },
+      ]
     }
+    sync_windows = [
+      {
+        kind = "allow"
+        applications = ["api-*"]
+        clusters = ["*"]
+        namespaces = ["*"]
+        duration = "3600s"
+        schedule = "10 1 * * *"
+        manual_sync = true
+      },
+      {
+        kind = "deny"
+        applications = ["foo"]
+        clusters = ["in-cluster"]
+        namespaces = ["default"]
+        duration = "12h"
+        schedule = "22 1 5 * *"
+        manual_sync = false
+        timezone = "Europe/London"
+      },
+    ]
     signature_keys = [
       "4AEE18F83AFDEB23",
       "07E34825A909B250"
@@ -352,7 +397,7 @@ resource "argocd_project" "simple" {
 func testAccArgoCDProjectSimpleWithoutOrphaned(name string) string {
 	return fmt.Sprintf(`
   resource "argocd_project" "simple" {
-    metadata {
+    metadata = {
       name      = "%s"
       namespace = "argocd"
       labels = {
@@ -362,15 +407,17 @@ func testAccArgoCDProjectSimpleWithoutOrphaned(name string) string {
         "this.is.a.really.long.nested.key" = "yes, really!"
       }
     }
-  
-    spec {
+
+    spec = {
       description  = "simple project"
       source_repos = ["*"]
-  
-      destination {
-        name      = "anothercluster"
-        namespace = "bar"
-      }
+
+      destinations = [
+        {
+          name      = "anothercluster"
+          namespace = "bar"
+        },
+      ]
     }
   }
 	`, name)
@@ -379,7 +426,7 @@ func testAccArgoCDProjectSimpleWithoutOrphaned(name string) string {
 func testAccArgoCDProjectSimpleWithEmptyOrphaned(name string) string {
 	return fmt.Sprintf(`
   resource "argocd_project" "simple" {
-    metadata {
+    metadata = {
       name      = "%s"
       namespace = "argocd"
       labels = {
@@ -389,16 +436,18 @@ func testAccArgoCDProjectSimpleWithEmptyOrphaned(name string) string {
         "this.is.a.really.long.nested.key" = "yes, really!"
       }
     }
-  
-    spec {
+
+    spec = {
       description  = "simple project"
       source_repos = ["*"]
-  
-      destination {
-        name      = "anothercluster"
-        namespace = "bar"
-      }
-      orphaned_resources { }
+
+      destinations = [
+        {
+          name      = "anothercluster"
+          namespace = "bar"
+        },
+      ]
+      orphaned_resources = {}
     }
   }
 	`, name)
@@ -407,34 +456,38 @@ func testAccArgoCDProjectSimpleWithEmptyOrphaned(name string) string {
 func testAccArgoCDProjectCoexistenceWithTokenResource(name string, count int) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "coexistence" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "coexistence"
-    destination {
-	   server    = "https://kubernetes.default.svc"
-	   namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "testrole"
-      policies = [
-        "p, proj:%s:testrole, applications, override, %s/foo, allow",
-      ]
-    }
+    roles = [
+      {
+        name = "testrole"
+        policies = [
+          "p, proj:%s:testrole, applications, override, %s/foo, allow",
+        ]
+      },
+    ]
   }
 }
 
 resource "argocd_project_token" "multiple" {
   count   = %d
-  project = argocd_project.coexistence.metadata.0.name
+  project = argocd_project.coexistence.metadata.name
   role    = "testrole"
 }
 resource "argocd_project_token" "coexistence_testrole_exp" {
-  project    = argocd_project.coexistence.metadata.0.name
+  project    = argocd_project.coexistence.metadata.name
   role       = "testrole"
   expires_in = "264h"
 }
@@ -444,24 +497,28 @@ resource "argocd_project_token" "coexistence_testrole_exp" {
 func testAccArgoCDProjectPolicyError(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "failure" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "expected policy failures"
-    destination {
-	   server    = "https://kubernetes.default.svc"
-	   namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "incorrect-policy"
-      policies = [
-        "p, proj:%s:bar, applicat, foo, %s/*, whatever",
-      ]
-    }
+    roles = [
+      {
+        name = "incorrect-policy"
+        policies = [
+          "p, proj:%s:bar, applicat, foo, %s/*, whatever",
+        ]
+      },
+    ]
   }
 }
 	`, name, name, name)
@@ -470,24 +527,28 @@ resource "argocd_project" "failure" {
 func testAccArgoCDProjectRoleNameError(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "failure" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "expected role name failure"
-    destination {
-	   server    = "https://kubernetes.default.svc"
-	   namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "incorrect role name"
-      policies = [
-        "p, proj:%s:testrole, applications, override, %s/foo, allow",
-      ]
-    }
+    roles = [
+      {
+        name = "incorrect role name"
+        policies = [
+          "p, proj:%s:testrole, applications, override, %s/foo, allow",
+        ]
+      },
+    ]
   }
 }
 	`, name, name, name)
@@ -496,33 +557,39 @@ resource "argocd_project" "failure" {
 func testAccArgoCDProjectSyncWindowScheduleError(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "failure" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "expected policy failures"
-    destination {
-	   server    = "https://kubernetes.default.svc"
-	   namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "incorrect-syncwindow"
-      policies = [
-        "p, proj:%s:testrole, applications, override, %s/foo, allow",
-      ]
-    }
-	sync_window {
-      kind = "allow"
-      applications = ["api-*"]
-      clusters = ["*"]
-      namespaces = ["*"]
-      duration = "3600s"
-      schedule = "10 1 * * * 5"
-      manual_sync = true
-    }
+    roles = [
+      {
+        name = "incorrect-syncwindow"
+        policies = [
+          "p, proj:%s:testrole, applications, override, %s/foo, allow",
+        ]
+      },
+    ]
+    sync_windows = [
+      {
+        kind = "allow"
+        applications = ["api-*"]
+        clusters = ["*"]
+        namespaces = ["*"]
+        duration = "3600s"
+        schedule = "10 1 * * * 5"
+        manual_sync = true
+      },
+    ]
   }
 }
 	`, name, name, name)
@@ -531,33 +598,39 @@ resource "argocd_project" "failure" {
 func testAccArgoCDProjectSyncWindowDurationError(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "failure" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "expected duration failure"
-    destination {
-	   server    = "https://kubernetes.default.svc"
-	   namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "incorrect-syncwindow"
-      policies = [
-        "p, proj:%s:testrole, applications, override, %s/foo, allow",
-      ]
-    }
-	sync_window {
-      kind = "allow"
-      applications = ["api-*"]
-      clusters = ["*"]
-      namespaces = ["*"]
-      duration = "123"
-      schedule = "10 1 * * *"
-      manual_sync = true
-    }
+    roles = [
+      {
+        name = "incorrect-syncwindow"
+        policies = [
+          "p, proj:%s:testrole, applications, override, %s/foo, allow",
+        ]
+      },
+    ]
+    sync_windows = [
+      {
+        kind = "allow"
+        applications = ["api-*"]
+        clusters = ["*"]
+        namespaces = ["*"]
+        duration = "123"
+        schedule = "10 1 * * *"
+        manual_sync = true
+      },
+    ]
   }
 }
 	`, name, name, name)
@@ -566,33 +639,39 @@ resource "argocd_project" "failure" {
 func testAccArgoCDProjectSyncWindowKindError(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "failure" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "expected kind failure"
-    destination {
-	   server    = "https://kubernetes.default.svc"
-	   namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "incorrect-syncwindow"
-      policies = [
-        "p, proj:%s:testrole, applications, override, %s/foo, allow",
-      ]
-    }
-	sync_window {
-      kind = "whatever"
-      applications = ["api-*"]
-      clusters = ["*"]
-      namespaces = ["*"]
-      duration = "600s"
-      schedule = "10 1 * * *"
-      manual_sync = true
-    }
+    roles = [
+      {
+        name = "incorrect-syncwindow"
+        policies = [
+          "p, proj:%s:testrole, applications, override, %s/foo, allow",
+        ]
+      },
+    ]
+    sync_windows = [
+      {
+        kind = "whatever"
+        applications = ["api-*"]
+        clusters = ["*"]
+        namespaces = ["*"]
+        duration = "600s"
+        schedule = "10 1 * * *"
+        manual_sync = true
+      },
+    ]
   }
 }
 	`, name, name, name)
@@ -601,7 +680,7 @@ resource "argocd_project" "failure" {
 func testAccArgoCDProjectSimpleWithoutRole(name string) string {
 	return fmt.Sprintf(`
   resource "argocd_project" "simple" {
-    metadata {
+    metadata = {
       name      = "%s"
       namespace = "argocd"
       labels = {
@@ -611,22 +690,26 @@ func testAccArgoCDProjectSimpleWithoutRole(name string) string {
         "this.is.a.really.long.nested.key" = "yes, really!"
       }
     }
-  
-    spec {
+
+    spec = {
       description  = "simple project"
       source_repos = ["*"]
-  
-      destination {
-        name      = "anothercluster"
-        namespace = "bar"
-      }
-      orphaned_resources {
+
+      destinations = [
+        {
+          name      = "anothercluster"
+          namespace = "bar"
+        },
+      ]
+      orphaned_resources = {
         warn = true
-        ignore {
-          group = "apps/v1"
-          kind  = "Deployment"
-          name  = "ignored1"
-        }
+        ignore = [
+          {
+            group = "apps/v1"
+            kind  = "Deployment"
+            name  = "ignored1"
+          },
+        ]
       }
     }
   }
@@ -636,7 +719,7 @@ func testAccArgoCDProjectSimpleWithoutRole(name string) string {
 func testAccArgoCDProjectSimpleWithRole(name string) string {
 	return fmt.Sprintf(`
   resource "argocd_project" "simple" {
-    metadata {
+    metadata = {
       name      = "%s"
       namespace = "argocd"
       labels = {
@@ -646,30 +729,36 @@ func testAccArgoCDProjectSimpleWithRole(name string) string {
         "this.is.a.really.long.nested.key" = "yes, really!"
       }
     }
-  
-    spec {
+
+    spec = {
       description  = "simple project"
       source_repos = ["*"]
-  
-      destination {
-        name      = "anothercluster"
-        namespace = "bar"
-      }
-      orphaned_resources {
+
+      destinations = [
+        {
+          name      = "anothercluster"
+          namespace = "bar"
+        },
+      ]
+      orphaned_resources = {
         warn = true
-        ignore {
-          group = "apps/v1"
-          kind  = "Deployment"
-          name  = "ignored1"
-        }
-      }
-      role {
-        name = "anotherrole"
-        policies = [
-          "p, proj:%s:anotherrole, applications, get, %s/*, allow",
-          "p, proj:%s:anotherrole, applications, sync, %s/*, deny",
+        ignore = [
+          {
+            group = "apps/v1"
+            kind  = "Deployment"
+            name  = "ignored1"
+          },
         ]
       }
+      roles = [
+        {
+          name = "anotherrole"
+          policies = [
+            "p, proj:%s:anotherrole, applications, get, %s/*, allow",
+            "p, proj:%s:anotherrole, applications, sync, %s/*, deny",
+          ]
+        },
+      ]
     }
   }
 	`, name, name, name, name, name)
@@ -678,7 +767,7 @@ func testAccArgoCDProjectSimpleWithRole(name string) string {
 func testAccArgoCDProjectWithClustersRepositoriesRolePolicy(name string) string {
 	return fmt.Sprintf(`
   resource "argocd_project" "simple" {
-    metadata {
+    metadata = {
       name      = "%[1]s"
       namespace = "argocd"
       labels = {
@@ -688,30 +777,36 @@ func testAccArgoCDProjectWithClustersRepositoriesRolePolicy(name string) string
         "this.is.a.really.long.nested.key" = "yes, really!"
       }
     }
-  
-    spec {
+
+    spec = {
       description  = "simple project"
       source_repos = ["*"]
-  
-      destination {
-        name      = "anothercluster"
-        namespace = "bar"
-      }
-      orphaned_resources {
+
+      destinations = [
+        {
+          name      = "anothercluster"
+          namespace = "bar"
+        },
+      ]
+      orphaned_resources = {
         warn = true
-        ignore {
-          group = "apps/v1"
-          kind  = "Deployment"
-          name  = "ignored1"
-        }
-      }
-      role {
-        name = "admin"
-        policies = [
-          "p, proj:%[1]s:admin, clusters, get, %[1]s/*, allow",
-          "p, proj:%[1]s:admin, repositories, get, %[1]s/*, allow",
+        ignore = [
+          {
+            group = "apps/v1"
+            kind  = "Deployment"
+            name  = "ignored1"
+          },
         ]
       }
+      roles = [
+        {
+          name = "admin"
+          policies = [
+            "p, proj:%[1]s:admin, clusters, get, %[1]s/*, allow",
+            "p, proj:%[1]s:admin, repositories, get, %[1]s/*, allow",
+          ]
+        },
+      ]
     }
   }
 	`, name)
@@ -720,7 +815,7 @@ func testAccArgoCDProjectWithClustersRepositoriesRolePolicy(name string) string
 func testAccArgoCDProjectWithExecLogsRolePolicy(name string) string {
 	return fmt.Sprintf(`
   resource "argocd_project" "simple" {
-    metadata {
+    metadata = {
       name      = "%[1]s"
       namespace = "argocd"
       labels = {
@@ -730,30 +825,36 @@ func testAccArgoCDProjectWithExecLogsRolePolicy(name string) string {
         "this.is.a.really.long.nested.key" = "yes, really!"
       }
     }
-  
-    spec {
+
+    spec = {
       description  = "simple project"
       source_repos = ["*"]
-  
-      destination {
-        name      = "anothercluster"
-        namespace = "bar"
-      }
-      orphaned_resources {
+
+      destinations = [
+        {
+          name      = "anothercluster"
+          namespace = "bar"
+        },
+      ]
+      orphaned_resources = {
         warn = true
-        ignore {
-          group = "apps/v1"
-          kind  = "Deployment"
-          name  = "ignored1"
-        }
-      }
-      role {
-        name = "admin"
-        policies = [
-          "p, proj:%[1]s:admin, exec, create, %[1]s/*, allow",
-          "p, proj:%[1]s:admin, logs, get, %[1]s/*, allow",
+        ignore = [
+          {
+            group = "apps/v1"
+            kind  = "Deployment"
+            name  = "ignored1"
+          },
         ]
       }
+      roles = [
+        {
+          name = "admin"
+          policies = [
+            "p, proj:%[1]s:admin, exec, create, %[1]s/*, allow",
+            "p, proj:%[1]s:admin, logs, get, %[1]s/*, allow",
+          ]
+        },
+      ]
     }
   }
 	`, name)
@@ -762,7 +863,7 @@ func testAccArgoCDProjectWithExecLogsRolePolicy(name string) string {
 func testAccArgoCDProjectWithSourceNamespaces(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "simple" {
-  metadata {
+  metadata = {
     name      = "%s"
     namespace = "argocd"
     labels = {
@@ -773,63 +874,110 @@ resource "argocd_project" "simple" {
     }
   }
 
-  spec {
+  spec = {
     description  = "simple project"
     source_repos = ["*"]
     source_namespaces = ["*"]
 
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "default"
-    }
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "foo"
-    }
-    orphaned_resources {
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "default"
+      },
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "foo"
+      },
+    ]
+    orphaned_resources = {
       warn = true
-      ignore {
-        group = "apps/v1"
-        kind  = "Deployment"
-        name  = "ignored1"
-      }
+      ignore = [
+        {
+          group = "apps/v1"
+          kind  = "Deployment"
+          name  = "ignored1"
+        },
+      ]
     }
   }
 }
 	`, name)
 }
 
+func testAccArgoCDProjectWithDefaultMetadata(name, team string) string {
+	return fmt.Sprintf(`
+provider "argocd" {
+  default_metadata {
+    labels = {
+      team = "%s"
+    }
+    annotations = {
+      "example.com/owner" = "platform"
+    }
+  }
+}
+
+resource "argocd_project" "simple" {
+  metadata = {
+    name      = "%s"
+    namespace = "argocd"
+    labels = {
+      acceptance = "true"
+    }
+  }
+
+  spec = {
+    description  = "simple project"
+    source_repos = ["*"]
+
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "default"
+      },
+    ]
+  }
+}
+	`, team, name)
+}
+
 func testAccArgoCDProjectSyncWindowTimezoneError(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "failure" {
-  metadata {
+  metadata = {
     name        = "%s"
     namespace   = "argocd"
   }
 
-  spec {
+  spec = {
     description = "expected timezone failure"
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "*"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
     source_repos = ["*"]
-    role {
-      name = "incorrect-syncwindow"
-      policies = [
-      "p, proj:%s:testrole, applications, override, %s/foo, allow",
-      ]
-    }
-    sync_window {
-      kind = "allow"
-      applications = ["api-*"]
-      clusters = ["*"]
-      namespaces = ["*"]
-      duration = "1h"
-      schedule = "10 1 * * *"
-      manual_sync = true
-      timezone = "invalid"
-    }
+    roles = [
+      {
+        name = "incorrect-syncwindow"
+        policies = [
+          "p, proj:%s:testrole, applications, override, %s/foo, allow",
+        ]
+      },
+    ]
+    sync_windows = [
+      {
+        kind = "allow"
+        applications = ["api-*"]
+        clusters = ["*"]
+        namespaces = ["*"]
+        duration = "1h"
+        schedule = "10 1 * * *"
+        manual_sync = true
+        timezone = "invalid"
+      },
+    ]
   }
 }
   `, name, name, name)
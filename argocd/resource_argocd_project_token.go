@@ -4,14 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
-	"sync"
 	"time"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 	"github.com/cristalhq/jwt/v3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 )
 
@@ -168,10 +167,6 @@ func resourceArgoCDProjectTokenCreate(ctx context.Context, d *schema.ResourceDat
 		Role:    role,
 	}
 
-	if _, ok := tokenMutexProjectMap[projectName]; !ok {
-		tokenMutexProjectMap[projectName] = &sync.RWMutex{}
-	}
-
 	if d, ok := d.GetOk("description"); ok {
 		opts.Description = d.(string)
 	}
@@ -208,9 +203,9 @@ func resourceArgoCDProjectTokenCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	tokenMutexProjectMap[projectName].Lock()
+	si.Locks.Project(projectName).Lock()
 	resp, err := si.ProjectClient.CreateToken(ctx, opts)
-	tokenMutexProjectMap[projectName].Unlock()
+	si.Locks.Project(projectName).Unlock()
 
 	if err != nil {
 		return argoCDAPIError("create", "token for project", projectName, err)
@@ -280,19 +275,16 @@ func resourceArgoCDProjectTokenRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	projectName := d.Get("project").(string)
-	if _, ok := tokenMutexProjectMap[projectName]; !ok {
-		tokenMutexProjectMap[projectName] = &sync.RWMutex{}
-	}
 
 	// Delete token from state if project has been deleted in an out-of-band fashion
-	tokenMutexProjectMap[projectName].RLock()
+	si.Locks.Project(projectName).RLock()
 	p, err := si.ProjectClient.Get(ctx, &project.ProjectQuery{
 		Name: projectName,
 	})
-	tokenMutexProjectMap[projectName].RUnlock()
+	si.Locks.Project(projectName).RUnlock()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -300,13 +292,13 @@ func resourceArgoCDProjectTokenRead(ctx context.Context, d *schema.ResourceData,
 		return argoCDAPIError("read", "project", projectName, err)
 	}
 
-	tokenMutexProjectMap[projectName].RLock()
+	si.Locks.Project(projectName).RLock()
 	token, _, err := p.GetJWTToken(
 		d.Get("role").(string),
 		0,
 		d.Id(),
 	)
-	tokenMutexProjectMap[projectName].RUnlock()
+	si.Locks.Project(projectName).RUnlock()
 
 	if err != nil {
 		// Token has been deleted in an out-of-band fashion
@@ -364,11 +356,7 @@ func resourceArgoCDProjectTokenDelete(ctx context.Context, d *schema.ResourceDat
 
 	projectName := d.Get("project").(string)
 
-	if _, ok := tokenMutexProjectMap[projectName]; !ok {
-		tokenMutexProjectMap[projectName] = &sync.RWMutex{}
-	}
-
-	tokenMutexProjectMap[projectName].Lock()
+	si.Locks.Project(projectName).Lock()
 
 	_, err := si.ProjectClient.DeleteToken(ctx, &project.ProjectTokenDeleteRequest{
 		Id:      d.Id(),
@@ -376,7 +364,7 @@ func resourceArgoCDProjectTokenDelete(ctx context.Context, d *schema.ResourceDat
 		Role:    d.Get("role").(string),
 	})
 
-	tokenMutexProjectMap[projectName].Unlock()
+	si.Locks.Project(projectName).Unlock()
 
 	if err != nil {
 		return argoCDAPIError("delete", "token for project", projectName, err)
@@ -4,13 +4,13 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	application "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 )
 
@@ -40,7 +40,7 @@ func resourceArgoCDRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-		tokenMutexConfiguration.Lock()
+		si.Locks.ConfigMap().Lock()
 
 		var r *application.Repository
 
@@ -51,7 +51,7 @@ func resourceArgoCDRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 				Upsert: false,
 			},
 		)
-		tokenMutexConfiguration.Unlock()
+		si.Locks.ConfigMap().Unlock()
 
 		if err != nil {
 			// TODO: better way to detect ssh handshake failing ?
@@ -82,16 +82,33 @@ func resourceArgoCDRepositoryRead(ctx context.Context, d *schema.ResourceData, m
 		return pluginSDKDiags(diags)
 	}
 
-	tokenMutexConfiguration.RLock()
-	r, err := si.RepositoryClient.Get(ctx, &repository.RepoQuery{
-		Repo:         d.Id(),
-		ForceRefresh: true,
-	})
-	tokenMutexConfiguration.RUnlock()
+	var (
+		r   *application.Repository
+		ok  bool
+		err error
+	)
+
+	si.Locks.ConfigMap().RLock()
+
+	// Repositories listed by the read cache lack their GitHub App settings,
+	// hence those using (or possibly using, e.g. when imported) a GitHub App
+	// are always read directly.
+	if d.Get("repo").(string) != "" && d.Get("githubapp_id").(string) == "" {
+		r, ok = si.CachedRepository(ctx, d.Id())
+	}
+
+	if !ok {
+		r, err = si.RepositoryClient.Get(ctx, &repository.RepoQuery{
+			Repo:         d.Id(),
+			ForceRefresh: true,
+		})
+	}
+
+	si.Locks.ConfigMap().RUnlock()
 
 	if err != nil {
 		// Repository has already been deleted in an out-of-band fashion
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -117,12 +134,12 @@ func resourceArgoCDRepositoryUpdate(ctx context.Context, d *schema.ResourceData,
 		return errorToDiagnostics(fmt.Sprintf("failed to expand repository %s", d.Id()), err)
 	}
 
-	tokenMutexConfiguration.Lock()
+	si.Locks.ConfigMap().Lock()
 	r, err := si.RepositoryClient.UpdateRepository(
 		ctx,
 		&repository.RepoUpdateRequest{Repo: repo},
 	)
-	tokenMutexConfiguration.Unlock()
+	si.Locks.ConfigMap().Unlock()
 
 	if err != nil {
 		return argoCDAPIError("update", "repository", repo.Repo, err)
@@ -152,15 +169,15 @@ func resourceArgoCDRepositoryDelete(ctx context.Context, d *schema.ResourceData,
 		return pluginSDKDiags(diags)
 	}
 
-	tokenMutexConfiguration.Lock()
+	si.Locks.ConfigMap().Lock()
 	_, err := si.RepositoryClient.DeleteRepository(
 		ctx,
 		&repository.RepoQuery{Repo: d.Id()},
 	)
-	tokenMutexConfiguration.Unlock()
+	si.Locks.ConfigMap().Unlock()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			// Repository has already been deleted in an out-of-band fashion
 			d.SetId("")
 			return nil
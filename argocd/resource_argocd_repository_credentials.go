@@ -3,12 +3,12 @@ package argocd
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
 	application "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 )
 
@@ -40,7 +40,7 @@ func resourceArgoCDRepositoryCredentialsCreate(ctx context.Context, d *schema.Re
 		return errorToDiagnostics("failed to expand repository credentials", err)
 	}
 
-	tokenMutexConfiguration.Lock()
+	si.Locks.ConfigMap().Lock()
 	rc, err := si.RepoCredsClient.CreateRepositoryCredentials(
 		ctx,
 		&repocreds.RepoCredsCreateRequest{
@@ -48,7 +48,7 @@ func resourceArgoCDRepositoryCredentialsCreate(ctx context.Context, d *schema.Re
 			Upsert: false,
 		},
 	)
-	tokenMutexConfiguration.Unlock()
+	si.Locks.ConfigMap().Unlock()
 
 	if err != nil {
 		return argoCDAPIError("create", "repository credentials", repoCreds.URL, err)
@@ -65,11 +65,11 @@ func resourceArgoCDRepositoryCredentialsRead(ctx context.Context, d *schema.Reso
 		return pluginSDKDiags(diags)
 	}
 
-	tokenMutexConfiguration.RLock()
+	si.Locks.ConfigMap().RLock()
 	rcl, err := si.RepoCredsClient.ListRepositoryCredentials(ctx, &repocreds.RepoCredsQuery{
 		Url: d.Id(),
 	})
-	tokenMutexConfiguration.RUnlock()
+	si.Locks.ConfigMap().RUnlock()
 
 	if err != nil {
 		return argoCDAPIError("read", "repository credentials", d.Id(), err)
@@ -108,13 +108,13 @@ func resourceArgoCDRepositoryCredentialsUpdate(ctx context.Context, d *schema.Re
 		return errorToDiagnostics(fmt.Sprintf("failed to expand repository credentials %s", d.Id()), err)
 	}
 
-	tokenMutexConfiguration.Lock()
+	si.Locks.ConfigMap().Lock()
 	r, err := si.RepoCredsClient.UpdateRepositoryCredentials(
 		ctx,
 		&repocreds.RepoCredsUpdateRequest{
 			Creds: repoCreds},
 	)
-	tokenMutexConfiguration.Unlock()
+	si.Locks.ConfigMap().Unlock()
 
 	if err != nil {
 		return argoCDAPIError("update", "repository credentials", repoCreds.URL, err)
@@ -131,15 +131,15 @@ func resourceArgoCDRepositoryCredentialsDelete(ctx context.Context, d *schema.Re
 		return pluginSDKDiags(diags)
 	}
 
-	tokenMutexConfiguration.Lock()
+	si.Locks.ConfigMap().Lock()
 	_, err := si.RepoCredsClient.DeleteRepositoryCredentials(
 		ctx,
 		&repocreds.RepoCredsDeleteRequest{Url: d.Id()},
 	)
-	tokenMutexConfiguration.Unlock()
+	si.Locks.ConfigMap().Unlock()
 
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if diagnostics.IsNotFound(err) {
 			// Repository credentials have already been deleted in an out-of-band fashion
 			d.SetId("")
 			return nil
@@ -12,8 +12,8 @@ import (
 
 func TestAccArgoCDRepository_Simple(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDRepositorySimple(),
@@ -43,8 +43,8 @@ func TestAccArgoCDRepository_Helm(t *testing.T) {
 	projectName := acctest.RandString(10)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDRepositoryHelm(),
@@ -176,19 +176,21 @@ resource "argocd_repository" "helm" {
 func testAccArgoCDRepositoryHelmProjectScoped(project string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "simple" {
-  metadata {
+  metadata = {
     name      = "%[1]s"
     namespace = "argocd"
   }
 
-  spec {
+  spec = {
     description  = "simple project"
     source_repos = ["*"]
-    
-	destination {
-      name      = "anothercluster"
-      namespace = "bar"
-    }
+
+    destinations = [
+      {
+        name      = "anothercluster"
+        namespace = "bar"
+      },
+    ]
   }
 }
 
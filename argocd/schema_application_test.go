@@ -1,548 +1,109 @@
 package argocd
 
 import (
-	"context"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func TestUpgradeSchemaApplication_V0V1_Default_SkipCrds(t *testing.T) {
+func TestApplicationSpecSchemaV4_ExpandFlattenRoundTrip(t *testing.T) {
 	t.Parallel()
 
-	v0 := map[string]interface{}{
-		"spec": []interface{}{
-			map[string]interface{}{
-				"source": []interface{}{map[string]interface{}{
-					"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-					"chart":           "redis",
-					"target_revision": "16.9.11",
+	destination := []interface{}{map[string]interface{}{
+		"server":    "https://kubernetes.default.svc",
+		"namespace": "default",
+	}}
 
-					"helm": []interface{}{map[string]interface{}{
-						"release_name": "testing",
+	cases := []struct {
+		name    string
+		sources []interface{}
+	}{
+		{
+			name: "helm",
+			sources: []interface{}{map[string]interface{}{
+				"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
+				"chart":           "redis",
+				"target_revision": "16.9.11",
+				"helm": []interface{}{map[string]interface{}{
+					"release_name":               "testing",
+					"values":                     "architecture: standalone\n",
+					"value_files":                []interface{}{"values.yaml", "values-prod.yaml"},
+					"ignore_missing_value_files": true,
+					"pass_credentials":           true,
+					"skip_crds":                  true,
+					"skip_tests":                 true,
+					"namespace":                  "redis",
+					"kube_version":               "1.29.0",
+					"api_versions":               []interface{}{"monitoring.coreos.com/v1/ServiceMonitor", "v1/Service"},
+					"parameter": []interface{}{map[string]interface{}{
+						"name":         "image.tag",
+						"value":        "6.2.5",
+						"force_string": true,
 					}},
-				}},
-				"destination": []interface{}{map[string]interface{}{
-					"server":    "https://kubernetes.default.svc",
-					"namespace": "default",
-				}},
-			},
-		},
-	}
-
-	v1 := map[string]interface{}{
-		"spec": []interface{}{
-			map[string]interface{}{
-				"source": []interface{}{map[string]interface{}{
-					"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-					"chart":           "redis",
-					"target_revision": "16.9.11",
-
-					"helm": []interface{}{map[string]interface{}{
-						"release_name": "testing",
-						"skip_crds":    false,
+					"file_parameter": []interface{}{map[string]interface{}{
+						"name": "config",
+						"path": "files/config.json",
 					}},
 				}},
-				"destination": []interface{}{map[string]interface{}{
-					"server":    "https://kubernetes.default.svc",
-					"namespace": "default",
-				}},
-			},
-		},
-	}
-
-	actual, _ := resourceArgoCDApplicationStateUpgradeV0(context.TODO(), v0, nil)
-
-	if !reflect.DeepEqual(v1, actual) {
-		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", v1, actual)
-	}
-}
-
-func TestUpgradeSchemaApplication_V0V1_Default_SkipCrds_NoChange(t *testing.T) {
-	t.Parallel()
-
-	v0 := map[string]interface{}{
-		"spec": []interface{}{
-			map[string]interface{}{
-				"source": []interface{}{map[string]interface{}{
-					"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-					"chart":           "redis",
-					"target_revision": "16.9.11",
-				}},
-				"destination": []interface{}{map[string]interface{}{
-					"server":    "https://kubernetes.default.svc",
-					"namespace": "default",
-				}},
-			},
+			}},
 		},
-	}
-
-	actual, _ := resourceArgoCDApplicationStateUpgradeV0(context.TODO(), v0, nil)
-
-	if !reflect.DeepEqual(v0, actual) {
-		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", v0, actual)
-	}
-}
-
-func TestUpgradeSchemaApplication_V1V2_Default_NoChange(t *testing.T) {
-	t.Parallel()
-
-	v1 := map[string]interface{}{
-		"spec": []interface{}{
-			map[string]interface{}{
-				"source": []interface{}{map[string]interface{}{
-					"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-					"chart":           "redis",
-					"target_revision": "16.9.11",
-
-					"helm": []interface{}{map[string]interface{}{
-						"release_name": "testing",
-						"skip_crds":    false,
-					}},
-				}},
-				"destination": []interface{}{map[string]interface{}{
-					"server":    "https://kubernetes.default.svc",
-					"namespace": "default",
-				}},
-			},
-		},
-	}
-
-	actual, _ := resourceArgoCDApplicationStateUpgradeV1(context.TODO(), v1, nil)
-
-	if !reflect.DeepEqual(v1, actual) {
-		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", v1, actual)
-	}
-}
-
-func TestUpgradeSchemaApplication_V1V2_WithKsonnet(t *testing.T) {
-	t.Parallel()
-
-	v1 := map[string]interface{}{
-		"spec": []interface{}{
-			map[string]interface{}{
-				"source": []interface{}{map[string]interface{}{
-					"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-					"chart":           "redis",
-					"target_revision": "16.9.11",
-
-					"ksonnet": []interface{}{map[string]interface{}{
-						"destination": []interface{}{map[string]interface{}{
-							"namespace": "foo",
-						}},
-					}},
-				}},
-				"destination": []interface{}{map[string]interface{}{
-					"server":    "https://kubernetes.default.svc",
-					"namespace": "default",
+		{
+			name: "helm values object",
+			sources: []interface{}{map[string]interface{}{
+				"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
+				"chart":           "redis",
+				"target_revision": "16.9.11",
+				"helm": []interface{}{map[string]interface{}{
+					"values_object": `{"architecture":"standalone","image":{"tag":"6.2.5"}}`,
 				}},
-			},
+			}},
 		},
-	}
-
-	_, err := resourceArgoCDApplicationStateUpgradeV1(context.TODO(), v1, nil)
-
-	if err == nil || !strings.Contains(err.Error(), "'ksonnet' support has been removed") {
-		t.Fatalf("\n\nexpected error during state migration was not found - err returned was: %v", err)
-	}
-}
-
-func TestUpgradeSchemaApplication_V2V3_Default_NoChange(t *testing.T) {
-	t.Parallel()
-
-	v2 := map[string]interface{}{
-		"metadata": []interface{}{
-			map[string]interface{}{
-				"name":      "test",
-				"namespace": "argocd",
-			},
-		},
-		"spec": []interface{}{
-			map[string]interface{}{
-				"source": []interface{}{map[string]interface{}{
+		{
+			name: "helm value files from referenced source",
+			sources: []interface{}{
+				map[string]interface{}{
 					"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
 					"chart":           "redis",
 					"target_revision": "16.9.11",
-
 					"helm": []interface{}{map[string]interface{}{
-						"release_name": "testing",
-						"skip_crds":    false,
+						"value_files": []interface{}{"$values/helm/redis/values.yaml"},
 					}},
-				}},
-				"destination": []interface{}{map[string]interface{}{
-					"server":    "https://kubernetes.default.svc",
-					"namespace": "default",
-				}},
-			},
-		},
-	}
-
-	actual, _ := resourceArgoCDApplicationStateUpgradeV2(context.TODO(), v2, nil)
-
-	if !reflect.DeepEqual(v2, actual) {
-		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", v2, actual)
-	}
-}
-func TestUpgradeSchemaApplication_V3V4(t *testing.T) {
-	t.Parallel()
-
-	type stateUpgradeTestCases []struct {
-		name          string
-		sourceState   map[string]interface{}
-		expectedState map[string]interface{}
-	}
-
-	cases := stateUpgradeTestCases{
-		{
-			name: "no sync policy",
-			sourceState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-					},
-				},
-			},
-			expectedState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-					},
-				},
-			},
-		},
-		{
-			name: "full sync policy",
-			sourceState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"automated": map[string]interface{}{
-								"prune":       true,
-								"self_heal":   true,
-								"allow_empty": true,
-							},
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-								"backoff": map[string]interface{}{
-									"duration":     "30s",
-									"max_duration": "2m",
-									"factor":       "2",
-								},
-							}},
-						}},
-					},
-				},
-			},
-			expectedState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"automated": []map[string]interface{}{
-								{
-									"prune":       true,
-									"self_heal":   true,
-									"allow_empty": true,
-								},
-							},
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-								"backoff": []map[string]interface{}{
-									{
-										"duration":     "30s",
-										"max_duration": "2m",
-										"factor":       "2",
-									},
-								},
-							}},
-						}},
-					},
-				},
-			},
-		},
-		{
-			name: "no automated block",
-			sourceState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-								"backoff": map[string]interface{}{
-									"duration":     "30s",
-									"max_duration": "2m",
-									"factor":       "2",
-								},
-							}},
-						}},
-					},
-				},
-			},
-			expectedState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-								"backoff": []map[string]interface{}{
-									{
-										"duration":     "30s",
-										"max_duration": "2m",
-										"factor":       "2",
-									},
-								},
-							}},
-						}},
-					},
-				},
-			},
-		},
-		{
-			name: "blank automated block",
-			sourceState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"automated": map[string]interface{}{},
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-								"backoff": map[string]interface{}{
-									"duration":     "30s",
-									"max_duration": "2m",
-									"factor":       "2",
-								},
-							}},
-						}},
-					},
-				},
-			},
-			expectedState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
 				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"automated": []map[string]interface{}{{}},
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-								"backoff": []map[string]interface{}{
-									{
-										"duration":     "30s",
-										"max_duration": "2m",
-										"factor":       "2",
-									},
-								},
-							}},
-						}},
-					},
+				map[string]interface{}{
+					"repo_url":        "https://github.com/argoproj/argocd-example-apps.git",
+					"target_revision": "HEAD",
+					"ref":             "values",
 				},
 			},
 		},
 		{
-			name: "no backoff",
-			sourceState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"automated": map[string]interface{}{
-								"prune":       true,
-								"self_heal":   true,
-								"allow_empty": true,
-							},
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-							}},
-						}},
-					},
-				},
-			},
-			expectedState: map[string]interface{}{
-				"metadata": []interface{}{
-					map[string]interface{}{
-						"name":      "test",
-						"namespace": "argocd",
-					},
-				},
-				"spec": []interface{}{
-					map[string]interface{}{
-						"source": []interface{}{map[string]interface{}{
-							"repo_url":        "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami",
-							"chart":           "redis",
-							"target_revision": "16.9.11",
-						}},
-						"destination": []interface{}{map[string]interface{}{
-							"server":    "https://kubernetes.default.svc",
-							"namespace": "default",
-						}},
-						"sync_policy": []interface{}{map[string]interface{}{
-							"automated": []map[string]interface{}{
-								{
-									"prune":       true,
-									"self_heal":   true,
-									"allow_empty": true,
-								},
-							},
-							"sync_options": []string{
-								"Validate=false",
-							},
-							"retry": []interface{}{map[string]interface{}{
-								"limit": "5",
-							}},
+			name: "kustomize",
+			sources: []interface{}{map[string]interface{}{
+				"repo_url":        "https://github.com/kubernetes-sigs/kustomize",
+				"path":            "examples/helloWorld",
+				"target_revision": "release-kustomize-v3.7",
+				"kustomize": []interface{}{map[string]interface{}{
+					"name_prefix":         "foo-",
+					"namespace":           "bar",
+					"components":          []interface{}{"../components/ingress", "../components/tls"},
+					"force_common_labels": true,
+					"common_labels":       map[string]interface{}{"app": "hello"},
+					"replicas": []interface{}{map[string]interface{}{
+						"name":  "the-deployment",
+						"count": "2",
+					}},
+					"patches": []interface{}{map[string]interface{}{
+						"patch":   "- op: replace\n  path: /metadata/name\n  value: renamed\n",
+						"options": map[string]interface{}{"allowNameChange": true},
+						"target": []interface{}{map[string]interface{}{
+							"kind": "Deployment",
+							"name": "the-deployment",
 						}},
-					},
-				},
-			},
+					}},
+				}},
+			}},
 		},
 	}
 
@@ -552,13 +113,51 @@ func TestUpgradeSchemaApplication_V3V4(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			actualState, err := resourceArgoCDApplicationStateUpgradeV3(context.TODO(), tc.sourceState, nil)
+			s := map[string]*schema.Schema{
+				"spec": applicationSpecSchemaV4(false),
+			}
+			raw := map[string]interface{}{
+				"spec": []interface{}{map[string]interface{}{
+					"source":      tc.sources,
+					"destination": destination,
+				}},
+			}
+
+			d := schema.TestResourceDataRaw(t, s, raw)
+
+			spec, err := expandApplicationSpec(d.Get("spec.0").(map[string]interface{}))
 			if err != nil {
-				t.Fatalf("error migrating state: %s", err)
+				t.Fatalf("expandApplicationSpec() error = %v", err)
 			}
-			if !reflect.DeepEqual(actualState, tc.expectedState) {
-				t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", tc.expectedState, actualState)
+
+			flattened := schema.TestResourceDataRaw(t, s, map[string]interface{}{})
+			if err := flattened.Set("spec", flattenApplicationSpec(spec)); err != nil {
+				t.Fatalf("failed to set flattened spec: %v", err)
+			}
+
+			// Compare flattened state attributes since sets cannot be compared
+			// using reflect.DeepEqual
+			d.SetId("test")
+			flattened.SetId("test")
+
+			expected := sourceStateAttributes(d)
+			got := sourceStateAttributes(flattened)
+
+			if !reflect.DeepEqual(expected, got) {
+				t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, got)
 			}
 		})
 	}
 }
+
+func sourceStateAttributes(d *schema.ResourceData) map[string]string {
+	result := make(map[string]string)
+
+	for k, v := range d.State().Attributes {
+		if strings.HasPrefix(k, "spec.0.source.") {
+			result[k] = v
+		}
+	}
+
+	return result
+}
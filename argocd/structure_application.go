@@ -1,23 +1,16 @@
 package argocd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	application "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Expand
 
-func expandApplication(d *schema.ResourceData) (metadata meta.ObjectMeta, spec application.ApplicationSpec, err error) {
-	metadata = expandMetadata(d)
-	spec, err = expandApplicationSpec(d.Get("spec.0").(map[string]interface{}))
-
-	return
-}
-
 func expandApplicationSpec(s map[string]interface{}) (spec application.ApplicationSpec, err error) {
 	if v, ok := s["project"]; ok {
 		spec.Project = v.(string)
@@ -51,13 +44,16 @@ func expandApplicationSpec(s map[string]interface{}) (spec application.Applicati
 	}
 
 	if v, ok := s["source"].([]interface{}); ok && len(v) > 0 {
-		spec.Sources = expandApplicationSource(v)
+		spec.Sources, err = expandApplicationSource(v)
+		if err != nil {
+			return
+		}
 	}
 
 	return spec, nil
 }
 
-func expandApplicationSource(_ass []interface{}) []application.ApplicationSource {
+func expandApplicationSource(_ass []interface{}) ([]application.ApplicationSource, error) {
 	ass := make([]application.ApplicationSource, len(_ass))
 
 	for i, v := range _ass {
@@ -85,7 +81,12 @@ func expandApplicationSource(_ass []interface{}) []application.ApplicationSource
 		}
 
 		if v, ok := as["helm"]; ok {
-			s.Helm = expandApplicationSourceHelm(v.([]interface{}))
+			helm, err := expandApplicationSourceHelm(v.([]interface{}))
+			if err != nil {
+				return nil, err
+			}
+
+			s.Helm = helm
 		}
 
 		if v, ok := as["kustomize"]; ok {
@@ -103,7 +104,7 @@ func expandApplicationSource(_ass []interface{}) []application.ApplicationSource
 		ass[i] = s
 	}
 
-	return ass
+	return ass, nil
 }
 
 func expandApplicationSourcePlugin(in []interface{}) *application.ApplicationSourcePlugin {
@@ -234,12 +235,83 @@ func expandApplicationSourceKustomize(in []interface{}) *application.Application
 		}
 	}
 
+	if v, ok := a["force_common_labels"]; ok {
+		result.ForceCommonLabels = v.(bool)
+	}
+
+	if v, ok := a["namespace"]; ok {
+		result.Namespace = v.(string)
+	}
+
+	if v, ok := a["components"]; ok {
+		for _, c := range v.([]interface{}) {
+			result.Components = append(result.Components, c.(string))
+		}
+	}
+
+	if v, ok := a["replicas"]; ok {
+		for _, r := range v.([]interface{}) {
+			rm := r.(map[string]interface{})
+
+			result.Replicas = append(result.Replicas, application.KustomizeReplica{
+				Name:  rm["name"].(string),
+				Count: intstr.Parse(rm["count"].(string)),
+			})
+		}
+	}
+
+	if v, ok := a["patches"]; ok {
+		for _, p := range v.([]interface{}) {
+			result.Patches = append(result.Patches, expandApplicationSourceKustomizePatch(p.(map[string]interface{})))
+		}
+	}
+
+	return result
+}
+
+func expandApplicationSourceKustomizePatch(in map[string]interface{}) application.KustomizePatch {
+	result := application.KustomizePatch{}
+
+	if v, ok := in["patch"]; ok {
+		result.Patch = v.(string)
+	}
+
+	if v, ok := in["path"]; ok {
+		result.Path = v.(string)
+	}
+
+	if opts, ok := in["options"]; ok && len(opts.(map[string]interface{})) > 0 {
+		result.Options = make(map[string]bool)
+
+		for k, v := range opts.(map[string]interface{}) {
+			result.Options[k] = v.(bool)
+		}
+	}
+
+	if v, ok := in["target"]; ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		t := v.([]interface{})[0].(map[string]interface{})
+
+		result.Target = &application.KustomizeSelector{
+			KustomizeResId: application.KustomizeResId{
+				KustomizeGvk: application.KustomizeGvk{
+					Group:   t["group"].(string),
+					Version: t["version"].(string),
+					Kind:    t["kind"].(string),
+				},
+				Name:      t["name"].(string),
+				Namespace: t["namespace"].(string),
+			},
+			AnnotationSelector: t["annotation_selector"].(string),
+			LabelSelector:      t["label_selector"].(string),
+		}
+	}
+
 	return result
 }
 
-func expandApplicationSourceHelm(in []interface{}) *application.ApplicationSourceHelm {
+func expandApplicationSourceHelm(in []interface{}) (*application.ApplicationSourceHelm, error) {
 	if len(in) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	result := &application.ApplicationSourceHelm{}
@@ -255,6 +327,14 @@ func expandApplicationSourceHelm(in []interface{}) *application.ApplicationSourc
 		result.Values = v.(string)
 	}
 
+	if v, ok := a["values_object"]; ok && v.(string) != "" {
+		if result.Values != "" {
+			return nil, fmt.Errorf("helm `values` and `values_object` are mutually exclusive")
+		}
+
+		result.ValuesObject = &runtime.RawExtension{Raw: []byte(v.(string))}
+	}
+
 	if v, ok := a["release_name"]; ok {
 		result.ReleaseName = v.(string)
 	}
@@ -311,7 +391,25 @@ func expandApplicationSourceHelm(in []interface{}) *application.ApplicationSourc
 		result.SkipCrds = v.(bool)
 	}
 
-	return result
+	if v, ok := a["skip_tests"]; ok {
+		result.SkipTests = v.(bool)
+	}
+
+	if v, ok := a["namespace"]; ok {
+		result.Namespace = v.(string)
+	}
+
+	if v, ok := a["kube_version"]; ok {
+		result.KubeVersion = v.(string)
+	}
+
+	if v, ok := a["api_versions"]; ok {
+		for _, av := range v.([]interface{}) {
+			result.APIVersions = append(result.APIVersions, av.(string))
+		}
+	}
+
+	return result, nil
 }
 
 func expandApplicationSyncPolicy(sp interface{}) (*application.SyncPolicy, error) {
@@ -483,14 +581,6 @@ func expandApplicationInfo(infos *schema.Set) (result []application.Info, err er
 	return
 }
 
-func expandApplicationDestinations(ds *schema.Set) (result []application.ApplicationDestination) {
-	for _, dest := range ds.List() {
-		result = append(result, expandApplicationDestination(dest))
-	}
-
-	return
-}
-
 func expandApplicationDestination(dest interface{}) (result application.ApplicationDestination) {
 	d, ok := dest.(map[string]interface{})
 	if !ok {
@@ -504,49 +594,8 @@ func expandApplicationDestination(dest interface{}) (result application.Applicat
 	}
 }
 
-func expandSyncWindows(sws []interface{}) (result []*application.SyncWindow) {
-	for _, _sw := range sws {
-		sw := _sw.(map[string]interface{})
-
-		result = append(result, &application.SyncWindow{
-			Applications: expandStringList(sw["applications"].([]interface{})),
-			Clusters:     expandStringList(sw["clusters"].([]interface{})),
-			Duration:     sw["duration"].(string),
-			Kind:         sw["kind"].(string),
-			ManualSync:   sw["manual_sync"].(bool),
-			Namespaces:   expandStringList(sw["namespaces"].([]interface{})),
-			Schedule:     sw["schedule"].(string),
-			TimeZone:     sw["timezone"].(string),
-		})
-	}
-
-	return
-}
-
 // Flatten
 
-func flattenApplication(app *application.Application, d *schema.ResourceData) error {
-	metadata := flattenMetadata(app.ObjectMeta, d)
-	if err := d.Set("metadata", metadata); err != nil {
-		e, _ := json.MarshalIndent(metadata, "", "\t")
-		return fmt.Errorf("error persisting metadata: %s\n%s", err, e)
-	}
-
-	spec := flattenApplicationSpec(app.Spec)
-	if err := d.Set("spec", spec); err != nil {
-		e, _ := json.MarshalIndent(spec, "", "\t")
-		return fmt.Errorf("error persisting spec: %s\n%s", err, e)
-	}
-
-	status := flattenApplicationStatus(app.Status)
-	if err := d.Set("status", status); err != nil {
-		e, _ := json.MarshalIndent(status, "", "\t")
-		return fmt.Errorf("error persisting status: %s\n%s", err, e)
-	}
-
-	return nil
-}
-
 func flattenApplicationSpec(s application.ApplicationSpec) []map[string]interface{} {
 	spec := map[string]interface{}{
 		"destination":       flattenApplicationDestinations([]application.ApplicationDestination{s.Destination}),
@@ -745,13 +794,31 @@ func flattenApplicationSourceKustomize(as []*application.ApplicationSourceKustom
 				images = append(images, string(i))
 			}
 
+			var replicas []map[string]interface{}
+			for _, r := range a.Replicas {
+				replicas = append(replicas, map[string]interface{}{
+					"count": r.Count.String(),
+					"name":  r.Name,
+				})
+			}
+
+			var patches []map[string]interface{}
+			for _, p := range a.Patches {
+				patches = append(patches, flattenApplicationSourceKustomizePatch(p))
+			}
+
 			result = append(result, map[string]interface{}{
-				"common_annotations": a.CommonAnnotations,
-				"common_labels":      a.CommonLabels,
-				"images":             images,
-				"name_prefix":        a.NamePrefix,
-				"name_suffix":        a.NameSuffix,
-				"version":            a.Version,
+				"common_annotations":  a.CommonAnnotations,
+				"common_labels":       a.CommonLabels,
+				"components":          a.Components,
+				"force_common_labels": a.ForceCommonLabels,
+				"images":              images,
+				"name_prefix":         a.NamePrefix,
+				"name_suffix":         a.NameSuffix,
+				"namespace":           a.Namespace,
+				"patches":             patches,
+				"replicas":            replicas,
+				"version":             a.Version,
 			})
 		}
 	}
@@ -759,6 +826,30 @@ func flattenApplicationSourceKustomize(as []*application.ApplicationSourceKustom
 	return
 }
 
+func flattenApplicationSourceKustomizePatch(p application.KustomizePatch) map[string]interface{} {
+	result := map[string]interface{}{
+		"options": p.Options,
+		"patch":   p.Patch,
+		"path":    p.Path,
+	}
+
+	if p.Target != nil {
+		result["target"] = []map[string]interface{}{
+			{
+				"annotation_selector": p.Target.AnnotationSelector,
+				"group":               p.Target.Group,
+				"kind":                p.Target.Kind,
+				"label_selector":      p.Target.LabelSelector,
+				"name":                p.Target.Name,
+				"namespace":           p.Target.Namespace,
+				"version":             p.Target.Version,
+			},
+		}
+	}
+
+	return result
+}
+
 func flattenApplicationSourceHelm(as []*application.ApplicationSourceHelm) (result []map[string]interface{}) {
 	for _, a := range as {
 		if a != nil {
@@ -779,13 +870,23 @@ func flattenApplicationSourceHelm(as []*application.ApplicationSourceHelm) (resu
 				})
 			}
 
+			var valuesObject string
+			if a.ValuesObject != nil {
+				valuesObject = string(a.ValuesObject.Raw)
+			}
+
 			result = append(result, map[string]interface{}{
 				"parameter":                  parameters,
 				"file_parameter":             fileParameters,
 				"release_name":               a.ReleaseName,
 				"skip_crds":                  a.SkipCrds,
+				"skip_tests":                 a.SkipTests,
+				"namespace":                  a.Namespace,
+				"kube_version":               a.KubeVersion,
+				"api_versions":               a.APIVersions,
 				"value_files":                a.ValueFiles,
 				"values":                     a.Values,
+				"values_object":              valuesObject,
 				"pass_credentials":           a.PassCredentials,
 				"ignore_missing_value_files": a.IgnoreMissingValueFiles,
 			})
@@ -806,107 +907,3 @@ func flattenApplicationDestinations(ds []application.ApplicationDestination) (re
 
 	return
 }
-
-func flattenApplicationStatus(s application.ApplicationStatus) []map[string]interface{} {
-	status := map[string]interface{}{
-		"conditions": flattenApplicationConditions(s.Conditions),
-		"health":     flattenApplicationHealthStatus(s.Health),
-		"resources":  flattenApplicationResourceStatuses(s.Resources),
-		"summary":    flattenApplicationSummary(s.Summary),
-		"sync":       flattenApplicationSyncStatus(s.Sync),
-	}
-
-	if s.OperationState != nil {
-		status["operation_state"] = flattenApplicationOperationState(*s.OperationState)
-	}
-
-	if s.ReconciledAt != nil {
-		status["reconciled_at"] = s.ReconciledAt.String()
-	}
-
-	return []map[string]interface{}{status}
-}
-
-func flattenApplicationConditions(aacs []application.ApplicationCondition) []map[string]interface{} {
-	acs := make([]map[string]interface{}, len(aacs))
-
-	for i, v := range aacs {
-		acs[i] = map[string]interface{}{
-			"message": v.Message,
-			"type":    v.Type,
-		}
-
-		if v.LastTransitionTime != nil {
-			acs[i]["last_transition_time"] = v.LastTransitionTime.String()
-		}
-	}
-
-	return acs
-}
-
-func flattenApplicationHealthStatus(hs application.HealthStatus) []map[string]interface{} {
-	h := map[string]interface{}{
-		"message": hs.Message,
-		"status":  hs.Status,
-	}
-
-	return []map[string]interface{}{h}
-}
-
-func flattenApplicationSyncStatus(ss application.SyncStatus) []map[string]interface{} {
-	s := map[string]interface{}{
-		"revision":  ss.Revision,
-		"revisions": ss.Revisions,
-		"status":    ss.Status,
-	}
-
-	return []map[string]interface{}{s}
-}
-
-func flattenApplicationResourceStatuses(arss []application.ResourceStatus) []map[string]interface{} {
-	rss := make([]map[string]interface{}, len(arss))
-
-	for i, v := range arss {
-		rss[i] = map[string]interface{}{
-			"group":            v.Group,
-			"hook":             v.Hook,
-			"kind":             v.Kind,
-			"name":             v.Name,
-			"namespace":        v.Namespace,
-			"requires_pruning": v.RequiresPruning,
-			"status":           v.Status,
-			"sync_wave":        convertInt64ToString(v.SyncWave),
-			"version":          v.Version,
-		}
-
-		if v.Health != nil {
-			rss[i]["health"] = flattenApplicationHealthStatus(*v.Health)
-		}
-	}
-
-	return rss
-}
-
-func flattenApplicationSummary(as application.ApplicationSummary) []map[string]interface{} {
-	s := map[string]interface{}{
-		"external_urls": as.ExternalURLs,
-		"images":        as.Images,
-	}
-
-	return []map[string]interface{}{s}
-}
-
-func flattenApplicationOperationState(os application.OperationState) []map[string]interface{} {
-	s := map[string]interface{}{
-		"message":     os.Message,
-		"phase":       os.Phase,
-		"retry_count": convertInt64ToString(os.RetryCount),
-		"started_at":  os.StartedAt.String(),
-	}
-
-	if os.FinishedAt != nil {
-		s["finished_at"] = os.FinishedAt.String()
-	}
-
-	return []map[string]interface{}{s}
-}
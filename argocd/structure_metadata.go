@@ -1,11 +1,14 @@
 package argocd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/provider"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -67,3 +70,60 @@ func metadataIsInternalKey(annotationKey string) bool {
 
 	return strings.HasSuffix(u.Hostname(), "kubernetes.io") || annotationKey == "notified.notifications.argoproj.io"
 }
+
+// mergeDefaultMetadata merges the default metadata of the provider into the
+// annotations and labels of an object.
+func mergeDefaultMetadata(si *provider.ServerInterface, annotations, labels map[string]string) (map[string]string, map[string]string) {
+	defaults := si.DefaultMetadata()
+
+	return provider.MergeDefaultMetadata(annotations, defaults.Annotations), provider.MergeDefaultMetadata(labels, defaults.Labels)
+}
+
+// removeDefaultMetadata removes the default annotations and labels that are
+// not explicitly managed from those of an object returned by the API.
+func removeDefaultMetadata(si *provider.ServerInterface, d *schema.ResourceData, annotations, labels map[string]string) (map[string]string, map[string]string) {
+	defaults := si.DefaultMetadata()
+	managedAnnotations := expandStringMap(d.Get("metadata.0.annotations").(map[string]interface{}))
+	managedLabels := expandStringMap(d.Get("metadata.0.labels").(map[string]interface{}))
+
+	return provider.RemoveDefaultMetadata(annotations, defaults.Annotations, managedAnnotations), provider.RemoveDefaultMetadata(labels, defaults.Labels, managedLabels)
+}
+
+// validateDefaultMetadata validates the planned annotations and labels once
+// merged with the default metadata of the provider, as they will be sent to
+// the ArgoCD API server.
+func validateDefaultMetadata(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	si, ok := m.(*provider.ServerInterface)
+	if !ok {
+		return nil
+	}
+
+	defaults := si.DefaultMetadata()
+
+	var es []error
+
+	for _, a := range []struct {
+		key      string
+		defaults map[string]string
+		validate schema.SchemaValidateFunc
+	}{
+		{"metadata.0.annotations", defaults.Annotations, validateMetadataAnnotations},
+		{"metadata.0.labels", defaults.Labels, validateMetadataLabels},
+	} {
+		if len(a.defaults) == 0 || !d.NewValueKnown(a.key) {
+			continue
+		}
+
+		planned, _ := d.Get(a.key).(map[string]interface{})
+		merged := map[string]interface{}{}
+
+		for k, v := range provider.MergeDefaultMetadata(expandStringMap(planned), a.defaults) {
+			merged[k] = v
+		}
+
+		_, errs := a.validate(merged, a.key)
+		es = append(es, errs...)
+	}
+
+	return errors.Join(es...)
+}
@@ -1,16 +1,17 @@
 package argocd
 
 import (
+	"encoding/json"
 	"fmt"
-	"regexp"
+	"reflect"
 	"strconv"
-	"strings"
 
-	"github.com/argoproj/argo-cd/v2/server/rbacpolicy"
 	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	customtypes "github.com/oboukili/terraform-provider-argocd/internal/types"
 )
 
 func convertStringToInt64(s string) (i int64, err error) {
@@ -76,83 +77,36 @@ func sliceOfString(slice []interface{}) []string {
 	return result
 }
 
-func isValidPolicyAction(action string) bool {
-	validActions := map[string]bool{
-		rbacpolicy.ActionGet:      true,
-		rbacpolicy.ActionCreate:   true,
-		rbacpolicy.ActionUpdate:   true,
-		rbacpolicy.ActionDelete:   true,
-		rbacpolicy.ActionSync:     true,
-		rbacpolicy.ActionOverride: true,
-		"*":                       true,
-	}
-	validActionPatterns := []*regexp.Regexp{
-		regexp.MustCompile("action/.*"),
-	}
-
-	if validActions[action] {
-		return true
-	}
-
-	for i := range validActionPatterns {
-		if validActionPatterns[i].MatchString(action) {
-			return true
-		}
-	}
-
-	return false
+// suppressEquivalentJSONDiffs suppresses diffs between two JSON encoded
+// strings that are semantically equal (e.g. when keys are ordered differently
+// or whitespace differs).
+func suppressEquivalentJSONDiffs(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return isEquivalentJSON(oldValue, newValue)
 }
 
-func validatePolicy(project string, role string, policy string) error {
-	policyComponents := strings.Split(policy, ",")
-	if len(policyComponents) != 6 || strings.Trim(policyComponents[0], " ") != "p" {
-		return fmt.Errorf("invalid policy rule '%s': must be of the form: 'p, sub, res, act, obj, eft'", policy)
-	}
-
-	// subject
-	subject := strings.Trim(policyComponents[1], " ")
-	expectedSubject := fmt.Sprintf("proj:%s:%s", project, role)
-
-	if subject != expectedSubject {
-		return fmt.Errorf("invalid policy rule '%s': policy subject must be: '%s', not '%s'", policy, expectedSubject, subject)
-	}
-
-	// resource
-	// https://github.com/argoproj/argo-cd/blob/c99669e088b5f25c8ce8faff6df25797a8beb5ba/pkg/apis/application/v1alpha1/types.go#L1554
-	validResources := map[string]bool{
-		rbacpolicy.ResourceApplications: true,
-		rbacpolicy.ResourceRepositories: true,
-		rbacpolicy.ResourceClusters:     true,
-		rbacpolicy.ResourceExec:         true,
-		rbacpolicy.ResourceLogs:         true,
+func isEquivalentJSON(a, b string) bool {
+	if a == b {
+		return true
 	}
 
-	resource := strings.Trim(policyComponents[2], " ")
-	if !validResources[resource] {
-		return fmt.Errorf("invalid policy rule '%s': resource '%s' not recognised", policy, resource)
-	}
+	var av, bv interface{}
 
-	// action
-	action := strings.Trim(policyComponents[3], " ")
-	if !isValidPolicyAction(action) {
-		return fmt.Errorf("invalid policy rule '%s': invalid action '%s'", policy, action)
+	if err := json.Unmarshal([]byte(a), &av); err != nil {
+		return false
 	}
 
-	// object
-	object := strings.Trim(policyComponents[4], " ")
-
-	objectRegexp, err := regexp.Compile(fmt.Sprintf(`^%s/[*\w-.]+$`, project))
-	if err != nil || !objectRegexp.MatchString(object) {
-		return fmt.Errorf("invalid policy rule '%s': object must be of form '%s/*' or '%s/<APPNAME>', not '%s'", policy, project, project, object)
+	if err := json.Unmarshal([]byte(b), &bv); err != nil {
+		return false
 	}
 
-	// effect
-	effect := strings.Trim(policyComponents[5], " ")
-	if effect != "allow" && effect != "deny" {
-		return fmt.Errorf("invalid policy rule '%s': effect must be: 'allow' or 'deny'", policy)
-	}
+	return reflect.DeepEqual(av, bv)
+}
 
-	return nil
+// suppressEquivalentYAMLDiffs suppresses diffs between two YAML documents that
+// are structurally equal (e.g. when trailing newlines, comments or key ordering
+// differ).
+func suppressEquivalentYAMLDiffs(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return customtypes.YAMLSemanticEquals(oldValue, newValue)
 }
 
 func persistToState(key string, data interface{}, d *schema.ResourceData) error {
@@ -164,13 +118,7 @@ func persistToState(key string, data interface{}, d *schema.ResourceData) error
 }
 
 func argoCDAPIError(action, resource, id string, err error) diag.Diagnostics {
-	return []diag.Diagnostic{
-		{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("failed to %s %s %s", action, resource, id),
-			Detail:   err.Error(),
-		},
-	}
+	return pluginSDKDiags(diagnostics.ArgoCDAPIError(action, resource, id, err))
 }
 
 func errorToDiagnostics(summary string, err error) diag.Diagnostics {
@@ -186,13 +134,14 @@ func errorToDiagnostics(summary string, err error) diag.Diagnostics {
 	return []diag.Diagnostic{d}
 }
 
-func featureNotSupported(feature features.Feature) diag.Diagnostics {
+func featureNotSupported(feature features.Feature, reason string) diag.Diagnostics {
 	f := features.ConstraintsMap[feature]
 
 	return []diag.Diagnostic{
 		{
 			Severity: diag.Error,
-			Summary:  fmt.Sprintf("%s is only supported from ArgoCD %s onwards", f.Name, f.MinVersion.String()),
+			Summary:  fmt.Sprintf("ArgoCD server does not support %s", f.Name),
+			Detail:   reason,
 		},
 	}
 }
@@ -1,14 +1,14 @@
 package argocd
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	_ "time/tzdata"
 
-	argocdtime "github.com/argoproj/pkg/time"
-	"github.com/robfig/cron/v3"
 	"golang.org/x/crypto/ssh"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
 	utilValidation "k8s.io/apimachinery/pkg/util/validation"
@@ -49,75 +49,39 @@ func validateMetadataAnnotations(value interface{}, key string) (ws []string, es
 	return
 }
 
-func validateMetadataName(value interface{}, key string) (ws []string, es []error) {
+func validateJSONObject(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
-
-	errors := apiValidation.NameIsDNSSubdomain(v, false)
-	if len(errors) > 0 {
-		for _, err := range errors {
-			es = append(es, fmt.Errorf("%s %s", key, err))
-		}
+	if v == "" {
+		return
 	}
 
-	return
-}
-
-func validateRoleName(value interface{}, key string) (ws []string, es []error) {
-	v := value.(string)
-
-	roleNameRegexp := regexp.MustCompile(`^[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?$`)
-	if !roleNameRegexp.MatchString(v) {
-		es = append(es, fmt.Errorf("%s: invalid role name '%s'. Must consist of alphanumeric characters, '-' or '_', and must start and end with an alphanumeric character", key, v))
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &m); err != nil {
+		es = append(es, fmt.Errorf("%s: must be a JSON encoded object: %s", key, err))
 	}
 
 	return
 }
 
-func validateGroupName(value interface{}, key string) (ws []string, es []error) {
+func validateKustomizeReplicaCount(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 
-	invalidChars := regexp.MustCompile("[,\n\r\t]")
-	if invalidChars.MatchString(v) {
-		es = append(es, fmt.Errorf("%s: group '%s' contains invalid characters", key, v))
+	count, err := strconv.Atoi(v)
+	if err != nil || count < 0 {
+		es = append(es, fmt.Errorf("%s: must be a non-negative integer, got %q", key, v))
 	}
 
 	return
 }
 
-func validateSyncWindowKind(value interface{}, key string) (ws []string, es []error) {
-	v := value.(string)
-	if v != "allow" && v != "deny" {
-		es = append(es, fmt.Errorf("%s: kind '%s' mismatch: can only be allow or deny", key, v))
-	}
-
-	return
-}
-
-func validateSyncWindowSchedule(value interface{}, key string) (ws []string, es []error) {
-	v := value.(string)
-	specParser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-
-	if _, err := specParser.Parse(v); err != nil {
-		es = append(es, fmt.Errorf("%s: cannot parse schedule '%s': %s", key, v, err))
-	}
-
-	return
-}
-
-func validateSyncWindowDuration(value interface{}, key string) (ws []string, es []error) {
+func validateMetadataName(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 
-	if _, err := argocdtime.ParseDuration(v); err != nil {
-		es = append(es, fmt.Errorf("%s: cannot parse duration '%s': %s", key, v, err))
-	}
-
-	return
-}
-
-func validateSyncWindowTimezone(value interface{}, key string) (ws []string, es []error) {
-	v := value.(string)
-	if _, err := time.LoadLocation(v); err != nil {
-		es = append(es, fmt.Errorf("%s: cannot parse timezone '%s': %s", key, v, err))
+	errors := apiValidation.NameIsDNSSubdomain(v, false)
+	if len(errors) > 0 {
+		for _, err := range errors {
+			es = append(es, fmt.Errorf("%s %s", key, err))
+		}
 	}
 
 	return
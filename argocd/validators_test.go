@@ -47,3 +47,93 @@ func Test_validateSSHPrivateKey(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateJSONObject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{
+			name:  "Empty string",
+			value: "",
+		},
+		{
+			name:  "JSON object",
+			value: `{"foo":{"bar":["baz"]}}`,
+		},
+		{
+			name:    "JSON array",
+			value:   `["foo"]`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			value:   "foo: bar",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotWs, gotEs := validateJSONObject(tt.value, "values_object")
+
+			if len(gotWs) != 0 {
+				t.Errorf("validateJSONObject() gotWs = %v, want none", gotWs)
+			}
+
+			if (len(gotEs) != 0) != tt.wantErr {
+				t.Errorf("validateJSONObject() gotEs = %v, wantErr %v", gotEs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateKustomizeReplicaCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			value: "0",
+		},
+		{
+			name:  "Positive integer",
+			value: "3",
+		},
+		{
+			name:    "Negative integer",
+			value:   "-1",
+			wantErr: true,
+		},
+		{
+			name:    "Not an integer",
+			value:   "three",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, gotEs := validateKustomizeReplicaCount(tt.value, "count")
+
+			if (len(gotEs) != 0) != tt.wantErr {
+				t.Errorf("validateKustomizeReplicaCount() gotEs = %v, wantErr %v", gotEs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+data "argocd_application_events" "foo" {
+  application_name      = "foo"
+  application_namespace = "argocd"
+}
@@ -11,6 +11,28 @@ provider "argocd" {
   password    = local.password
 }
 
+# Exposed ArgoCD API - authenticated using a short-lived token obtained by
+# executing an external command.
+provider "argocd" {
+  server_addr = "argocd.local:443"
+  auth_exec {
+    command       = "vault"
+    args          = ["kv", "get", "-field=token", "secret/argocd"]
+    output_format = "plain"
+  }
+}
+
+# Exposed ArgoCD API - authenticated using an ID token obtained by exchanging
+# the federated identity token of a CI runner with an OIDC issuer.
+provider "argocd" {
+  server_addr = "argocd.local:443"
+  oidc {
+    issuer_url         = "https://dex.argocd.local"
+    client_id          = "argocd-ci"
+    subject_token_file = "/var/run/secrets/tokens/oidc-token"
+  }
+}
+
 # Exposed ArgoCD API - (pre)authenticated using local ArgoCD config (e.g. when
 # you have previously logged in using SSO).
 provider "argocd" {
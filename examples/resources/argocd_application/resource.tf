@@ -148,3 +148,37 @@ resource "argocd_application" "multiple_sources" {
     }
   }
 }
+
+# Spec fields not (yet) exposed in `spec` can be set using `spec_override_json`
+resource "argocd_application" "spec_override_json" {
+  metadata {
+    name      = "kustomize-app-with-override"
+    namespace = "argocd"
+  }
+
+  spec {
+    source {
+      repo_url        = "https://github.com/kubernetes-sigs/kustomize"
+      path            = "examples/helloWorld"
+      target_revision = "release-kustomize-v3.7"
+      kustomize {
+        common_annotations = {
+          "this.is.a.common" = "anno-tation"
+        }
+      }
+    }
+
+    destination {
+      server    = "https://kubernetes.default.svc"
+      namespace = "default"
+    }
+  }
+
+  spec_override_json = jsonencode({
+    source = {
+      kustomize = {
+        forceCommonAnnotations = true
+      }
+    }
+  })
+}
@@ -2,15 +2,39 @@ package diagnostics
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
 )
 
 func ArgoCDAPIError(action, resource, id string, err error) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	diags.AddError(fmt.Sprintf("failed to %s %s %s", action, resource, id), err.Error())
+	summary := strings.TrimSpace(fmt.Sprintf("failed to %s %s %s", action, resource, id))
+	detail := err.Error()
+
+	switch {
+	case IsPermissionDenied(err):
+		if p := rbacPermission(err); p != "" {
+			summary = fmt.Sprintf("%s: token lacks RBAC permission `%s`", summary, p)
+		} else {
+			summary = fmt.Sprintf("%s: permission denied", summary)
+		}
+
+		detail = fmt.Sprintf("%s\n\nEnsure that the RBAC policies of the ArgoCD server (or of the project, when authenticating using a project token) grant this permission to the account used by the provider.", detail)
+	case IsNotFound(err):
+		summary = fmt.Sprintf("%s: not found", summary)
+	case IsAlreadyExists(err):
+		summary = fmt.Sprintf("%s: already exists", summary)
+		detail = fmt.Sprintf("%s\n\nEither import the existing %s into the Terraform state or use a different identifier.", detail, resource)
+	case IsFailedPrecondition(err):
+		summary = fmt.Sprintf("%s: precondition failed", summary)
+		detail = fmt.Sprintf("%s\n\nThe %s may have been modified concurrently or be in the process of being deleted, retrying the operation may succeed.", detail, resource)
+	}
+
+	diags.AddError(summary, detail)
 
 	return diags
 }
@@ -29,12 +53,27 @@ func Error(summary string, err error) diag.Diagnostics {
 	return diags
 }
 
-func FeatureNotSupported(f features.Feature) diag.Diagnostics {
+// FeatureNotSupported returns an error reporting that the feature is not
+// available on the ArgoCD server, along with the reason why (see
+// `ServerInterface.FeatureSupport`).
+func FeatureNotSupported(f features.Feature, reason string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	fc := features.ConstraintsMap[f]
+	diags.AddError(featureNotSupportedSummary(f), reason)
+
+	return diags
+}
+
+// AttributeFeatureNotSupported is FeatureNotSupported for the attribute at `p`,
+// which is guarded by the feature.
+func AttributeFeatureNotSupported(p path.Path, f features.Feature, reason string) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	diags.AddError(fmt.Sprintf("%s is only supported from ArgoCD %s onwards", fc.Name, fc.MinVersion.String()), "")
+	diags.AddAttributeError(p, featureNotSupportedSummary(f), reason)
 
 	return diags
 }
+
+func featureNotSupportedSummary(f features.Feature) string {
+	return fmt.Sprintf("ArgoCD server does not support %s", features.ConstraintsMap[f].Name)
+}
@@ -0,0 +1,52 @@
+package diagnostics
+
+import (
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rbacPermissionRegexp matches the message of the errors returned by the
+// ArgoCD API when the RBAC policies do not grant a permission, e.g.
+// `permission denied: applications, get, default/foo, sub: admin, iat: ...`.
+var rbacPermissionRegexp = regexp.MustCompile(`^permission denied: ([^,]+), ([^,]+),`)
+
+// IsNotFound returns whether `err` was returned by the ArgoCD API because the
+// requested object does not exist.
+func IsNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// IsPermissionDenied returns whether `err` was returned by the ArgoCD API
+// because the RBAC policies do not grant the permission required by the
+// request. Note that ArgoCD also returns such errors when requesting objects
+// that do not exist without being granted permission on all objects of that
+// kind, so these errors do not imply that the object exists.
+func IsPermissionDenied(err error) bool {
+	return status.Code(err) == codes.PermissionDenied
+}
+
+// IsAlreadyExists returns whether `err` was returned by the ArgoCD API because
+// the object to create already exists.
+func IsAlreadyExists(err error) bool {
+	return status.Code(err) == codes.AlreadyExists
+}
+
+// IsFailedPrecondition returns whether `err` was returned by the ArgoCD API
+// because the object is not in a state allowing the request, e.g. it has been
+// modified concurrently or is being deleted.
+func IsFailedPrecondition(err error) bool {
+	return status.Code(err) == codes.FailedPrecondition
+}
+
+// rbacPermission returns the RBAC permission (e.g. `applications, get`) that
+// the error `err` was returned for, if any.
+func rbacPermission(err error) string {
+	m := rbacPermissionRegexp.FindStringSubmatch(status.Convert(err).Message())
+	if m == nil {
+		return ""
+	}
+
+	return m[1] + ", " + m[2]
+}
@@ -0,0 +1,99 @@
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	notFound := status.Error(codes.NotFound, "applications.argoproj.io \"foo\" not found")
+	permissionDenied := status.Error(codes.PermissionDenied, "permission denied")
+
+	assert.True(t, IsNotFound(notFound))
+	assert.True(t, IsNotFound(fmt.Errorf("wrapped: %w", notFound)))
+	assert.False(t, IsNotFound(permissionDenied))
+	assert.False(t, IsNotFound(nil))
+
+	// Errors which merely mention "NotFound" are not considered as such
+	assert.False(t, IsNotFound(errors.New("NotFound")))
+	assert.False(t, IsNotFound(status.Error(codes.PermissionDenied, "permission denied: applications, get, default/NotFound")))
+
+	assert.True(t, IsPermissionDenied(permissionDenied))
+	assert.True(t, IsAlreadyExists(status.Error(codes.AlreadyExists, "exists")))
+	assert.True(t, IsFailedPrecondition(status.Error(codes.FailedPrecondition, "precondition")))
+}
+
+func TestArgoCDAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		err             error
+		expectedSummary string
+		expectedDetail  string
+	}{
+		{
+			name:            "permission denied with RBAC permission",
+			err:             status.Error(codes.PermissionDenied, "permission denied: applications, get, default/foo, sub: admin, iat: 2023-01-01T00:00:00Z"),
+			expectedSummary: "failed to read application foo: token lacks RBAC permission `applications, get`",
+			expectedDetail:  "rpc error: code = PermissionDenied desc = permission denied: applications, get, default/foo, sub: admin, iat: 2023-01-01T00:00:00Z",
+		},
+		{
+			name:            "permission denied without RBAC permission",
+			err:             status.Error(codes.PermissionDenied, "permission denied"),
+			expectedSummary: "failed to read application foo: permission denied",
+			expectedDetail:  "rpc error: code = PermissionDenied desc = permission denied",
+		},
+		{
+			name:            "not found",
+			err:             status.Error(codes.NotFound, "applications.argoproj.io \"foo\" not found"),
+			expectedSummary: "failed to read application foo: not found",
+			expectedDetail:  "rpc error: code = NotFound desc = applications.argoproj.io \"foo\" not found",
+		},
+		{
+			name:            "already exists",
+			err:             status.Error(codes.AlreadyExists, "existing application spec is different, use upsert flag to force update"),
+			expectedSummary: "failed to read application foo: already exists",
+			expectedDetail:  "Either import the existing application into the Terraform state",
+		},
+		{
+			name:            "failed precondition",
+			err:             status.Error(codes.FailedPrecondition, "the object has been modified"),
+			expectedSummary: "failed to read application foo: precondition failed",
+			expectedDetail:  "The application may have been modified concurrently",
+		},
+		{
+			name:            "other gRPC error",
+			err:             status.Error(codes.Internal, "boom"),
+			expectedSummary: "failed to read application foo",
+			expectedDetail:  "rpc error: code = Internal desc = boom",
+		},
+		{
+			name:            "non gRPC error",
+			err:             errors.New("boom"),
+			expectedSummary: "failed to read application foo",
+			expectedDetail:  "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := ArgoCDAPIError("read", "application", "foo", tt.err)
+
+			assert.Len(t, diags, 1)
+			assert.Equal(t, tt.expectedSummary, diags[0].Summary())
+			assert.Contains(t, diags[0].Detail(), tt.expectedDetail)
+		})
+	}
+}
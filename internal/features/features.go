@@ -1,6 +1,8 @@
 package features
 
 import (
+	"sort"
+
 	"github.com/Masterminds/semver/v3"
 )
 
@@ -15,20 +17,185 @@ const (
 	ManagedNamespaceMetadata
 	ApplicationSetApplicationsSyncPolicy
 	ApplicationSetIgnoreApplicationDifferences
+	ApplicationHelmValuesObject
+	ApplicationKustomizeNamespace
+	ApplicationKustomizeReplicas
+	ApplicationKustomizePatches
+	ApplicationsInAnyNamespace
+	ApplicationKustomizeComponents
+	ApplicationHelmNamespace
+	ApplicationHelmCapabilities
+	ApplicationHelmSkipTests
 )
 
 type FeatureConstraint struct {
 	Name       string
 	MinVersion *semver.Version
+
+	// Paths lists, by resource type, the schema paths of the attributes
+	// guarded by the feature. Path steps are attribute names or list indexes
+	// separated by `.`, the elements of lists and sets being traversed
+	// implicitly (e.g. `spec.source.helm.values_object` matches the attribute
+	// in every source while `spec.source.1` only matches the second source).
+	// The feature is used as soon as any of the attributes is set to a
+	// non-empty value.
+	Paths map[string][]string
+
+	// Probe, if set, is run against servers whose version supports the
+	// feature to check that it is also enabled.
+	Probe Probe
 }
 
 var ConstraintsMap = map[Feature]FeatureConstraint{
-	ExecLogsPolicy:                             {"exec/logs RBAC policy", semver.MustParse("2.4.4")},
-	ProjectSourceNamespaces:                    {"project source namespaces", semver.MustParse("2.5.0")},
-	MultipleApplicationSources:                 {"multiple application sources", semver.MustParse("2.6.3")}, // Whilst the feature was introduced in 2.6.0 there was a bug that affects refresh of applications (and hence `wait` within this provider) that was only fixed in https://github.com/argoproj/argo-cd/pull/12576
-	ApplicationSet:                             {"application sets", semver.MustParse("2.5.0")},
-	ApplicationSetProgressiveSync:              {"progressive sync (`strategy`)", semver.MustParse("2.6.0")},
-	ManagedNamespaceMetadata:                   {"managed namespace metadsata", semver.MustParse("2.6.0")},
-	ApplicationSetApplicationsSyncPolicy:       {"application set level application sync policy", semver.MustParse("2.8.0")},
-	ApplicationSetIgnoreApplicationDifferences: {"application set ignore application differences", semver.MustParse("2.9.0")},
+	ExecLogsPolicy: {
+		Name:       "exec/logs RBAC policy",
+		MinVersion: semver.MustParse("2.4.4"),
+	},
+	ProjectSourceNamespaces: {
+		Name:       "project source namespaces",
+		MinVersion: semver.MustParse("2.5.0"),
+		Paths: map[string][]string{
+			"argocd_project": {"spec.source_namespaces"},
+		},
+	},
+	MultipleApplicationSources: {
+		Name: "multiple application sources",
+		// Whilst the feature was introduced in 2.6.0 there was a bug that affects refresh of applications (and hence `wait` within this provider) that was only fixed in https://github.com/argoproj/argo-cd/pull/12576
+		MinVersion: semver.MustParse("2.6.3"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.1"},
+			"argocd_application_set": {"spec.template.spec.source.1"},
+		},
+	},
+	ApplicationSet: {
+		Name:       "application sets",
+		MinVersion: semver.MustParse("2.5.0"),
+		Paths: map[string][]string{
+			"argocd_application_set": {"spec"},
+		},
+		Probe: probeApplicationSetCRD,
+	},
+	ApplicationSetProgressiveSync: {
+		Name:       "progressive sync (`strategy`)",
+		MinVersion: semver.MustParse("2.6.0"),
+		Paths: map[string][]string{
+			"argocd_application_set": {"spec.strategy"},
+		},
+	},
+	ManagedNamespaceMetadata: {
+		Name:       "managed namespace metadata",
+		MinVersion: semver.MustParse("2.6.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.sync_policy.managed_namespace_metadata"},
+			"argocd_application_set": {"spec.template.spec.sync_policy.managed_namespace_metadata"},
+		},
+	},
+	ApplicationSetApplicationsSyncPolicy: {
+		Name:       "application set level application sync policy",
+		MinVersion: semver.MustParse("2.8.0"),
+		Paths: map[string][]string{
+			"argocd_application_set": {"spec.sync_policy.applications_sync"},
+		},
+	},
+	ApplicationSetIgnoreApplicationDifferences: {
+		Name:       "application set ignore application differences",
+		MinVersion: semver.MustParse("2.9.0"),
+		Paths: map[string][]string{
+			"argocd_application_set": {"spec.ignore_application_differences"},
+		},
+	},
+	ApplicationHelmValuesObject: {
+		Name:       "helm `values_object`",
+		MinVersion: semver.MustParse("2.8.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.helm.values_object"},
+			"argocd_application_set": {"spec.template.spec.source.helm.values_object"},
+		},
+	},
+	ApplicationKustomizeNamespace: {
+		Name:       "kustomize `namespace`",
+		MinVersion: semver.MustParse("2.5.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.kustomize.namespace"},
+			"argocd_application_set": {"spec.template.spec.source.kustomize.namespace"},
+		},
+	},
+	ApplicationKustomizeReplicas: {
+		Name:       "kustomize `replicas`",
+		MinVersion: semver.MustParse("2.8.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.kustomize.replicas"},
+			"argocd_application_set": {"spec.template.spec.source.kustomize.replicas"},
+		},
+	},
+	ApplicationKustomizePatches: {
+		Name:       "kustomize `patches`",
+		MinVersion: semver.MustParse("2.9.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.kustomize.patches"},
+			"argocd_application_set": {"spec.template.spec.source.kustomize.patches"},
+		},
+	},
+	// Applications in any namespace are checked against the namespace of the
+	// application rather than using guarded paths, since the namespace of the
+	// control plane is always allowed.
+	ApplicationsInAnyNamespace: {
+		Name:       "applications in any namespace",
+		MinVersion: semver.MustParse("2.5.0"),
+		Probe:      probeApplicationsInAnyNamespace,
+	},
+	ApplicationKustomizeComponents: {
+		Name:       "kustomize `components`",
+		MinVersion: semver.MustParse("2.10.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.kustomize.components"},
+			"argocd_application_set": {"spec.template.spec.source.kustomize.components"},
+		},
+	},
+	ApplicationHelmNamespace: {
+		Name:       "helm `namespace`",
+		MinVersion: semver.MustParse("2.13.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.helm.namespace"},
+			"argocd_application_set": {"spec.template.spec.source.helm.namespace"},
+		},
+	},
+	ApplicationHelmCapabilities: {
+		Name:       "helm `kube_version` and `api_versions`",
+		MinVersion: semver.MustParse("2.13.0"),
+		Paths: map[string][]string{
+			"argocd_application": {
+				"spec.source.helm.kube_version",
+				"spec.source.helm.api_versions",
+			},
+			"argocd_application_set": {
+				"spec.template.spec.source.helm.kube_version",
+				"spec.template.spec.source.helm.api_versions",
+			},
+		},
+	},
+	ApplicationHelmSkipTests: {
+		Name:       "helm `skip_tests`",
+		MinVersion: semver.MustParse("2.14.0"),
+		Paths: map[string][]string{
+			"argocd_application":     {"spec.source.helm.skip_tests"},
+			"argocd_application_set": {"spec.template.spec.source.helm.skip_tests"},
+		},
+	},
+}
+
+// Guarding returns, in ascending order, the features guarding attributes of
+// `resourceType`.
+func Guarding(resourceType string) []Feature {
+	var fs []Feature
+
+	for f, fc := range ConstraintsMap {
+		if len(fc.Paths[resourceType]) > 0 {
+			fs = append(fs, f)
+		}
+	}
+
+	sort.Slice(fs, func(i, j int) bool { return fs[i] < fs[j] })
+
+	return fs
 }
@@ -0,0 +1,108 @@
+package features
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Value gives access to the configuration of a resource, so that the paths
+// guarded by features (see FeatureConstraint.Paths) can be looked up whether
+// the resource is implemented using the plugin SDK or framework. P is the type
+// of the paths of the attributes.
+type Value[P any] interface {
+	// IsSet returns whether the value is known and not null.
+	IsSet() bool
+
+	// IsEmpty returns whether the value, which is set, is an empty string or
+	// collection, or false.
+	IsEmpty() bool
+
+	// Path returns the path of the value.
+	Path() P
+
+	// Attribute returns the attribute `name` of an object value, if any.
+	Attribute(name string) (Value[P], bool)
+
+	// Elements returns the elements of a list, set or tuple value, and whether
+	// they are ordered (i.e. can be addressed by index in guarded paths).
+	Elements() ([]Value[P], bool)
+}
+
+// UsedPaths returns the paths of the attributes of `v` matching the guarded
+// path `p` and set to a non-empty value. Unknown values are ignored as they
+// cannot be checked until apply.
+func UsedPaths[P any](v Value[P], p string) []P {
+	return usedPaths(v, strings.Split(p, "."))
+}
+
+func usedPaths[P any](v Value[P], steps []string) []P {
+	if !v.IsSet() {
+		return nil
+	}
+
+	if len(steps) == 0 {
+		if v.IsEmpty() {
+			return nil
+		}
+
+		return []P{v.Path()}
+	}
+
+	if a, ok := v.Attribute(steps[0]); ok {
+		return usedPaths(a, steps[1:])
+	}
+
+	elems, ordered := v.Elements()
+
+	if i, err := strconv.Atoi(steps[0]); err == nil && ordered {
+		if i < 0 || i >= len(elems) {
+			return nil
+		}
+
+		return usedPaths(elems[i], steps[1:])
+	}
+
+	var paths []P
+
+	for _, e := range elems {
+		paths = append(paths, usedPaths(e, steps)...)
+	}
+
+	return paths
+}
+
+// Unsupported is the use of an attribute guarded by a feature that is not
+// supported by the ArgoCD server.
+type Unsupported[P any] struct {
+	Feature Feature
+	Path    P
+	Reason  string
+}
+
+// CheckPaths returns the attributes of `config` that are guarded by a feature,
+// for `resourceType`, that is not supported according to `supported`. The
+// latter is only called once an attribute guarded by the feature is found to be
+// set, so that the ArgoCD server need only be reached if necessary. Checking
+// stops as soon as `supported` fails.
+func CheckPaths[P any](resourceType string, config Value[P], supported func(Feature) (bool, string, error)) ([]Unsupported[P], error) {
+	var unsupported []Unsupported[P]
+
+	for _, f := range Guarding(resourceType) {
+		for _, p := range ConstraintsMap[f].Paths[resourceType] {
+			for _, ap := range UsedPaths(config, p) {
+				ok, reason, err := supported(f)
+				if err != nil {
+					return unsupported, err
+				}
+
+				if ok {
+					break
+				}
+
+				unsupported = append(unsupported, Unsupported[P]{Feature: f, Path: ap, Reason: reason})
+			}
+		}
+	}
+
+	return unsupported, nil
+}
@@ -0,0 +1,165 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeValue implements Value for plain Go values, with paths formatted as
+// strings. Lists are ordered unlike sets, and nil values are unknown.
+type fakeValue struct {
+	v interface{}
+	p string
+}
+
+type fakeSet []interface{}
+
+func (f fakeValue) IsSet() bool {
+	return f.v != nil
+}
+
+func (f fakeValue) IsEmpty() bool {
+	switch v := f.v.(type) {
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case fakeSet:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+func (f fakeValue) Path() string {
+	return f.p
+}
+
+func (f fakeValue) Attribute(name string) (Value[string], bool) {
+	m, ok := f.v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	a, ok := m[name]
+	if !ok {
+		return nil, false
+	}
+
+	return fakeValue{v: a, p: f.p + "." + name}, true
+}
+
+func (f fakeValue) Elements() ([]Value[string], bool) {
+	var elems []Value[string]
+
+	switch v := f.v.(type) {
+	case []interface{}:
+		for i, e := range v {
+			elems = append(elems, fakeValue{v: e, p: fmt.Sprintf("%s.%d", f.p, i)})
+		}
+
+		return elems, true
+	case fakeSet:
+		for _, e := range v {
+			elems = append(elems, fakeValue{v: e, p: f.p + ".*"})
+		}
+	}
+
+	return elems, false
+}
+
+func TestUsedPaths(t *testing.T) {
+	t.Parallel()
+
+	config := fakeValue{p: "config", v: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"strategy": []interface{}{},
+			"source": []interface{}{
+				map[string]interface{}{"repo_url": "https://charts.example", "helm": nil},
+				map[string]interface{}{"repo_url": "https://git.example", "helm": map[string]interface{}{"skip_crds": true}},
+				map[string]interface{}{"repo_url": "https://git.example", "helm": map[string]interface{}{"skip_crds": false}},
+				map[string]interface{}{"repo_url": "https://git.example", "helm": map[string]interface{}{"skip_crds": nil}},
+			},
+			"roles": fakeSet{
+				map[string]interface{}{"name": "foo", "groups": fakeSet{}},
+				map[string]interface{}{"name": "bar", "groups": fakeSet{"admins"}},
+			},
+		},
+	}}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"spec", []string{"config.spec"}},
+		{"spec.strategy", nil},
+		{"spec.unknown_attribute", nil},
+		{"spec.source.1", []string{"config.spec.source.1"}},
+		{"spec.source.4", nil},
+		{"spec.source.-1", nil},
+		{"spec.source.helm.skip_crds", []string{"config.spec.source.1.helm.skip_crds"}},
+		{"spec.source.1.helm.skip_crds", []string{"config.spec.source.1.helm.skip_crds"}},
+		{"spec.roles.groups", []string{"config.spec.roles.*.groups"}},
+		{"spec.roles.0", nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, UsedPaths[string](config, tt.path))
+		})
+	}
+}
+
+func TestCheckPaths(t *testing.T) {
+	t.Parallel()
+
+	config := fakeValue{p: "config", v: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"source": []interface{}{
+				map[string]interface{}{"helm": map[string]interface{}{"values_object": `{"foo":"bar"}`, "kube_version": ""}},
+				map[string]interface{}{"helm": map[string]interface{}{"values_object": `{"foo":"baz"}`}},
+			},
+		},
+	}}
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		var checked []Feature
+
+		got, err := CheckPaths[string]("argocd_application", config, func(f Feature) (bool, string, error) {
+			checked = append(checked, f)
+
+			return f != ApplicationHelmValuesObject, "not supported", nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Unsupported[string]{
+			{Feature: ApplicationHelmValuesObject, Path: "config.spec.source.0.helm.values_object", Reason: "not supported"},
+			{Feature: ApplicationHelmValuesObject, Path: "config.spec.source.1.helm.values_object", Reason: "not supported"},
+		}, got)
+
+		// Only features guarding attributes that are set are checked
+		assert.Equal(t, []Feature{MultipleApplicationSources, ApplicationHelmValuesObject, ApplicationHelmValuesObject}, checked)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := CheckPaths[string]("argocd_application", config, func(f Feature) (bool, string, error) {
+			return false, "", errors.New("unavailable")
+		})
+
+		assert.EqualError(t, err, "unavailable")
+		assert.Empty(t, got)
+	})
+}
@@ -0,0 +1,63 @@
+package features
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/settings"
+)
+
+// Server is the ArgoCD server against which runtime probes are run.
+type Server interface {
+	// Settings returns the settings of the ArgoCD API server.
+	Settings(ctx context.Context) (*settings.Settings, error)
+
+	// KubernetesResourceExists returns whether the Kubernetes API of the
+	// cluster ArgoCD is installed in serves `resource` in `groupVersion`
+	// (e.g. whether its CRD is installed). `ok` is false if the provider does
+	// not have access to the Kubernetes API.
+	KubernetesResourceExists(ctx context.Context, groupVersion, resource string) (exists, ok bool, err error)
+}
+
+// Probe reports whether a feature that is supported by the version of the
+// ArgoCD server is also enabled, depending on its settings, and if not the
+// reason why. Features that cannot be probed are assumed to be enabled.
+//
+// Note: some capabilities, such as progressive syncs or the SCM providers
+// allowed by the ApplicationSet controller, depend on flags of the controllers
+// that are not exposed by the ArgoCD API, hence cannot be probed.
+type Probe func(ctx context.Context, s Server) (enabled bool, reason string, err error)
+
+// probeApplicationsInAnyNamespace probes whether applications may be created
+// outside of the namespace of the ArgoCD control plane.
+func probeApplicationsInAnyNamespace(ctx context.Context, s Server) (bool, string, error) {
+	ss, err := s.Settings(ctx)
+	if err != nil {
+		return false, "", err
+	}
+
+	// Servers that do not report the namespace of the control plane do not
+	// report whether the feature is enabled either.
+	if ss.ControllerNamespace == "" || ss.AppsInAnyNamespaceEnabled {
+		return true, "", nil
+	}
+
+	return false, "Applications in any namespace are not enabled on the ArgoCD server (see `application.namespaces` in the `argocd-cmd-params-cm` ConfigMap).", nil
+}
+
+// probeApplicationSetCRD probes whether the ApplicationSet CRD is installed,
+// which is not the case when the ApplicationSet controller is disabled. As the
+// ArgoCD API server does not report errors when listing application sets
+// without the CRD, it is only probed when the provider has access to the
+// Kubernetes API (i.e. in core mode or when port forwarding).
+func probeApplicationSetCRD(ctx context.Context, s Server) (bool, string, error) {
+	exists, ok, err := s.KubernetesResourceExists(ctx, "argoproj.io/v1alpha1", "applicationsets")
+	if err != nil {
+		return false, "", err
+	}
+
+	if !ok || exists {
+		return true, "", nil
+	}
+
+	return false, "The ApplicationSet CRD (`applicationsets.argoproj.io`) is not installed, i.e. the ApplicationSet controller is not enabled.", nil
+}
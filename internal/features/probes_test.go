@@ -0,0 +1,77 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer reports whether the Kubernetes resources in `resources` exist,
+// unless `kubernetes` is false.
+type fakeServer struct {
+	kubernetes bool
+	resources  map[string]bool
+	err        error
+}
+
+func (s fakeServer) Settings(context.Context) (*settings.Settings, error) {
+	return &settings.Settings{}, nil
+}
+
+func (s fakeServer) KubernetesResourceExists(_ context.Context, groupVersion, resource string) (bool, bool, error) {
+	return s.resources[groupVersion+"/"+resource], s.kubernetes, s.err
+}
+
+func TestProbeApplicationSetCRD(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		server      fakeServer
+		wantEnabled bool
+		wantErr     bool
+	}{
+		{
+			name:        "no access to the Kubernetes API",
+			server:      fakeServer{},
+			wantEnabled: true,
+		},
+		{
+			name:        "CRD installed",
+			server:      fakeServer{kubernetes: true, resources: map[string]bool{"argoproj.io/v1alpha1/applicationsets": true}},
+			wantEnabled: true,
+		},
+		{
+			name:        "CRD not installed",
+			server:      fakeServer{kubernetes: true},
+			wantEnabled: false,
+		},
+		{
+			name:    "discovery failure",
+			server:  fakeServer{kubernetes: true, err: errors.New("unavailable")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			enabled, reason, err := probeApplicationSetCRD(context.Background(), tt.server)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEnabled, enabled)
+			assert.Equal(t, tt.wantEnabled, reason == "")
+		})
+	}
+}
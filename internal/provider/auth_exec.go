@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	authExecOutputFormatJSON  = "json"
+	authExecOutputFormatPlain = "plain"
+)
+
+// authExecOptions configure the command executed to obtain an ArgoCD
+// authentication token.
+type authExecOptions struct {
+	command      string
+	args         []string
+	env          map[string]string
+	outputFormat string
+}
+
+// authExecOutput is the expected output of the command when using the `json`
+// output format.
+type authExecOutput struct {
+	Token               string     `json:"token"`
+	ExpirationTimestamp *time.Time `json:"expiration_timestamp,omitempty"`
+}
+
+// token executes the command and returns the token it produced, along with
+// its expiry time (zero if unknown).
+func (o authExecOptions) token(ctx context.Context) (string, time.Time, error) {
+	cmd := exec.CommandContext(ctx, o.command, o.args...)
+
+	cmd.Env = os.Environ()
+	for k, v := range o.env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if s := strings.TrimSpace(stderr.String()); s != "" {
+			return "", time.Time{}, fmt.Errorf("failed to execute %s: %w: %s", o.command, err, s)
+		}
+
+		return "", time.Time{}, fmt.Errorf("failed to execute %s: %w", o.command, err)
+	}
+
+	var out authExecOutput
+
+	switch o.outputFormat {
+	case authExecOutputFormatJSON:
+		if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to decode output of %s: %w", o.command, err)
+		}
+	default:
+		out.Token = stdout.String()
+	}
+
+	token := strings.TrimSpace(out.Token)
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf("%s did not output a token", o.command)
+	}
+
+	if out.ExpirationTimestamp == nil {
+		return token, time.Time{}, nil
+	}
+
+	return token, *out.ExpirationTimestamp, nil
+}
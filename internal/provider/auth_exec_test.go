@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthExecOptions_token(t *testing.T) {
+	t.Parallel()
+
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		opts           authExecOptions
+		expectedToken  string
+		expectedExpiry time.Time
+		expectedError  string
+	}{
+		{
+			name: "plain",
+			opts: authExecOptions{
+				command: "sh",
+				args:    []string{"-c", "echo '  foo  '"},
+			},
+			expectedToken: "foo",
+		},
+		{
+			name: "plain with env",
+			opts: authExecOptions{
+				command:      "sh",
+				args:         []string{"-c", "echo $TOKEN"},
+				env:          map[string]string{"TOKEN": "bar"},
+				outputFormat: authExecOutputFormatPlain,
+			},
+			expectedToken: "bar",
+		},
+		{
+			name: "json",
+			opts: authExecOptions{
+				command:      "sh",
+				args:         []string{"-c", `echo '{"token": "foo", "expiration_timestamp": "2030-01-01T00:00:00Z"}'`},
+				outputFormat: authExecOutputFormatJSON,
+			},
+			expectedToken:  "foo",
+			expectedExpiry: expiry,
+		},
+		{
+			name: "json without expiry",
+			opts: authExecOptions{
+				command:      "sh",
+				args:         []string{"-c", `echo '{"token": "foo"}'`},
+				outputFormat: authExecOutputFormatJSON,
+			},
+			expectedToken: "foo",
+		},
+		{
+			name: "invalid json",
+			opts: authExecOptions{
+				command:      "sh",
+				args:         []string{"-c", "echo foo"},
+				outputFormat: authExecOutputFormatJSON,
+			},
+			expectedError: "failed to decode output of sh",
+		},
+		{
+			name: "no token",
+			opts: authExecOptions{
+				command: "true",
+			},
+			expectedError: "true did not output a token",
+		},
+		{
+			name: "failure",
+			opts: authExecOptions{
+				command: "sh",
+				args:    []string{"-c", "echo denied >&2; exit 1"},
+			},
+			expectedError: "failed to execute sh: exit status 1: denied",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, expiry, err := tt.opts.token(context.Background())
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedToken, token)
+			assert.True(t, tt.expectedExpiry.Equal(expiry), "expected expiry %s, got %s", tt.expectedExpiry, expiry)
+		})
+	}
+}
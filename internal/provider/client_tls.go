@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"crypto/tls"
+	"errors"
+
+	tlsutil "github.com/argoproj/argo-cd/v2/util/tls"
+)
+
+// clientTLSData is the TLS material of the API client that is set inline,
+// using either the provider configuration or environment variables, rather
+// than using files.
+type clientTLSData struct {
+	certData       string
+	clientCertData string
+	clientKeyData  string
+}
+
+// apply adds the inline TLS material to `config`, the TLS configuration
+// resolved by the API client, which only supports TLS material stored in
+// files.
+func (t clientTLSData) apply(config *tls.Config) error {
+	if t.certData != "" {
+		config.RootCAs = tlsutil.BestEffortSystemCertPool()
+		if !config.RootCAs.AppendCertsFromPEM([]byte(t.certData)) {
+			return errors.New("failed to append certificates")
+		}
+	}
+
+	if t.clientCertData != "" {
+		clientCert, err := tls.X509KeyPair([]byte(t.clientCertData), []byte(t.clientKeyData))
+		if err != nil {
+			return err
+		}
+
+		config.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// testCertificate is a PEM encoded certificate and private key.
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+
+	certPEM string
+	keyPEM  string
+}
+
+// newTestCertificate issues a certificate for 127.0.0.1 and argocd.example
+// signed by `parent`, or self-signed if nil.
+func newTestCertificate(t *testing.T, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "argocd"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"argocd.example"},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func TestGetClientTLSData(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCertificate(t, nil)
+	client := newTestCertificate(t, ca)
+	other := newTestCertificate(t, ca)
+
+	tests := []struct {
+		name          string
+		config        ArgoCDProviderConfig
+		expectedPath  path.Path
+		expectedError string
+	}{
+		{
+			name: "valid",
+			config: ArgoCDProviderConfig{
+				CertData:       types.StringValue(ca.certPEM),
+				ClientCertData: types.StringValue(client.certPEM),
+				ClientKeyData:  types.StringValue(client.keyPEM),
+			},
+		},
+		{
+			name: "cert_data alongside cert_file",
+			config: ArgoCDProviderConfig{
+				CertData: types.StringValue(ca.certPEM),
+				CertFile: types.StringValue("/etc/ssl/ca.crt"),
+			},
+			expectedPath:  path.Root("cert_data"),
+			expectedError: "Conflicting TLS configuration",
+		},
+		{
+			name: "invalid cert_data",
+			config: ArgoCDProviderConfig{
+				CertData: types.StringValue("foo"),
+			},
+			expectedPath:  path.Root("cert_data"),
+			expectedError: "Invalid certificate",
+		},
+		{
+			name: "client_key_data without client_cert_data",
+			config: ArgoCDProviderConfig{
+				ClientKeyData: types.StringValue(client.keyPEM),
+			},
+			expectedPath:  path.Root("client_cert_data"),
+			expectedError: "Missing client certificate",
+		},
+		{
+			name: "client_cert_data without client_key_data",
+			config: ArgoCDProviderConfig{
+				ClientCertData: types.StringValue(client.certPEM),
+			},
+			expectedPath:  path.Root("client_key_data"),
+			expectedError: "Missing client key",
+		},
+		{
+			name: "client_cert_data alongside client_cert_file",
+			config: ArgoCDProviderConfig{
+				ClientCertData: types.StringValue(client.certPEM),
+				ClientKeyData:  types.StringValue(client.keyPEM),
+				ClientCertFile: types.StringValue("/etc/ssl/tls.crt"),
+			},
+			expectedPath:  path.Root("client_cert_data"),
+			expectedError: "Conflicting TLS configuration",
+		},
+		{
+			name: "mismatched client_key_data",
+			config: ArgoCDProviderConfig{
+				ClientCertData: types.StringValue(client.certPEM),
+				ClientKeyData:  types.StringValue(other.keyPEM),
+			},
+			expectedPath:  path.Root("client_cert_data"),
+			expectedError: "Invalid client certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, diags := tt.config.getClientTLSData()
+
+			if tt.expectedError == "" {
+				assert.False(t, diags.HasError(), "%v", diags)
+				return
+			}
+
+			require.True(t, diags.HasError())
+
+			d, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+
+			assert.Equal(t, tt.expectedError, d.Summary())
+			assert.Equal(t, tt.expectedPath, d.Path())
+		})
+	}
+}
+
+func TestClientTLSData_mTLS(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCertificate(t, nil)
+	client := newTestCertificate(t, ca)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	serverCert, err := tls.X509KeyPair([]byte(ca.certPEM), []byte(ca.keyPEM))
+	require.NoError(t, err)
+
+	// The server requires a client certificate issued by the CA
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    roots,
+		MinVersion:   tls.VersionTLS12,
+	})))
+	version.RegisterVersionServiceServer(s, &fakeVersionServer{})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	si := NewServerInterface(ArgoCDProviderConfig{
+		ServerAddr:     types.StringValue(l.Addr().String()),
+		AuthToken:      types.StringValue("foo"),
+		CertData:       types.StringValue(ca.certPEM),
+		ClientCertData: types.StringValue(client.certPEM),
+		ClientKeyData:  types.StringValue(client.keyPEM),
+	})
+
+	diags := si.InitClients(context.Background())
+	assert.False(t, diags.HasError(), "%v", diags)
+}
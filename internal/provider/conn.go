@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/common"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	httputil "github.com/argoproj/argo-cd/v2/util/http"
+	"github.com/argoproj/argo-cd/v2/util/localconfig"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/proxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// grpcWebProxyBufferSize is the size of the in-memory buffer of the
+// connections to the local gRPC-Web proxy.
+const grpcWebProxyBufferSize = 1 << 20
+
+// connOptions are the options of the connections to the ArgoCD API server.
+// They are resolved by the ArgoCD API client, from the API client options
+// derived from the provider configuration and from the local ArgoCD
+// configuration if any. Connections are nonetheless established by the
+// provider, as the API client neither allows configuring how they are (e.g.
+// through a proxy) nor installing interceptors.
+type connOptions struct {
+	serverAddr      string
+	authToken       string
+	plainText       bool
+	grpcWeb         bool
+	grpcWebRootPath string
+	tlsConfig       *tls.Config
+	headers         metadata.MD
+	userAgent       string
+
+	proxy *proxyOptions
+
+	// httpClient sends the gRPC-Web and REST requests, along with the
+	// additional headers.
+	httpClient *http.Client
+}
+
+// newConnOptions resolves the connection options using the ArgoCD API client,
+// which starts to forward a local port to the ArgoCD API server if port
+// forwarding is enabled.
+func newConnOptions(opts *apiclient.ClientOptions, tlsData clientTLSData, proxy *proxyOptions) (*connOptions, error) {
+	acOpts := *opts
+
+	// The API client sends a request using the gRPC protocol when created,
+	// unless gRPC-Web is enabled, which would not go through the proxy. The
+	// provider sends it instead, see dialServer.
+	acOpts.GRPCWeb = true
+
+	if acOpts.ServerName == "" {
+		acOpts.ServerName = common.DefaultServerName
+	}
+
+	ac, err := apiclient.NewClient(&acOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := ac.ClientOptions()
+
+	o := &connOptions{
+		serverAddr:      resolved.ServerAddr,
+		authToken:       resolved.AuthToken,
+		plainText:       resolved.PlainText,
+		grpcWeb:         opts.GRPCWeb,
+		grpcWebRootPath: opts.GRPCWebRootPath,
+		proxy:           proxy,
+	}
+
+	if err := o.setLocalConfig(opts); err != nil {
+		return nil, err
+	}
+
+	if !o.grpcWeb && o.grpcWebRootPath == "" && !strings.Contains(o.serverAddr, ":") {
+		// Same default as the ArgoCD API client
+		o.serverAddr += ":443"
+	}
+
+	// The HTTP client of the API client holds the resolved TLS configuration
+	// and headers.
+	o.httpClient, err = ac.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	th, ok := o.httpClient.Transport.(*httputil.TransportWithHeader)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport %T of ArgoCD API client", o.httpClient.Transport)
+	}
+
+	t, ok := th.RoundTripper.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport %T of ArgoCD API client", th.RoundTripper)
+	}
+
+	if proxy != nil {
+		t.Proxy = proxy.requestProxyFunc()
+	}
+
+	o.tlsConfig = t.TLSClientConfig
+
+	if err := tlsData.apply(o.tlsConfig); err != nil {
+		return nil, err
+	}
+
+	o.userAgent = th.Header.Get("User-Agent")
+	o.headers = metadata.MD{}
+
+	for k, vs := range th.Header {
+		if http.CanonicalHeaderKey(k) == "User-Agent" {
+			continue
+		}
+
+		o.headers.Append(k, vs...)
+	}
+
+	return o, nil
+}
+
+// setLocalConfig enables the gRPC-Web protocol if the context of the local
+// ArgoCD configuration, if any, does. This is not exposed by the ArgoCD API
+// client.
+func (o *connOptions) setLocalConfig(opts *apiclient.ClientOptions) error {
+	localCfg, err := localconfig.ReadLocalConfig(opts.ConfigPath)
+	if err != nil || localCfg == nil {
+		return err
+	}
+
+	configCtx, err := localCfg.ResolveContext(opts.Context)
+	if err != nil {
+		return err
+	}
+
+	o.grpcWeb = o.grpcWeb || configCtx.Server.GRPCWeb
+
+	if o.grpcWebRootPath == "" {
+		o.grpcWebRootPath = configCtx.Server.GRPCWebRootPath
+	}
+
+	return nil
+}
+
+// dialContext establishes a network connection to `addr`, through the proxy
+// configured by the provider if any, and otherwise through the SOCKS5 proxy
+// configured using the `ALL_PROXY` environment variable, if any, as the ArgoCD
+// API client does.
+func (o *connOptions) dialContext(ctx context.Context, addr string) (net.Conn, error) {
+	if o.proxy != nil {
+		return o.proxy.dialContext(ctx, addr)
+	}
+
+	return proxy.Dial(ctx, "tcp", addr)
+}
+
+// dial returns a connection to the ArgoCD API server, authenticated using
+// `creds`, along with a closer releasing it. Requests are sent using the
+// gRPC-Web protocol through an in-process proxy if `grpc_web` is enabled.
+// Requests go through `interceptors`, the first one being called first.
+func (o *connOptions) dial(creds credentials.PerRPCCredentials, interceptors ...grpc.UnaryClientInterceptor) (*grpc.ClientConn, io.Closer, error) {
+	dialOpts := []grpc.DialOption{
+		grpc.WithPerRPCCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(apiclient.MaxGRPCMessageSize), grpc.MaxCallSendMsgSize(apiclient.MaxGRPCMessageSize)),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	}
+
+	// The HTTP client sends the additional headers of gRPC-Web requests
+	if o.grpcWeb || o.grpcWebRootPath != "" {
+		return o.dialGRPCWeb(dialOpts)
+	}
+
+	dialOpts = append(dialOpts,
+		grpc.WithUserAgent(o.userAgent),
+		grpc.WithChainUnaryInterceptor(headersUnaryClientInterceptor(o.headers)),
+		grpc.WithContextDialer(o.dialContext),
+	)
+
+	if o.plainText {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(o.tlsConfig)))
+	}
+
+	conn, err := grpc.NewClient("passthrough:///"+o.serverAddr, dialOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, conn, nil
+}
+
+// dialServer returns a connection to the ArgoCD API server, as dial does. As
+// the ArgoCD API client does, it falls back to the gRPC-Web protocol if gRPC
+// requests cannot be sent, e.g. through a proxy that does not support HTTP/2,
+// but gRPC-Web requests can.
+func (o *connOptions) dialServer(ctx context.Context, creds credentials.PerRPCCredentials, interceptors ...grpc.UnaryClientInterceptor) (*grpc.ClientConn, io.Closer, error) {
+	conn, closer, err := o.dial(creds, interceptors...)
+	if err != nil || o.grpcWeb || o.grpcWebRootPath != "" {
+		return conn, closer, err
+	}
+
+	if _, err = version.NewVersionServiceClient(conn).Version(ctx, &empty.Empty{}); err == nil {
+		return conn, closer, nil
+	}
+
+	web := *o
+	web.grpcWeb = true
+
+	webConn, webCloser, werr := web.dial(creds, interceptors...)
+	if werr != nil {
+		return conn, closer, nil
+	}
+
+	if _, werr = version.NewVersionServiceClient(webConn).Version(ctx, &empty.Empty{}); werr != nil {
+		_ = webCloser.Close()
+		return conn, closer, nil
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("gRPC requests to the ArgoCD API server failed (%s) but gRPC-Web requests succeeded, set `grpc_web = true` to avoid this warning", err))
+
+	_ = closer.Close()
+	*o = web
+
+	return webConn, webCloser, nil
+}
+
+// dialGRPCWeb returns a connection to an in-process gRPC server forwarding
+// requests to the ArgoCD API server using the gRPC-Web protocol, along with a
+// closer releasing both.
+func (o *connOptions) dialGRPCWeb(dialOpts []grpc.DialOption) (*grpc.ClientConn, io.Closer, error) {
+	l := bufconn.Listen(grpcWebProxyBufferSize)
+
+	s := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+			return o.forwardGRPCWeb(stream)
+		}),
+	)
+
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	dialOpts = append(dialOpts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.DialContext(ctx)
+		}),
+	)
+
+	conn, err := grpc.NewClient("passthrough:///grpc-web", dialOpts...)
+	if err != nil {
+		s.Stop()
+		return nil, nil, err
+	}
+
+	return conn, closerFunc(func() error {
+		err := conn.Close()
+		s.Stop()
+		o.httpClient.CloseIdleConnections()
+
+		return err
+	}), nil
+}
+
+// forwardGRPCWeb forwards the request received on `stream` to the ArgoCD API
+// server using the gRPC-Web protocol, and the responses back.
+func (o *connOptions) forwardGRPCWeb(stream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "failed to get method name from stream")
+	}
+
+	var msg []byte
+	if err := stream.RecvMsg(&msg); err != nil {
+		return err
+	}
+
+	scheme := "https"
+	if o.plainText {
+		scheme = "http"
+	}
+
+	u := fmt.Sprintf("%s://%s%s", scheme, o.serverAddr, method)
+	if rootPath := strings.Trim(o.grpcWebRootPath, "/"); rootPath != "" {
+		u = fmt.Sprintf("%s://%s/%s%s", scheme, o.serverAddr, rootPath, method)
+	}
+
+	req, err := http.NewRequestWithContext(stream.Context(), http.MethodPost, u, bytes.NewReader(grpcWebFrame(0, msg)))
+	if err != nil {
+		return err
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	for k, vs := range md {
+		if strings.HasPrefix(k, ":") || k == "user-agent" {
+			continue
+		}
+
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return status.Errorf(codes.Unavailable, "%s %s failed with status code %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+	}
+
+	// Trailers-only responses hold the status in the headers
+	if err := grpcWebStatus(resp.Header.Get("Grpc-Status"), resp.Header.Get("Grpc-Message")); err != nil {
+		return err
+	}
+
+	for {
+		header := make([]byte, 5)
+		if _, err := io.ReadFull(resp.Body, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		data := make([]byte, binary.BigEndian.Uint32(header[1:]))
+		if _, err := io.ReadFull(resp.Body, data); err != nil {
+			return err
+		}
+
+		if header[0]&grpcWebTrailerFlag != 0 {
+			trailers, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(append(data, "\r\n"...)))).ReadMIMEHeader()
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to parse gRPC-Web trailers: %s", err)
+			}
+
+			return grpcWebStatus(trailers.Get("Grpc-Status"), trailers.Get("Grpc-Message"))
+		}
+
+		if err := stream.SendMsg(data); err != nil {
+			return err
+		}
+	}
+}
+
+// grpcWebTrailerFlag flags gRPC-Web frames holding the trailers.
+const grpcWebTrailerFlag = 0x80
+
+// grpcWebFrame returns a gRPC-Web frame holding `data`.
+func grpcWebFrame(flags byte, data []byte) []byte {
+	frame := make([]byte, 5, 5+len(data))
+	frame[0] = flags
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+
+	return append(frame, data...)
+}
+
+// grpcWebStatus returns the error described by the `grpc-status` and
+// `grpc-message` headers or trailers of a gRPC-Web response, if any.
+func grpcWebStatus(code, message string) error {
+	if code == "" {
+		return nil
+	}
+
+	c, err := strconv.ParseUint(code, 10, 32)
+	if err != nil {
+		return status.Error(codes.Unknown, message)
+	}
+
+	if codes.Code(c) == codes.OK {
+		return nil
+	}
+
+	if m, err := url.PathUnescape(message); err == nil {
+		message = m
+	}
+
+	return status.Error(codes.Code(c), message)
+}
+
+// tokenCredentials authenticates requests to the ArgoCD API server using a
+// token.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{apiclient.MetaDataTokenKey: string(t)}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// headersUnaryClientInterceptor returns a gRPC client interceptor that sends
+// the additional `headers` along with each request.
+func headersUnaryClientInterceptor(headers metadata.MD) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for k, vs := range headers {
+			for _, v := range vs {
+				ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// rawCodec passes messages through as is.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message of type %T", v)
+	}
+
+	return b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message of type %T", v)
+	}
+
+	*b = data
+
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
+
+// closerFunc is a function releasing resources.
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// headersSessionServer records the metadata of the requests it receives.
+type headersSessionServer struct {
+	session.UnimplementedSessionServiceServer
+
+	md chan metadata.MD
+}
+
+func (s *headersSessionServer) GetUserInfo(ctx context.Context, _ *session.GetUserInfoRequest) (*session.GetUserInfoResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.md <- md
+
+	if len(md.Get(apiclient.MetaDataTokenKey)) == 0 || md.Get(apiclient.MetaDataTokenKey)[0] != "foo" {
+		return nil, status.Error(codes.Unauthenticated, "invalid session: token is expired")
+	}
+
+	return &session.GetUserInfoResponse{LoggedIn: true, Username: "admin"}, nil
+}
+
+// startGRPCWebServer starts a server only accepting gRPC-Web requests, over
+// HTTP/1.1, and returns its address.
+func startGRPCWebServer(t *testing.T, register func(*grpc.Server)) string {
+	t.Helper()
+
+	s := grpc.NewServer()
+	register(s)
+
+	srv := httptest.NewServer(grpcweb.WrapServer(s))
+	t.Cleanup(srv.Close)
+
+	return srv.Listener.Addr().String()
+}
+
+func TestConnOptions_dial(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		grpcWeb bool
+		start   func(t *testing.T, register func(*grpc.Server)) string
+	}{
+		{
+			name: "gRPC",
+			start: func(t *testing.T, register func(*grpc.Server)) string {
+				return startFakeServer(t, register).Target()
+			},
+		},
+		{
+			name:    "gRPC-Web",
+			grpcWeb: true,
+			start:   startGRPCWebServer,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &headersSessionServer{md: make(chan metadata.MD, 2)}
+			addr := tt.start(t, func(s *grpc.Server) {
+				session.RegisterSessionServiceServer(s, srv)
+			})
+
+			o, err := newConnOptions(&apiclient.ClientOptions{
+				ServerAddr: addr,
+				PlainText:  true,
+				GRPCWeb:    tt.grpcWeb,
+				Headers:    []string{"Hello:HiThere"},
+				UserAgent:  "terraform-provider-argocd/test",
+			}, clientTLSData{}, nil)
+			require.NoError(t, err)
+
+			for token, expectedCode := range map[string]codes.Code{"foo": codes.OK, "bar": codes.Unauthenticated} {
+				conn, closer, err := o.dial(tokenCredentials(token))
+				require.NoError(t, err)
+
+				_, err = session.NewSessionServiceClient(conn).GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+				require.NoError(t, closer.Close())
+
+				// Errors are returned as is, including gRPC-Web trailers
+				assert.Equal(t, expectedCode, status.Code(err), "%v", err)
+
+				if expectedCode != codes.OK {
+					assert.Contains(t, err.Error(), "token is expired")
+				}
+
+				md := <-srv.md
+				assert.Equal(t, []string{token}, md.Get(apiclient.MetaDataTokenKey))
+				assert.Equal(t, []string{"HiThere"}, md.Get("hello"))
+				require.NotEmpty(t, md.Get("user-agent"))
+				assert.True(t, strings.HasPrefix(md.Get("user-agent")[0], "terraform-provider-argocd/test"), md.Get("user-agent")[0])
+			}
+		})
+	}
+}
+
+func TestConnOptions_dialServer(t *testing.T) {
+	t.Parallel()
+
+	addr := startGRPCWebServer(t, func(s *grpc.Server) {
+		version.RegisterVersionServiceServer(s, &fakeVersionServer{})
+	})
+
+	o, err := newConnOptions(&apiclient.ClientOptions{ServerAddr: addr, PlainText: true}, clientTLSData{}, nil)
+	require.NoError(t, err)
+
+	// gRPC-Web is used as the server does not accept gRPC requests
+	conn, closer, err := o.dialServer(context.Background(), tokenCredentials("foo"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = closer.Close()
+	})
+
+	assert.True(t, o.grpcWeb)
+
+	_, err = version.NewVersionServiceClient(conn).Version(context.Background(), &empty.Empty{})
+	assert.NoError(t, err)
+}
+
+func TestConnOptions_dialServer_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	o, err := newConnOptions(&apiclient.ClientOptions{ServerAddr: addr, PlainText: true}, clientTLSData{}, nil)
+	require.NoError(t, err)
+
+	// The failure is left to the first request
+	conn, closer, err := o.dialServer(context.Background(), tokenCredentials("foo"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = closer.Close()
+	})
+
+	assert.False(t, o.grpcWeb)
+
+	_, err = version.NewVersionServiceClient(conn).Version(context.Background(), &empty.Empty{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
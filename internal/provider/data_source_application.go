@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -93,42 +94,54 @@ func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 func readApplication(ctx context.Context, si *ServerInterface, data *applicationModel) (diags diag.Diagnostics) {
 	ids := strings.Split(data.ID.ValueString(), ":")
-	appName := ids[0]
-	namespace := ids[1]
+
+	app, diags := getApplication(ctx, si, ids[0], ids[1])
+	if diags.HasError() {
+		return diags
+	}
+
+	if app == nil {
+		data.ID = types.StringUnknown()
+		return diags
+	}
+
+	data.Metadata = newObjectMeta(app.ObjectMeta)
+	data.Spec = newApplicationSpec(app.Spec)
+	data.Status = newApplicationStatus(app.Status)
+
+	return diags
+}
+
+// getApplication returns the application with the given name and namespace, or
+// nil if it does not exist.
+func getApplication(ctx context.Context, si *ServerInterface, name, namespace string) (*v1alpha1.Application, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
 	apps, err := si.ApplicationClient.List(ctx, &application.ApplicationQuery{
-		Name:         &appName,
+		Name:         &name,
 		AppNamespace: &namespace,
 	})
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
-			data.ID = types.StringUnknown()
-			return diags
+		if diagnostics.IsNotFound(err) {
+			return nil, diags
 		}
 
-		diags.Append(diagnostics.ArgoCDAPIError("read", "application", appName, err)...)
+		diags.Append(diagnostics.ArgoCDAPIError("read", "application", name, err)...)
 
-		return diags
+		return nil, diags
 	}
 
 	l := len(apps.Items)
 
 	switch {
 	case l < 1:
-		data.ID = types.StringUnknown()
-		return diags
+		return nil, diags
 	case l == 1:
 		break
 	case l > 1:
-		diags.AddError(fmt.Sprintf("found multiple applications matching name '%s' and namespace '%s'", appName, namespace), "")
-		return diags
+		diags.AddError(fmt.Sprintf("found multiple applications matching name '%s' and namespace '%s'", name, namespace), "")
+		return nil, diags
 	}
 
-	app := apps.Items[0]
-
-	data.Metadata = newObjectMeta(app.ObjectMeta)
-	data.Spec = newApplicationSpec(app.Spec)
-	data.Status = newApplicationStatus(app.Status)
-
-	return diags
+	return &apps.Items[0], diags
 }
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &applicationEventsDataSource{}
+
+func NewArgoCDApplicationEventsDataSource() datasource.DataSource {
+	return &applicationEventsDataSource{}
+}
+
+// applicationEventsDataSource defines the data source implementation.
+type applicationEventsDataSource struct {
+	si *ServerInterface
+}
+
+func (d *applicationEventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_events"
+}
+
+func (d *applicationEventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the Kubernetes events of an existing ArgoCD application, or of a specific resource managed by the application.",
+		Attributes:          applicationEventsSchemaAttributes(),
+	}
+}
+
+func (d *applicationEventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	si, ok := req.ProviderData.(*ServerInterface)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data",
+			fmt.Sprintf("Expected *ServerInterface, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.si = si
+}
+
+func (d *applicationEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data applicationEventsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(d.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read events
+	events, err := listApplicationEvents(ctx, d.si, &application.ApplicationResourceEventsQuery{
+		Name:              data.ApplicationName.ValueStringPointer(),
+		AppNamespace:      data.ApplicationNamespace.ValueStringPointer(),
+		Project:           data.Project.ValueStringPointer(),
+		ResourceName:      data.ResourceName.ValueStringPointer(),
+		ResourceNamespace: data.ResourceNamespace.ValueStringPointer(),
+		ResourceUID:       data.ResourceUID.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("read", "events for application", data.ApplicationName.ValueString(), err)...)
+		return
+	}
+
+	data.ID = types.StringValue(strings.Join([]string{
+		data.ApplicationName.ValueString(),
+		data.ApplicationNamespace.ValueString(),
+		data.ResourceNamespace.ValueString(),
+		data.ResourceName.ValueString(),
+	}, ":"))
+	data.Events = newApplicationEvents(events)
+
+	tflog.Trace(ctx, "read ArgoCD application events")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listApplicationEvents returns the Kubernetes events of an application, or of
+// a specific resource managed by the application, ordered from oldest to most
+// recent.
+func listApplicationEvents(ctx context.Context, si *ServerInterface, q *application.ApplicationResourceEventsQuery) ([]corev1.Event, error) {
+	el, err := si.ApplicationClient.ListResourceEvents(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	events := el.Items
+
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, tj := eventLastTimestamp(events[i]), eventLastTimestamp(events[j])
+		return ti.Before(&tj)
+	})
+
+	return events, nil
+}
+
+// lastWarningEvents returns (at most) the n most recent events of type
+// `Warning`, ordered from oldest to most recent. Events are expected to already
+// be ordered as returned by listApplicationEvents.
+func lastWarningEvents(events []corev1.Event, n int) []corev1.Event {
+	var warnings []corev1.Event
+
+	for _, e := range events {
+		if e.Type == corev1.EventTypeWarning {
+			warnings = append(warnings, e)
+		}
+	}
+
+	if len(warnings) > n {
+		warnings = warnings[len(warnings)-n:]
+	}
+
+	return warnings
+}
+
+// formatEvent returns a single line, human-readable, representation of an event.
+func formatEvent(e corev1.Event) string {
+	s := fmt.Sprintf("%s %s %s", eventLastTimestamp(e).String(), e.Type, e.Reason)
+
+	if e.Count > 1 {
+		s = fmt.Sprintf("%s (x%d)", s, e.Count)
+	}
+
+	return fmt.Sprintf("%s: %s", s, e.Message)
+}
+
+// eventLastTimestamp returns the time at which the most recent occurrence of an
+// event was recorded. Events emitted through the `events.k8s.io` API do not
+// set `LastTimestamp`, so fall back to the event series or event time.
+func eventLastTimestamp(e corev1.Event) metav1.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return metav1.Time{Time: e.Series.LastObservedTime.Time}
+	case !e.EventTime.IsZero():
+		return metav1.Time{Time: e.EventTime.Time}
+	default:
+		return e.FirstTimestamp
+	}
+}
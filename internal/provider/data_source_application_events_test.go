@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccArgoCDApplicationEventsDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"argocd": {
+						VersionConstraint: "~> 5.0",
+						Source:            "oboukili/argocd",
+					},
+				},
+				Config: `
+resource "argocd_application" "events" {
+	metadata {
+		name      = "events"
+		namespace = "argocd"
+	}
+
+	spec {
+		destination {
+			server    = "https://kubernetes.default.svc"
+			namespace = "events"
+		}
+
+		source {
+			repo_url        = "https://github.com/argoproj/argocd-example-apps.git"
+			path            = "guestbook"
+			target_revision = "HEAD"
+		}
+
+		sync_policy {
+			automated {}
+			sync_options = ["CreateNamespace=true"]
+		}
+	}
+
+	wait = true
+}
+				`,
+			},
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config: `
+data "argocd_application_events" "events" {
+	application_name      = "events"
+	application_namespace = "argocd"
+}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.argocd_application_events.events", "application_name", "events"),
+					resource.TestCheckResourceAttrSet("data.argocd_application_events.events", "events.0.reason"),
+					resource.TestCheckResourceAttrSet("data.argocd_application_events.events", "events.0.message"),
+					resource.TestCheckResourceAttrSet("data.argocd_application_events.events", "events.0.type"),
+					resource.TestCheckResourceAttrSet("data.argocd_application_events.events", "events.0.last_timestamp"),
+				),
+			},
+		},
+	})
+}
+
+func TestLastWarningEvents(t *testing.T) {
+	t.Parallel()
+
+	event := func(eventType, reason string) corev1.Event {
+		return corev1.Event{Type: eventType, Reason: reason}
+	}
+
+	events := []corev1.Event{
+		event(corev1.EventTypeWarning, "first"),
+		event(corev1.EventTypeNormal, "second"),
+		event(corev1.EventTypeWarning, "third"),
+		event(corev1.EventTypeWarning, "fourth"),
+	}
+
+	tests := []struct {
+		name string
+		n    int
+		want []corev1.Event
+	}{
+		{
+			name: "fewer warnings than limit",
+			n:    5,
+			want: []corev1.Event{events[0], events[2], events[3]},
+		},
+		{
+			name: "more warnings than limit",
+			n:    2,
+			want: []corev1.Event{events[2], events[3]},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, lastWarningEvents(events, tt.n))
+		})
+	}
+}
+
+func TestEventLastTimestamp(t *testing.T) {
+	t.Parallel()
+
+	first := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	last := metav1.NewTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name  string
+		event corev1.Event
+		want  metav1.Time
+	}{
+		{
+			name:  "last timestamp",
+			event: corev1.Event{FirstTimestamp: first, LastTimestamp: last},
+			want:  last,
+		},
+		{
+			name:  "event series",
+			event: corev1.Event{EventTime: metav1.NewMicroTime(first.Time), Series: &corev1.EventSeries{LastObservedTime: metav1.NewMicroTime(last.Time)}},
+			want:  last,
+		},
+		{
+			name:  "event time",
+			event: corev1.Event{EventTime: metav1.NewMicroTime(last.Time)},
+			want:  last,
+		},
+		{
+			name:  "first timestamp only",
+			event: corev1.Event{FirstTimestamp: first},
+			want:  first,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := eventLastTimestamp(tt.event)
+			assert.True(t, tt.want.Equal(&got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
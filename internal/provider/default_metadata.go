@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/oboukili/terraform-provider-argocd/internal/utils"
+	"github.com/oboukili/terraform-provider-argocd/internal/validators"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DefaultMetadata struct {
+	Annotations types.Map `tfsdk:"annotations"`
+	Labels      types.Map `tfsdk:"labels"`
+}
+
+// DefaultMetadata returns the annotations and labels that are merged into the
+// metadata of the objects managed by the provider.
+func (si *ServerInterface) DefaultMetadata() metav1.ObjectMeta {
+	var om metav1.ObjectMeta
+
+	if len(si.config.DefaultMetadata) == 0 {
+		return om
+	}
+
+	dm := si.config.DefaultMetadata[0]
+
+	// Unknown values cannot be set by the provider configuration at this
+	// point, they are ignored like null ones.
+	_ = dm.Annotations.ElementsAs(context.Background(), &om.Annotations, false)
+	_ = dm.Labels.ElementsAs(context.Background(), &om.Labels, false)
+
+	return om
+}
+
+// MergeDefaultMetadata returns the annotations or labels in `m` merged with the
+// default ones, the values in `m` taking precedence.
+func MergeDefaultMetadata(m, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return m
+	}
+
+	merged := make(map[string]string, len(m)+len(defaults))
+
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	for k, v := range m {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// RemoveDefaultMetadata returns a copy of the annotations or labels in `m`
+// returned by the API without the keys holding their default value, unless
+// they are explicitly managed. Keys whose value differs from the default one
+// are retained so that the drift is reported.
+func RemoveDefaultMetadata(m, defaults, managed map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return m
+	}
+
+	r := make(map[string]string, len(m))
+
+	for k, v := range m {
+		if d, ok := defaults[k]; ok && d == v {
+			if _, ok := managed[k]; !ok {
+				continue
+			}
+		}
+
+		r[k] = v
+	}
+
+	return r
+}
+
+// withDefaultMetadata returns `om` merged with the default metadata of the
+// provider.
+func (si *ServerInterface) withDefaultMetadata(om metav1.ObjectMeta) metav1.ObjectMeta {
+	defaults := si.DefaultMetadata()
+
+	om.Annotations = MergeDefaultMetadata(om.Annotations, defaults.Annotations)
+	om.Labels = MergeDefaultMetadata(om.Labels, defaults.Labels)
+
+	return om
+}
+
+// withoutDefaultMetadata returns `om`, as returned by the API, without the
+// default annotations and labels that are not explicitly managed in `managed`
+// (see RemoveDefaultMetadata).
+func (si *ServerInterface) withoutDefaultMetadata(om metav1.ObjectMeta, managed objectMeta) metav1.ObjectMeta {
+	defaults := si.DefaultMetadata()
+
+	om.Annotations = RemoveDefaultMetadata(om.Annotations, defaults.Annotations, utils.MapMap(managed.Annotations, types.String.ValueString))
+	om.Labels = RemoveDefaultMetadata(om.Labels, defaults.Labels, utils.MapMap(managed.Labels, types.String.ValueString))
+
+	return om
+}
+
+// validatePlannedMetadata validates the annotations and labels of the planned
+// object, whose metadata is found at `metadata`, once merged with the default
+// metadata of the provider, as they will be sent to the ArgoCD API server.
+func validatePlannedMetadata(ctx context.Context, si *ServerInterface, plan tfsdk.Plan, metadata path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if si == nil {
+		return diags
+	}
+
+	defaults := si.DefaultMetadata()
+
+	for _, a := range []struct {
+		name      string
+		defaults  map[string]string
+		validator validator.Map
+	}{
+		{"annotations", defaults.Annotations, validators.MetadataAnnotations()},
+		{"labels", defaults.Labels, validators.MetadataLabels()},
+	} {
+		if len(a.defaults) == 0 {
+			continue
+		}
+
+		p := metadata.AtName(a.name)
+
+		var planned types.Map
+
+		diags.Append(plan.GetAttribute(ctx, p, &planned)...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		if !utils.IsFullyKnown(ctx, planned) {
+			continue
+		}
+
+		var m map[string]string
+
+		diags.Append(planned.ElementsAs(ctx, &m, false)...)
+
+		merged, d := types.MapValueFrom(ctx, types.StringType, MergeDefaultMetadata(m, a.defaults))
+		diags.Append(d...)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		resp := &validator.MapResponse{}
+		a.validator.ValidateMap(ctx, validator.MapRequest{Path: p, ConfigValue: merged}, resp)
+
+		diags.Append(resp.Diagnostics...)
+	}
+
+	return diags
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeDefaultMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		m        map[string]string
+		defaults map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no defaults",
+			m:        map[string]string{"app": "foo"},
+			expected: map[string]string{"app": "foo"},
+		},
+		{
+			name:     "defaults only",
+			defaults: map[string]string{"team": "platform"},
+			expected: map[string]string{"team": "platform"},
+		},
+		{
+			name:     "explicit values take precedence",
+			m:        map[string]string{"app": "foo", "team": "apps"},
+			defaults: map[string]string{"team": "platform", "env": "prod"},
+			expected: map[string]string{"app": "foo", "team": "apps", "env": "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, MergeDefaultMetadata(tt.m, tt.defaults))
+		})
+	}
+}
+
+func TestRemoveDefaultMetadata(t *testing.T) {
+	t.Parallel()
+
+	defaults := map[string]string{"team": "platform", "env": "prod"}
+
+	tests := []struct {
+		name     string
+		m        map[string]string
+		managed  map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "default values are removed",
+			m:        map[string]string{"app": "foo", "team": "platform", "env": "prod"},
+			managed:  map[string]string{"app": "foo"},
+			expected: map[string]string{"app": "foo"},
+		},
+		{
+			name:     "managed keys are retained",
+			m:        map[string]string{"app": "foo", "team": "platform", "env": "prod"},
+			managed:  map[string]string{"app": "foo", "team": "platform"},
+			expected: map[string]string{"app": "foo", "team": "platform"},
+		},
+		{
+			name:     "drifted values are retained",
+			m:        map[string]string{"team": "apps", "env": "prod"},
+			expected: map[string]string{"team": "apps"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := make(map[string]string, len(tt.m))
+			for k, v := range tt.m {
+				m[k] = v
+			}
+
+			assert.Equal(t, tt.expected, RemoveDefaultMetadata(m, defaults, tt.managed))
+
+			// The map returned by the API (which may be cached) is left as is
+			assert.Equal(t, tt.m, m)
+		})
+	}
+}
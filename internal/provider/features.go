@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/settings"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
+	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+)
+
+// probeResult is the result of the runtime probe of a feature.
+type probeResult struct {
+	enabled bool
+	reason  string
+	err     error
+}
+
+// diagnostics returns a warning if the probe failed.
+func (r probeResult) diagnostics(fc features.FeatureConstraint) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.err != nil {
+		diags.AddWarning(
+			fmt.Sprintf("Failed to check whether the ArgoCD server supports %s", fc.Name),
+			fmt.Sprintf("%s. The feature is assumed to be supported, leaving it up to the ArgoCD API server to reject it.", r.err),
+		)
+	}
+
+	return diags
+}
+
+// FeatureSupport returns whether a feature is supported by the version of the
+// ArgoCD server and, for features declaring a probe, enabled on the server.
+// Otherwise, the reason why the feature is not available is returned as well.
+// Features are assumed to be supported until the version of the server is
+// known. Probes are only run once the API clients have been initialized, and
+// their results are cached. Features whose probe fails are assumed to be
+// enabled, leaving it up to the ArgoCD API server to reject them, in which case
+// a warning is returned.
+//
+// Note: progressive syncs (`features.ApplicationSetProgressiveSync`) are only
+// checked against the version of the server, and the SCM providers allowed in
+// application set generators are not checked at all. Both are enabled by flags
+// of the ApplicationSet controller (`applicationsetcontroller.enable.progressive.syncs`
+// and `applicationsetcontroller.allowed.scm.providers` in the
+// `argocd-cmd-params-cm` ConfigMap) that are not exposed by the ArgoCD API.
+func (si *ServerInterface) FeatureSupport(ctx context.Context, feature features.Feature) (bool, string, diag.Diagnostics) {
+	fc, ok := features.ConstraintsMap[feature]
+	if !ok {
+		return false, fmt.Sprintf("Unknown feature %d.", feature), nil
+	}
+
+	si.RLock()
+	initialized := si.initialized
+	serverVersion := si.ServerVersion
+	si.RUnlock()
+
+	// Nothing can be checked until the version of the server is known.
+	if serverVersion == nil {
+		return true, "", nil
+	}
+
+	if fc.MinVersion.Compare(serverVersion) == 1 {
+		return false, fmt.Sprintf("ArgoCD %s or later is required, whereas the server runs ArgoCD %s.", fc.MinVersion, serverVersion), nil
+	}
+
+	if fc.Probe == nil || !initialized {
+		return true, "", nil
+	}
+
+	si.probeMu.Lock()
+	defer si.probeMu.Unlock()
+
+	r, ok := si.probes[feature]
+	if !ok {
+		enabled, reason, err := fc.Probe(ctx, serverProbe{si})
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("failed to probe whether the ArgoCD server supports %s, assuming it does", fc.Name), map[string]interface{}{
+				"error": err.Error(),
+			})
+
+			enabled, reason = true, ""
+		}
+
+		r = probeResult{enabled: enabled, reason: reason, err: err}
+
+		if si.probes == nil {
+			si.probes = map[features.Feature]probeResult{}
+		}
+
+		si.probes[feature] = r
+	}
+
+	return r.enabled, r.reason, r.diagnostics(fc)
+}
+
+// getSettings returns the settings of the ArgoCD API server, which are only
+// requested once.
+func (si *ServerInterface) getSettings(ctx context.Context) (*settings.Settings, error) {
+	si.probeMu.Lock()
+	defer si.probeMu.Unlock()
+
+	return serverProbe{si}.Settings(ctx)
+}
+
+// serverProbe implements `features.Server`. Its methods must be called whilst
+// holding `probeMu`.
+type serverProbe struct {
+	si *ServerInterface
+}
+
+func (p serverProbe) Settings(ctx context.Context) (*settings.Settings, error) {
+	if p.si.serverSettings != nil {
+		return p.si.serverSettings, nil
+	}
+
+	s, err := p.si.SettingsClient.Get(ctx, &settings.SettingsQuery{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	p.si.serverSettings = s
+
+	return s, nil
+}
+
+func (p serverProbe) KubernetesResourceExists(_ context.Context, groupVersion, resource string) (bool, bool, error) {
+	if p.si.kubeClientConfig == nil {
+		return false, false, nil
+	}
+
+	restConfig, err := p.si.kubeClientConfig.ClientConfig()
+	if err != nil {
+		return false, false, fmt.Errorf("failed to load Kubernetes client configuration: %w", err)
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to create Kubernetes discovery client: %w", err)
+	}
+
+	rl, err := dc.ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, true, nil
+	} else if err != nil {
+		return false, false, fmt.Errorf("failed to discover the resources of %s: %w", groupVersion, err)
+	}
+
+	for _, r := range rl.APIResources {
+		if r.Name == resource {
+			return true, true, nil
+		}
+	}
+
+	return false, true, nil
+}
+
+// checkFeatures returns an error for each attribute of `config` that is
+// guarded by a feature (see `features.FeatureConstraint.Paths`) not supported
+// by the ArgoCD server. The API clients are only initialized if any guarded
+// attribute is set, and nothing is checked while the provider configuration is
+// unknown since the server cannot be reached until apply.
+func checkFeatures(ctx context.Context, si *ServerInterface, resourceType string, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if si == nil || !si.IsConfigKnown() {
+		return diags
+	}
+
+	initialized := false
+
+	unsupported, err := features.CheckPaths[path.Path](resourceType, newConfigValue(ctx, config), func(f features.Feature) (bool, string, error) {
+		if !initialized {
+			diags.Append(si.InitClients(ctx)...)
+
+			if diags.HasError() {
+				return false, "", errors.New("failed to initialize API clients")
+			}
+
+			initialized = true
+		}
+
+		supported, reason, ds := si.FeatureSupport(ctx, f)
+		diags.Append(ds...)
+
+		return supported, reason, nil
+	})
+	if err != nil {
+		return diags
+	}
+
+	for _, u := range unsupported {
+		diags.Append(diagnostics.AttributeFeatureNotSupported(u.Path, u.Feature, u.Reason)...)
+	}
+
+	return diags
+}
+
+// configValue implements `features.Value` for the configuration of resources
+// implemented using the plugin framework. The type of the value is tracked so
+// that the elements of sets can be addressed.
+type configValue struct {
+	ctx context.Context
+	typ attr.Type
+	v   tftypes.Value
+	p   path.Path
+}
+
+func newConfigValue(ctx context.Context, config tfsdk.Config) configValue {
+	var typ attr.Type
+	if config.Schema != nil {
+		typ = config.Schema.Type()
+	}
+
+	return configValue{ctx: ctx, typ: typ, v: config.Raw, p: path.Empty()}
+}
+
+// child returns the value `v`, at `p`, reached from `c` through `step`.
+func (c configValue) child(step tftypes.AttributePathStep, v tftypes.Value, p path.Path) configValue {
+	var typ attr.Type
+
+	if c.typ != nil {
+		if t, err := c.typ.ApplyTerraform5AttributePathStep(step); err == nil {
+			typ, _ = t.(attr.Type)
+		}
+	}
+
+	return configValue{ctx: c.ctx, typ: typ, v: v, p: p}
+}
+
+func (c configValue) IsSet() bool {
+	return c.v.IsKnown() && !c.v.IsNull()
+}
+
+func (c configValue) IsEmpty() bool {
+	switch c.v.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elems []tftypes.Value
+		if err := c.v.As(&elems); err == nil {
+			return len(elems) == 0
+		}
+	case tftypes.Map:
+		var elems map[string]tftypes.Value
+		if err := c.v.As(&elems); err == nil {
+			return len(elems) == 0
+		}
+	}
+
+	if c.v.Type().Is(tftypes.String) {
+		var s string
+		if err := c.v.As(&s); err == nil {
+			return s == ""
+		}
+	}
+
+	if c.v.Type().Is(tftypes.Bool) {
+		var b bool
+		if err := c.v.As(&b); err == nil {
+			return !b
+		}
+	}
+
+	return false
+}
+
+func (c configValue) Path() path.Path {
+	return c.p
+}
+
+func (c configValue) Attribute(name string) (features.Value[path.Path], bool) {
+	if _, ok := c.v.Type().(tftypes.Object); !ok {
+		return nil, false
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := c.v.As(&attrs); err != nil {
+		return nil, false
+	}
+
+	a, ok := attrs[name]
+	if !ok {
+		return nil, false
+	}
+
+	return c.child(tftypes.AttributeName(name), a, c.p.AtName(name)), true
+}
+
+func (c configValue) Elements() ([]features.Value[path.Path], bool) {
+	switch c.v.Type().(type) {
+	case tftypes.List, tftypes.Set:
+	default:
+		return nil, false
+	}
+
+	var vs []tftypes.Value
+	if err := c.v.As(&vs); err != nil {
+		return nil, false
+	}
+
+	_, ordered := c.v.Type().(tftypes.List)
+	elems := make([]features.Value[path.Path], 0, len(vs))
+
+	for i, e := range vs {
+		if ordered {
+			elems = append(elems, c.child(tftypes.ElementKeyInt(i), e, c.p.AtListIndex(i)))
+			continue
+		}
+
+		// The elements of sets are addressed by value, which requires the
+		// type of the set to be known. The set itself is reported otherwise.
+		ec := c.child(tftypes.ElementKeyValue(e), e, c.p)
+
+		if ec.typ != nil {
+			if ev, err := ec.typ.ValueFromTerraform(c.ctx, e); err == nil {
+				ec.p = c.p.AtSetValue(ev)
+			}
+		}
+
+		elems = append(elems, ec)
+	}
+
+	return elems, ordered
+}
+
+// checkApplicationNamespace returns an error if the namespace of the
+// application in `config` is not the one of the ArgoCD control plane whilst
+// applications in any namespace are not available on the server. Nothing is
+// checked while the provider configuration is unknown.
+func checkApplicationNamespace(ctx context.Context, si *ServerInterface, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if si == nil || !si.IsConfigKnown() {
+		return diags
+	}
+
+	var namespace types.String
+
+	p := path.Root("metadata").AtListIndex(0).AtName("namespace")
+
+	diags.Append(config.GetAttribute(ctx, p, &namespace)...)
+
+	if diags.HasError() || namespace.IsNull() || namespace.IsUnknown() || namespace.ValueString() == "" {
+		return diags
+	}
+
+	diags.Append(si.InitClients(ctx)...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	s, err := si.getSettings(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "failed to get the namespace of the ArgoCD control plane", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return diags
+	}
+
+	if s.ControllerNamespace == "" || namespace.ValueString() == s.ControllerNamespace {
+		return diags
+	}
+
+	supported, reason, ds := si.FeatureSupport(ctx, features.ApplicationsInAnyNamespace)
+	diags.Append(ds...)
+
+	if !supported {
+		diags.Append(diagnostics.AttributeFeatureNotSupported(p, features.ApplicationsInAnyNamespace, reason)...)
+	}
+
+	return diags
+}
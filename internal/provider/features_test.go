@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/settings"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	customtypes "github.com/oboukili/terraform-provider-argocd/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestCheckFeatures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sr := &resource.SchemaResponse{}
+	NewApplicationResource().Schema(ctx, resource.SchemaRequest{}, sr)
+	require.False(t, sr.Diagnostics.HasError(), "%v", sr.Diagnostics)
+
+	plan := tfsdk.Plan{
+		Schema: sr.Schema,
+		Raw:    tftypes.NewValue(sr.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	diags := plan.SetAttribute(ctx, path.Root("spec"), []applicationSpecBlock{
+		{
+			Source: []applicationSourceBlock{
+				{
+					RepoURL: types.StringValue("https://charts.example"),
+					Helm: []applicationSourceHelmBlock{
+						{
+							ValuesObject: customtypes.YAMLStringValue(`{"foo":"bar"}`),
+							KubeVersion:  types.StringValue("1.29.0"),
+						},
+					},
+				},
+				{
+					RepoURL: types.StringValue("https://git.example"),
+					Kustomize: []applicationSourceKustomizeBlock{
+						{
+							Patches: []applicationKustomizePatchBlock{{Patch: types.StringValue("foo")}},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+	tests := []struct {
+		name    string
+		version string
+		unknown bool
+		want    []path.Path
+	}{
+		{
+			name:    "all features supported",
+			version: "2.13.0",
+		},
+		{
+			name:    "helm capabilities not supported",
+			version: "2.9.0",
+			want: []path.Path{
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(0).AtName("helm").AtListIndex(0).AtName("kube_version"),
+			},
+		},
+		{
+			name:    "kustomize patches not supported",
+			version: "2.8.0",
+			want: []path.Path{
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(1).AtName("kustomize").AtListIndex(0).AtName("patches"),
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(0).AtName("helm").AtListIndex(0).AtName("kube_version"),
+			},
+		},
+		{
+			name:    "multiple sources not supported",
+			version: "2.6.0",
+			want: []path.Path{
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(1),
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(0).AtName("helm").AtListIndex(0).AtName("values_object"),
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(1).AtName("kustomize").AtListIndex(0).AtName("patches"),
+				path.Root("spec").AtListIndex(0).AtName("source").AtListIndex(0).AtName("helm").AtListIndex(0).AtName("kube_version"),
+			},
+		},
+		{
+			name:    "unknown provider configuration",
+			version: "2.6.0",
+			unknown: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			si := serverInterfaceTestData(t, tt.version, semverEquals)
+			si.initialized = true
+			si.config.Unknown = tt.unknown
+
+			diags := checkFeatures(ctx, si, "argocd_application", config)
+
+			var got []path.Path
+
+			for _, d := range diags {
+				dp, ok := d.(diag.DiagnosticWithPath)
+				require.True(t, ok)
+
+				got = append(got, dp.Path())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfigValue_UsedPaths(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sr := &resource.SchemaResponse{}
+	NewProjectResource().Schema(ctx, resource.SchemaRequest{}, sr)
+	require.False(t, sr.Diagnostics.HasError(), "%v", sr.Diagnostics)
+
+	plan := tfsdk.Plan{
+		Schema: sr.Schema,
+		Raw:    tftypes.NewValue(sr.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	role := projectRole{
+		Name:     types.StringValue("admins"),
+		Groups:   []types.String{types.StringValue("admins")},
+		Policies: []types.String{types.StringValue("p, proj:foo:admins, applications, get, foo/*, allow")},
+	}
+
+	diags := plan.SetAttribute(ctx, path.Root("spec").AtName("roles"), []projectRole{
+		role,
+		{
+			Name:     types.StringValue("readers"),
+			Policies: []types.String{types.StringValue("p, proj:foo:readers, applications, get, foo/*, allow")},
+		},
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	var roles types.Set
+
+	diags = plan.GetAttribute(ctx, path.Root("spec").AtName("roles"), &roles)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	var expected path.Path
+
+	// The elements of sets are addressed by value
+	for _, v := range roles.Elements() {
+		if o, ok := v.(types.Object); ok && o.Attributes()["name"].Equal(types.StringValue("admins")) {
+			expected = path.Root("spec").AtName("roles").AtSetValue(v).AtName("groups")
+		}
+	}
+
+	got := features.UsedPaths[path.Path](newConfigValue(ctx, tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}), "spec.roles.groups")
+	assert.Equal(t, []path.Path{expected}, got)
+}
+
+// fakeSettingsClient returns `settings`, or `err`, and counts the requests.
+type fakeSettingsClient struct {
+	settings.SettingsServiceClient
+
+	settings *settings.Settings
+	err      error
+	requests atomic.Int32
+}
+
+func (c *fakeSettingsClient) Get(context.Context, *settings.SettingsQuery, ...grpc.CallOption) (*settings.Settings, error) {
+	c.requests.Add(1)
+
+	return c.settings, c.err
+}
+
+func TestServerInterface_FeatureSupport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		version       string
+		settings      *settings.Settings
+		err           error
+		wantSupported bool
+		wantReason    string
+	}{
+		{
+			name:          "version not supported",
+			version:       "2.4.0",
+			settings:      &settings.Settings{ControllerNamespace: "argocd", AppsInAnyNamespaceEnabled: true},
+			wantSupported: false,
+			wantReason:    "ArgoCD 2.5.0 or later is required, whereas the server runs ArgoCD 2.4.0.",
+		},
+		{
+			name:          "enabled",
+			version:       "2.9.0",
+			settings:      &settings.Settings{ControllerNamespace: "argocd", AppsInAnyNamespaceEnabled: true},
+			wantSupported: true,
+		},
+		{
+			name:          "not enabled",
+			version:       "2.9.0",
+			settings:      &settings.Settings{ControllerNamespace: "argocd"},
+			wantSupported: false,
+			wantReason:    "Applications in any namespace are not enabled on the ArgoCD server (see `application.namespaces` in the `argocd-cmd-params-cm` ConfigMap).",
+		},
+		{
+			name:          "not reported by the server",
+			version:       "2.9.0",
+			settings:      &settings.Settings{},
+			wantSupported: true,
+		},
+		{
+			name:          "unknown version",
+			settings:      &settings.Settings{ControllerNamespace: "argocd"},
+			wantSupported: true,
+		},
+		{
+			name:          "probe failure",
+			version:       "2.9.0",
+			err:           errors.New("unavailable"),
+			wantSupported: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sc := &fakeSettingsClient{settings: tt.settings, err: tt.err}
+
+			si := &ServerInterface{}
+			if tt.version != "" {
+				si = serverInterfaceTestData(t, tt.version, semverEquals)
+			}
+
+			si.SettingsClient = sc
+			si.initialized = true
+
+			for i := 0; i < 2; i++ {
+				supported, reason, diags := si.FeatureSupport(context.Background(), features.ApplicationsInAnyNamespace)
+
+				assert.Equal(t, tt.wantSupported, supported)
+				assert.Equal(t, tt.wantReason, reason)
+				assert.False(t, diags.HasError())
+
+				// Failures are reported as warnings, including when cached
+				if tt.err != nil {
+					require.Equal(t, 1, diags.WarningsCount())
+					assert.Equal(t, "Failed to check whether the ArgoCD server supports applications in any namespace", diags.Warnings()[0].Summary())
+					assert.Contains(t, diags.Warnings()[0].Detail(), "unavailable")
+				} else {
+					assert.Empty(t, diags)
+				}
+			}
+
+			// Probe results are cached, including failures
+			if tt.version == "2.9.0" {
+				assert.EqualValues(t, 1, sc.requests.Load())
+			} else {
+				assert.EqualValues(t, 0, sc.requests.Load())
+			}
+		})
+	}
+}
+
+func TestCheckApplicationNamespace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sr := &resource.SchemaResponse{}
+	NewApplicationResource().Schema(ctx, resource.SchemaRequest{}, sr)
+	require.False(t, sr.Diagnostics.HasError(), "%v", sr.Diagnostics)
+
+	si := serverInterfaceTestData(t, "2.9.0", semverEquals)
+	si.SettingsClient = &fakeSettingsClient{settings: &settings.Settings{ControllerNamespace: "argocd"}}
+	si.initialized = true
+
+	tests := []struct {
+		namespace string
+		wantError bool
+	}{
+		{"", false},
+		{"argocd", false},
+		{"team-a", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.namespace, func(t *testing.T) {
+			t.Parallel()
+
+			plan := tfsdk.Plan{
+				Schema: sr.Schema,
+				Raw:    tftypes.NewValue(sr.Schema.Type().TerraformType(ctx), nil),
+			}
+
+			if tt.namespace != "" {
+				diags := plan.SetAttribute(ctx, path.Root("metadata").AtListIndex(0).AtName("namespace"), tt.namespace)
+				require.False(t, diags.HasError(), "%v", diags)
+			}
+
+			diags := checkApplicationNamespace(ctx, si, tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw})
+
+			if !tt.wantError {
+				assert.False(t, diags.HasError(), "%v", diags)
+				return
+			}
+
+			require.Len(t, diags, 1)
+
+			d, ok := diags[0].(diag.DiagnosticWithPath)
+			require.True(t, ok)
+
+			assert.Equal(t, path.Root("metadata").AtListIndex(0).AtName("namespace"), d.Path())
+			assert.Equal(t, "ArgoCD server does not support applications in any namespace", d.Summary())
+			assert.Contains(t, d.Detail(), "`application.namespaces`")
+		})
+	}
+}
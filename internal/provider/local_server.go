@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/cmd/argocd/commands/headless"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// localServerProbeTimeout bounds the request made to check that the
+// Kubernetes API can be reached before starting the local server.
+const localServerProbeTimeout = 30 * time.Second
+
+var (
+	// localServerMu serializes the startup of local API servers, as it
+	// manipulates global state.
+	localServerMu sync.Mutex
+
+	fatalHook        = &fatalLogHook{}
+	installFatalHook sync.Once
+)
+
+// fatalLogHook turns fatal log entries into panics while active. The ArgoCD
+// server code exits the process using `log.Fatal` on (among others) errors
+// encountered at startup, which would otherwise kill the plugin without any
+// diagnostic. Exit handlers cannot be used to that end as the ones registered
+// by the ArgoCD code call `os.Exit` before ours would have a chance to run.
+//
+// As logrus hooks are global, and there is no way to tell which goroutine a
+// log entry originates from, this has two limitations:
+//   - only fatal entries logged on the goroutine starting the local server can
+//     be recovered (see recoverFatal). Those logged on the goroutines it spawns
+//     (e.g. while syncing caches) still terminate the plugin, only through an
+//     unrecovered panic rather than `os.Exit`;
+//   - fatal entries logged by unrelated code whilst the local server is being
+//     started are also turned into panics, which terminate the plugin all the
+//     same unless they happen to be logged on the starting goroutine, in which
+//     case they are reported as failing to start the local server.
+//
+// The hook is only active whilst the local server is being started (which is
+// serialized by localServerMu), which limits the window for the latter.
+type fatalLogHook struct {
+	active atomic.Bool
+}
+
+// localServerFatal is the value of the panics raised by fatalLogHook.
+type localServerFatal struct {
+	message string
+}
+
+func (h *fatalLogHook) Levels() []log.Level {
+	return []log.Level{log.FatalLevel}
+}
+
+func (h *fatalLogHook) Fire(e *log.Entry) error {
+	if h.active.Load() {
+		panic(localServerFatal{message: e.Message})
+	}
+
+	return nil
+}
+
+// startLocalServer starts the local ArgoCD API server used in core mode and
+// points `opts` to it. The server connects to the Kubernetes API using the
+// default kubeconfig (which can be overridden using the `KUBECONFIG`
+// environment variable), the in-cluster configuration if there is none, and
+// `opts.KubeOverrides`.
+func startLocalServer(ctx context.Context, opts *apiclient.ClientOptions) error {
+	localServerMu.Lock()
+	defer localServerMu.Unlock()
+
+	// HACK: `headless.StartLocalServer` manipulates this global variable
+	// when starting the local server without checking it's length/contents
+	// which leads to a panic if called multiple times. So, we need to
+	// ensure we "reset" it before calling the method.
+	if runtimeErrorHandlers == nil {
+		runtimeErrorHandlers = runtime.ErrorHandlers
+	} else {
+		runtime.ErrorHandlers = runtimeErrorHandlers
+	}
+
+	overrides := opts.KubeOverrides
+	if overrides == nil {
+		overrides = &clientcmd.ConfigOverrides{}
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), overrides)
+
+	// The local server waits indefinitely for its caches to sync, so make sure
+	// that the Kubernetes API can be reached beforehand
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load Kubernetes client configuration: %w", err)
+	}
+
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Timeout = localServerProbeTimeout
+
+	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes discovery client: %w", err)
+	}
+
+	if _, err = dc.ServerVersion(); err != nil {
+		return fmt.Errorf("failed to connect to the Kubernetes API: %w", err)
+	}
+
+	return recoverFatal(func() error {
+		return headless.MaybeStartLocalServer(ctx, opts, "", nil, nil, clientConfig)
+	})
+}
+
+// recoverFatal calls `f`, returning fatal log entries and panics as errors.
+// Only those raised on the calling goroutine can be recovered (see
+// fatalLogHook).
+func recoverFatal(f func() error) (err error) {
+	installFatalHook.Do(func() {
+		log.AddHook(fatalHook)
+	})
+
+	fatalHook.active.Store(true)
+	defer fatalHook.active.Store(false)
+
+	defer func() {
+		r := recover()
+
+		switch v := r.(type) {
+		case nil:
+		case localServerFatal:
+			err = errors.New(v.message)
+		default:
+			err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+
+	return f()
+}
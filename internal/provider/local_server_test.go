@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unreachableKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: unreachable
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: unreachable
+  context:
+    cluster: unreachable
+    user: unreachable
+    namespace: argocd
+current-context: unreachable
+users:
+- name: unreachable
+  user:
+    token: foo
+`
+
+func TestGetApiClientOptions_Core(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(unreachableKubeconfig), 0o600))
+
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	tests := []struct {
+		name          string
+		kubernetes    []Kubernetes
+		expectedError string
+	}{
+		{
+			name:          "KUBECONFIG",
+			expectedError: "127.0.0.1:1",
+		},
+		{
+			name: "kubernetes block",
+			kubernetes: []Kubernetes{
+				{
+					Host:     types.StringValue("https://127.0.0.1:2"),
+					Insecure: types.BoolValue(true),
+				},
+			},
+			expectedError: "127.0.0.1:2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			p := ArgoCDProviderConfig{
+				Core:       types.BoolValue(true),
+				Kubernetes: tt.kubernetes,
+			}
+
+			// Failures to start the local server are reported instead of
+			// exiting the process
+			_, diags := p.getApiClientOptions(context.Background())
+			require.True(t, diags.HasError())
+
+			assert.Equal(t, "failed to start local server", diags.Errors()[0].Summary())
+			assert.Contains(t, diags.Errors()[0].Detail(), tt.expectedError)
+		})
+	}
+}
+
+func TestRecoverFatal(t *testing.T) {
+	t.Parallel()
+
+	err := recoverFatal(func() error {
+		log.Fatal("timed out waiting for project cache to sync")
+		return nil
+	})
+	assert.EqualError(t, err, "timed out waiting for project cache to sync")
+
+	err = recoverFatal(func() error {
+		panic("boom")
+	})
+	assert.EqualError(t, err, "panic: boom")
+
+	err = recoverFatal(func() error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -1,15 +1,29 @@
 package provider
 
 import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/elliotchance/pie/v2"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	customtypes "github.com/oboukili/terraform-provider-argocd/internal/types"
 	"github.com/oboukili/terraform-provider-argocd/internal/utils"
 	"github.com/oboukili/terraform-provider-argocd/internal/validators"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 )
 
 type applicationModel struct {
@@ -19,6 +33,111 @@ type applicationModel struct {
 	Status   *applicationStatus `tfsdk:"status"`
 }
 
+type applicationResourceModel struct {
+	ID                     types.String           `tfsdk:"id"`
+	Metadata               []objectMeta           `tfsdk:"metadata"`
+	Spec                   []applicationSpecBlock `tfsdk:"spec"`
+	SpecOverrideJSON       customtypes.JSONString `tfsdk:"spec_override_json"`
+	Status                 types.List             `tfsdk:"status"`
+	Cascade                types.Bool             `tfsdk:"cascade"`
+	Timeouts               timeouts.Value         `tfsdk:"timeouts"`
+	Wait                   types.Bool             `tfsdk:"wait"`
+	WaitThroughSyncWindows types.Bool             `tfsdk:"wait_through_sync_windows"`
+}
+
+func (m applicationResourceModel) toApplication() (*v1alpha1.Application, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if len(m.Metadata) == 0 {
+		diags.AddAttributeError(path.Root("metadata"), "missing application metadata", "the metadata of the application must be set")
+	}
+
+	if len(m.Spec) == 0 {
+		diags.AddAttributeError(path.Root("spec"), "missing application spec", "the spec of the application must be set")
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &v1alpha1.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Application",
+			APIVersion: "argoproj.io/v1alpha1",
+		},
+		ObjectMeta: m.Metadata[0].toObjectMeta(),
+		Spec:       m.Spec[0].applicationSpec().toApplicationSpec(),
+	}, diags
+}
+
+// applicationJSON returns the JSON encoded application, with `spec_override_json`
+// merged into its spec (see mergeApplicationSpecOverride).
+func (m applicationResourceModel) applicationJSON(app *v1alpha1.Application) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	spec, err := mergeApplicationSpecOverride(app.Spec, m.SpecOverrideJSON.ValueJSONString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("spec_override_json"), "failed to merge spec_override_json into application spec", err.Error())
+		return nil, diags
+	}
+
+	// The spec of the application is shadowed by the merged one
+	b, err := json.Marshal(struct {
+		*v1alpha1.Application
+		Spec json.RawMessage `json:"spec"`
+	}{app, spec})
+	if err != nil {
+		diags.AddError("failed to marshal application", err.Error())
+		return nil, diags
+	}
+
+	return b, diags
+}
+
+// mergeApplicationSpecOverride strategically merges the JSON encoded override
+// into the application spec. The result is kept as JSON so that fields unknown
+// to the bundled ArgoCD API client (e.g. introduced by a newer version of
+// ArgoCD) are passed through to the server.
+func mergeApplicationSpecOverride(spec v1alpha1.ApplicationSpec, override string) (json.RawMessage, error) {
+	original, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal application spec: %w", err)
+	}
+
+	if override == "" {
+		return original, nil
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, []byte(override), v1alpha1.ApplicationSpec{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge spec_override_json into application spec: %w", err)
+	}
+
+	return patched, nil
+}
+
+// projectApplicationSpecOverride returns the JSON encoded application spec,
+// as returned by the REST API, limited to the keys that are set in the
+// override.
+func projectApplicationSpecOverride(spec json.RawMessage, override string) (string, error) {
+	var actual, shape interface{}
+
+	if err := json.Unmarshal(spec, &actual); err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal([]byte(override), &shape); err != nil {
+		return "", err
+	}
+
+	result, err := json.Marshal(utils.ProjectJSON(actual, shape))
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
 type applicationSpec struct {
 	Destination          applicationDestination                 `tfsdk:"destination"`
 	IgnoreDifferences    []applicationResourceIgnoreDifferences `tfsdk:"ignore_differences"`
@@ -30,27 +149,36 @@ type applicationSpec struct {
 }
 
 func applicationSpecSchemaAttribute(allOptional, computed bool) schema.Attribute {
+	attributes := applicationSpecSchemaAttributes(computed)
+	attributes["destination"] = applicationDestinationSchemaAttribute(computed)
+	attributes["ignore_differences"] = applicationResourceIgnoreDifferencesSchemaAttribute(computed)
+	attributes["infos"] = applicationInfoSchemaAttribute(computed)
+	attributes["sources"] = applicationSourcesSchemaAttribute(allOptional, computed)
+	attributes["sync_policy"] = applicationSyncPolicySchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "The application specification.",
 		Computed:            computed,
 		Required:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"destination":        applicationDestinationSchemaAttribute(computed),
-			"ignore_differences": applicationResourceIgnoreDifferencesSchemaAttribute(computed),
-			"infos":              applicationInfoSchemaAttribute(computed),
-			"project": schema.StringAttribute{
-				Computed:            computed,
-				Optional:            !computed,
-				MarkdownDescription: "The project the application belongs to. Defaults to `default`.",
-				Default:             stringdefault.StaticString("default"),
-			},
-			"revision_history_limit": schema.Int64Attribute{
-				MarkdownDescription: "Limits the number of items kept in the application's revision history, which is used for informational purposes as well as for rollbacks to previous versions. This should only be changed in exceptional circumstances. Setting to zero will store no history. This will reduce storage used. Increasing will increase the space used to store the history, so we do not recommend increasing it. Default is 10.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"sources":     applicationSourcesSchemaAttribute(allOptional, computed),
-			"sync_policy": applicationSyncPolicySchemaAttribute(computed),
+		Attributes:          attributes,
+	}
+}
+
+// applicationSpecSchemaAttributes returns the attributes of the application
+// spec, excluding those holding nested objects.
+func applicationSpecSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"project": schema.StringAttribute{
+			Computed:            true,
+			Optional:            !computed,
+			MarkdownDescription: "The project the application belongs to. Defaults to `default`.",
+			Default:             stringdefault.StaticString("default"),
+		},
+		"revision_history_limit": schema.Int64Attribute{
+			MarkdownDescription: "Limits the number of items kept in the application's revision history, which is used for informational purposes as well as for rollbacks to previous versions. This should only be changed in exceptional circumstances. Setting to zero will store no history. This will reduce storage used. Increasing will increase the space used to store the history, so we do not recommend increasing it. Default is 10.",
+			Computed:            true,
+			Optional:            !computed,
+			Default:             int64default.StaticInt64(10),
 		},
 	}
 }
@@ -76,6 +204,34 @@ func newApplicationSpec(as v1alpha1.ApplicationSpec) *applicationSpec {
 	return m
 }
 
+func (m applicationSpec) toApplicationSpec() v1alpha1.ApplicationSpec {
+	as := v1alpha1.ApplicationSpec{
+		Destination:          m.Destination.toApplicationDestination(),
+		IgnoreDifferences:    applicationResourceIgnoreDifferencesToArgoCD(m.IgnoreDifferences),
+		Info:                 applicationInfosToArgoCD(m.Infos),
+		Project:              m.Project.ValueString(),
+		RevisionHistoryLimit: m.RevisionHistoryLimit.ValueInt64Pointer(),
+		SyncPolicy:           m.SyncPolicy.toSyncPolicy(),
+	}
+
+	// A single source is sent to ArgoCD as `source` rather than `sources` in
+	// order to support ArgoCD versions prior to the introduction of multiple
+	// sources.
+	switch len(m.Sources) {
+	case 0:
+	case 1:
+		s := m.Sources[0].toApplicationSource()
+		as.Source = &s
+	default:
+		as.Sources = make(v1alpha1.ApplicationSources, len(m.Sources))
+		for i, v := range m.Sources {
+			as.Sources[i] = v.toApplicationSource()
+		}
+	}
+
+	return as
+}
+
 type applicationDestination struct {
 	Server    types.String `tfsdk:"server"`
 	Namespace types.String `tfsdk:"namespace"`
@@ -87,22 +243,26 @@ func applicationDestinationSchemaAttribute(computed bool) schema.Attribute {
 		MarkdownDescription: "Reference to the Kubernetes server and namespace in which the application will be deployed.",
 		Computed:            computed,
 		Required:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"server": schema.StringAttribute{
-				MarkdownDescription: "URL of the target cluster and must be set to the Kubernetes control plane API.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"namespace": schema.StringAttribute{
-				MarkdownDescription: "Target namespace for the application's resources. The namespace will only be set for namespace-scoped resources that have not set a value for .metadata.namespace.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the target cluster. Can be used instead of `server`.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
+		Attributes:          applicationDestinationSchemaAttributes(computed),
+	}
+}
+
+func applicationDestinationSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"server": schema.StringAttribute{
+			MarkdownDescription: "URL of the target cluster and must be set to the Kubernetes control plane API.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "Target namespace for the application's resources. The namespace will only be set for namespace-scoped resources that have not set a value for .metadata.namespace.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the target cluster. Can be used instead of `server`.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -115,6 +275,14 @@ func newApplicationDestination(ad v1alpha1.ApplicationDestination) applicationDe
 	}
 }
 
+func (m applicationDestination) toApplicationDestination() v1alpha1.ApplicationDestination {
+	return v1alpha1.ApplicationDestination{
+		Name:      m.Name.ValueString(),
+		Namespace: m.Namespace.ValueString(),
+		Server:    m.Server.ValueString(),
+	}
+}
+
 type applicationResourceIgnoreDifferences struct {
 	Group             types.String   `tfsdk:"group"`
 	Kind              types.String   `tfsdk:"kind"`
@@ -130,40 +298,44 @@ func applicationResourceIgnoreDifferencesSchemaAttribute(computed bool) schema.A
 		Computed:            computed,
 		Optional:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"group": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Group to match for.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"kind": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Kind to match for.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"name": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Name to match for.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"namespace": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Namespace to match for.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"json_pointers": schema.SetAttribute{
-					MarkdownDescription: "List of JSONPaths strings targeting the field(s) to ignore.",
-					Computed:            computed,
-					Optional:            !computed,
-					ElementType:         types.StringType,
-				},
-				"jq_path_expressions": schema.SetAttribute{
-					MarkdownDescription: "List of JQ path expression strings targeting the field(s) to ignore.",
-					Computed:            computed,
-					Optional:            !computed,
-					ElementType:         types.StringType,
-				},
-			},
+			Attributes: applicationResourceIgnoreDifferencesSchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationResourceIgnoreDifferencesSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"group": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Group to match for.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"kind": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Kind to match for.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Name to match for.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Namespace to match for.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"json_pointers": schema.SetAttribute{
+			MarkdownDescription: "List of JSONPaths strings targeting the field(s) to ignore.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
+		"jq_path_expressions": schema.SetAttribute{
+			MarkdownDescription: "List of JQ path expression strings targeting the field(s) to ignore.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
 		},
 	}
 }
@@ -188,6 +360,26 @@ func newApplicationResourceIgnoreDifferences(diffs []v1alpha1.ResourceIgnoreDiff
 	return ds
 }
 
+func applicationResourceIgnoreDifferencesToArgoCD(diffs []applicationResourceIgnoreDifferences) []v1alpha1.ResourceIgnoreDifferences {
+	if diffs == nil {
+		return nil
+	}
+
+	ds := make([]v1alpha1.ResourceIgnoreDifferences, len(diffs))
+	for i, v := range diffs {
+		ds[i] = v1alpha1.ResourceIgnoreDifferences{
+			Group:             v.Group.ValueString(),
+			Kind:              v.Kind.ValueString(),
+			Name:              v.Name.ValueString(),
+			Namespace:         v.Namespace.ValueString(),
+			JSONPointers:      pie.Map(v.JsonPointers, types.String.ValueString),
+			JQPathExpressions: pie.Map(v.JQPathExpressions, types.String.ValueString),
+		}
+	}
+
+	return ds
+}
+
 type applicationInfo struct {
 	Name  types.String `tfsdk:"name"`
 	Value types.String `tfsdk:"value"`
@@ -199,19 +391,26 @@ func applicationInfoSchemaAttribute(computed bool) schema.Attribute {
 		Computed:            computed,
 		Optional:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"name": schema.StringAttribute{
-					MarkdownDescription: "Name of the information.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"value": schema.StringAttribute{
-					MarkdownDescription: "Value of the information.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
+			Attributes: applicationInfoSchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationInfoSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the information.",
+			Computed:            computed,
+			Optional:            !computed,
+			Validators: []validator.String{
+				stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("value")),
 			},
 		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "Value of the information.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
 	}
 }
 
@@ -231,6 +430,22 @@ func newApplicationInfos(infos []v1alpha1.Info) []applicationInfo {
 	return is
 }
 
+func applicationInfosToArgoCD(infos []applicationInfo) []v1alpha1.Info {
+	if infos == nil {
+		return nil
+	}
+
+	is := make([]v1alpha1.Info, len(infos))
+	for i, v := range infos {
+		is[i] = v1alpha1.Info{
+			Name:  v.Name.ValueString(),
+			Value: v.Value.ValueString(),
+		}
+	}
+
+	return is
+}
+
 type applicationSource struct {
 	Chart          types.String                `tfsdk:"chart"`
 	Directory      *applicationSourceDirectory `tfsdk:"directory"`
@@ -244,44 +459,52 @@ type applicationSource struct {
 }
 
 func applicationSourcesSchemaAttribute(allOptional, computed bool) schema.Attribute {
+	attributes := applicationSourceSchemaAttributes(allOptional, computed)
+	attributes["directory"] = applicationSourceDirectorySchemaAttribute(computed)
+	attributes["helm"] = applicationSourceHelmSchemaAttribute(computed)
+	attributes["kustomize"] = applicationSourceKustomizeSchemaAttribute(computed)
+	attributes["plugin"] = applicationSourcePluginSchemaAttribute(computed)
+
 	return schema.ListNestedAttribute{
 		MarkdownDescription: "Location of the application's manifests or chart.",
 		Computed:            computed,
 		Required:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"chart": schema.StringAttribute{
-					MarkdownDescription: "Helm chart name. Must be specified for applications sourced from a Helm repo.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"directory": applicationSourceDirectorySchemaAttribute(computed),
-				"helm":      applicationSourceHelmSchemaAttribute(computed),
-				"kustomize": applicationSourceKustomizeSchemaAttribute(computed),
-				"path": schema.StringAttribute{
-					MarkdownDescription: "Directory path within the repository. Only valid for applications sourced from Git.",
-					Computed:            computed,
-					Optional:            !computed,
-					Default:             stringdefault.StaticString("."),
-				},
-				"plugin": applicationSourcePluginSchemaAttribute(computed),
-				"ref": schema.StringAttribute{
-					MarkdownDescription: "Reference to another `source` within defined sources. See associated documentation on [Helm value files from external Git repository](https://argo-cd.readthedocs.io/en/stable/user-guide/multiple_sources/#helm-value-files-from-external-git-repository) regarding combining `ref` with `path` and/or `chart`.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"repo_url": schema.StringAttribute{
-					MarkdownDescription: "URL to the repository (Git or Helm) that contains the application manifests.",
-					Optional:            allOptional && !computed,
-					Required:            !allOptional && !computed,
-					Computed:            computed,
-				},
-				"target_revision": schema.StringAttribute{
-					MarkdownDescription: "Revision of the source to sync the application to. In case of Git, this can be commit, tag, or branch. If omitted, will equal to HEAD. In case of Helm, this is a semver tag for the Chart's version.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-			},
+			Attributes: attributes,
+		},
+	}
+}
+
+// applicationSourceSchemaAttributes returns the attributes of an application
+// source, excluding those holding nested objects.
+func applicationSourceSchemaAttributes(allOptional, computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"chart": schema.StringAttribute{
+			MarkdownDescription: "Helm chart name. Must be specified for applications sourced from a Helm repo.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"path": schema.StringAttribute{
+			MarkdownDescription: "Directory path within the repository. Only valid for applications sourced from Git.",
+			Computed:            true,
+			Optional:            !computed,
+			Default:             stringdefault.StaticString("."),
+		},
+		"ref": schema.StringAttribute{
+			MarkdownDescription: "Reference to another `source` within defined sources. See associated documentation on [Helm value files from external Git repository](https://argo-cd.readthedocs.io/en/stable/user-guide/multiple_sources/#helm-value-files-from-external-git-repository) regarding combining `ref` with `path` and/or `chart`.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"repo_url": schema.StringAttribute{
+			MarkdownDescription: "URL to the repository (Git or Helm) that contains the application manifests.",
+			Optional:            allOptional && !computed,
+			Required:            !allOptional && !computed,
+			Computed:            computed,
+		},
+		"target_revision": schema.StringAttribute{
+			MarkdownDescription: "Revision of the source to sync the application to. In case of Git, this can be commit, tag, or branch. If omitted, will equal to HEAD. In case of Helm, this is a semver tag for the Chart's version.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -300,55 +523,58 @@ func newApplicationSource(as v1alpha1.ApplicationSource) applicationSource {
 	}
 }
 
+func (m applicationSource) toApplicationSource() v1alpha1.ApplicationSource {
+	return v1alpha1.ApplicationSource{
+		Chart:          m.Chart.ValueString(),
+		Directory:      m.Directory.toApplicationSourceDirectory(),
+		Helm:           m.Helm.toApplicationSourceHelm(),
+		Kustomize:      m.Kustomize.toApplicationSourceKustomize(),
+		Path:           m.Path.ValueString(),
+		Plugin:         m.Plugin.toApplicationSourcePlugin(),
+		Ref:            m.Ref.ValueString(),
+		RepoURL:        m.RepoURL.ValueString(),
+		TargetRevision: m.TargetRevision.ValueString(),
+	}
+}
+
 type applicationSourceDirectory struct {
-	Exclude types.String             `tfsdk:"exclude"`
-	Jsonnet applicationSourceJsonnet `tfsdk:"jsonnet"`
-	Include types.String             `tfsdk:"include"`
-	Recurse types.Bool               `tfsdk:"recurse"`
+	Exclude types.String              `tfsdk:"exclude"`
+	Jsonnet *applicationSourceJsonnet `tfsdk:"jsonnet"`
+	Include types.String              `tfsdk:"include"`
+	Recurse types.Bool                `tfsdk:"recurse"`
 }
 
 func applicationSourceDirectorySchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationSourceDirectorySchemaAttributes(computed)
+	attributes["jsonnet"] = applicationSourceJsonnetSchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Path/directory specific options.",
 		Computed:            computed,
 		Optional:            !computed,
-		// TODO: This isn't used at present but we will need to migrate it if we
-		// migrate the ArgoCD application resource.
-		//
-		// DiffSuppressFunc: func(k,
-		// oldValue, newValue string, d *schema.ResourceData) bool {
-		//  // Avoid drift when recurse is explicitly set to false
-		//  // Also ignore the directory node if both recurse & jsonnet are not set or ignored
-		//  if k == "spec.0.source.0.directory.0.recurse" && oldValue == "" && newValue == "false" {
-		//      return true
-		//  }
-		//  if k == "spec.0.source.0.directory.#" {
-		//      _, hasRecurse := d.GetOk("spec.0.source.0.directory.0.recurse")
-		//      _, hasJsonnet := d.GetOk("spec.0.source.0.directory.0.jsonnet")
-
-		// 		if !hasJsonnet && !hasRecurse {
-		// 			return true
-		// 		}
-		// 	}
-		// 	return false
-		// },
-		Attributes: map[string]schema.Attribute{
-			"exclude": schema.StringAttribute{
-				MarkdownDescription: "Glob pattern to match paths against that should be explicitly excluded from being used during manifest generation. This takes precedence over the `include` field. To match multiple patterns, wrap the patterns in {} and separate them with commas. For example: '{config.yaml,env-use2/*}'",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"include": schema.StringAttribute{
-				MarkdownDescription: "Glob pattern to match paths against that should be explicitly included during manifest generation. If this field is set, only matching manifests will be included. To match multiple patterns, wrap the patterns in {} and separate them with commas. For example: '{*.yml,*.yaml}'",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"jsonnet": applicationSourceJsonnetSchemaAttribute(computed),
-			"recurse": schema.BoolAttribute{
-				MarkdownDescription: "Whether to scan a directory recursively for manifests.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
+		Attributes:          attributes,
+	}
+}
+
+// applicationSourceDirectorySchemaAttributes returns the attributes of the
+// directory options of an application source, excluding those holding nested
+// objects.
+func applicationSourceDirectorySchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"exclude": schema.StringAttribute{
+			MarkdownDescription: "Glob pattern to match paths against that should be explicitly excluded from being used during manifest generation. This takes precedence over the `include` field. To match multiple patterns, wrap the patterns in {} and separate them with commas. For example: '{config.yaml,env-use2/*}'",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"include": schema.StringAttribute{
+			MarkdownDescription: "Glob pattern to match paths against that should be explicitly included during manifest generation. If this field is set, only matching manifests will be included. To match multiple patterns, wrap the patterns in {} and separate them with commas. For example: '{*.yml,*.yaml}'",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"recurse": schema.BoolAttribute{
+			MarkdownDescription: "Whether to scan a directory recursively for manifests.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -366,6 +592,19 @@ func newApplicationSourceDirectory(ad *v1alpha1.ApplicationSourceDirectory) *app
 	}
 }
 
+func (m *applicationSourceDirectory) toApplicationSourceDirectory() *v1alpha1.ApplicationSourceDirectory {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.ApplicationSourceDirectory{
+		Exclude: m.Exclude.ValueString(),
+		Jsonnet: m.Jsonnet.toApplicationSourceJsonnet(),
+		Include: m.Include.ValueString(),
+		Recurse: m.Recurse.ValueBool(),
+	}
+}
+
 type applicationSourceJsonnet struct {
 	ExtVars []applicationJsonnetVar `tfsdk:"ext_vars"`
 	Libs    []types.String          `tfsdk:"libs"`
@@ -373,41 +612,62 @@ type applicationSourceJsonnet struct {
 }
 
 func applicationSourceJsonnetSchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationSourceJsonnetSchemaAttributes(computed)
+	attributes["ext_vars"] = schema.ListNestedAttribute{
+		MarkdownDescription: "List of Jsonnet External Variables.",
+		Computed:            computed,
+		Optional:            !computed,
+		NestedObject:        applicationJsonnetVarSchemaNestedAttributeObject(computed),
+	}
+	attributes["tlas"] = schema.ListNestedAttribute{
+		MarkdownDescription: "List of Jsonnet Top-level Arguments",
+		Computed:            computed,
+		Optional:            !computed,
+		NestedObject:        applicationJsonnetVarSchemaNestedAttributeObject(computed),
+	}
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Jsonnet specific options.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"ext_vars": schema.ListNestedAttribute{
-				MarkdownDescription: "List of Jsonnet External Variables.",
-				Computed:            computed,
-				Optional:            !computed,
-				NestedObject:        applicationJsonnetVarSchemaNestedAttributeObject(computed),
-			},
-			"libs": schema.ListAttribute{
-				MarkdownDescription: "Additional library search dirs.",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-			},
-			"tlas": schema.ListNestedAttribute{
-				MarkdownDescription: "List of Jsonnet Top-level Arguments",
-				Computed:            computed,
-				Optional:            !computed,
-				NestedObject:        applicationJsonnetVarSchemaNestedAttributeObject(computed),
-			},
+		Attributes:          attributes,
+	}
+}
+
+// applicationSourceJsonnetSchemaAttributes returns the attributes of the
+// Jsonnet options of an application source, excluding those holding nested
+// objects.
+func applicationSourceJsonnetSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"libs": schema.ListAttribute{
+			MarkdownDescription: "Additional library search dirs.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
 		},
 	}
 }
 
-func newApplicationSourceJsonnet(asj v1alpha1.ApplicationSourceJsonnet) applicationSourceJsonnet {
-	return applicationSourceJsonnet{
+func newApplicationSourceJsonnet(asj v1alpha1.ApplicationSourceJsonnet) *applicationSourceJsonnet {
+	return &applicationSourceJsonnet{
 		ExtVars: newApplicationJsonnetVars(asj.ExtVars),
 		Libs:    pie.Map(asj.Libs, types.StringValue),
 		TLAs:    newApplicationJsonnetVars(asj.TLAs),
 	}
 }
 
+func (m *applicationSourceJsonnet) toApplicationSourceJsonnet() v1alpha1.ApplicationSourceJsonnet {
+	if m == nil {
+		return v1alpha1.ApplicationSourceJsonnet{}
+	}
+
+	return v1alpha1.ApplicationSourceJsonnet{
+		ExtVars: applicationJsonnetVarsToArgoCD(m.ExtVars),
+		Libs:    pie.Map(m.Libs, types.String.ValueString),
+		TLAs:    applicationJsonnetVarsToArgoCD(m.TLAs),
+	}
+}
+
 type applicationJsonnetVar struct {
 	Code  types.Bool   `tfsdk:"code"`
 	Name  types.String `tfsdk:"name"`
@@ -453,57 +713,121 @@ func newApplicationJsonnetVars(jvs []v1alpha1.JsonnetVar) []applicationJsonnetVa
 	return vs
 }
 
+func applicationJsonnetVarsToArgoCD(jvs []applicationJsonnetVar) []v1alpha1.JsonnetVar {
+	if jvs == nil {
+		return nil
+	}
+
+	vs := make([]v1alpha1.JsonnetVar, len(jvs))
+	for i, v := range jvs {
+		vs[i] = v1alpha1.JsonnetVar{
+			Code:  v.Code.ValueBool(),
+			Name:  v.Name.ValueString(),
+			Value: v.Value.ValueString(),
+		}
+	}
+
+	return vs
+}
+
 type applicationSourceHelm struct {
+	APIVersions             []types.String                 `tfsdk:"api_versions"`
 	FileParameters          []applicationHelmFileParameter `tfsdk:"file_parameters"`
 	IgnoreMissingValueFiles types.Bool                     `tfsdk:"ignore_missing_value_files"`
+	KubeVersion             types.String                   `tfsdk:"kube_version"`
+	Namespace               types.String                   `tfsdk:"namespace"`
 	Parameters              []applicationHelmParameter     `tfsdk:"parameters"`
 	PassCredentials         types.Bool                     `tfsdk:"pass_credentials"`
 	ReleaseName             types.String                   `tfsdk:"release_name"`
 	SkipCRDs                types.Bool                     `tfsdk:"skip_crds"`
+	SkipTests               types.Bool                     `tfsdk:"skip_tests"`
 	ValueFiles              []types.String                 `tfsdk:"value_files"`
-	Values                  types.String                   `tfsdk:"values"`
+	Values                  customtypes.YAMLString         `tfsdk:"values"`
+	ValuesObject            customtypes.YAMLString         `tfsdk:"values_object"`
 }
 
 func applicationSourceHelmSchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationSourceHelmSchemaAttributes(computed)
+	attributes["file_parameters"] = applicationHelmFileParameterSchemaAttribute(computed)
+	attributes["parameters"] = applicationHelmParameterSchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Helm specific options.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"file_parameters": applicationHelmFileParameterSchemaAttribute(computed),
-			"ignore_missing_value_files": schema.BoolAttribute{
-				MarkdownDescription: "Prevents 'helm template' from failing when `value_files` do not exist locally by not appending them to 'helm template --values'.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"parameters": applicationHelmParameterSchemaAttribute(computed),
-			"release_name": schema.StringAttribute{
-				MarkdownDescription: "Helm release name. If omitted it will use the application name.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"skip_crds": schema.BoolAttribute{
-				MarkdownDescription: "Whether to skip custom resource definition installation step (Helm's [--skip-crds](https://helm.sh/docs/chart_best_practices/custom_resource_definitions/)).",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"pass_credentials": schema.BoolAttribute{
-				MarkdownDescription: "If true then adds '--pass-credentials' to Helm commands to pass credentials to all domains.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"values": schema.StringAttribute{
-				MarkdownDescription: "Helm values to be passed to 'helm template', typically defined as a Attribute.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"value_files": schema.ListAttribute{
-				MarkdownDescription: "List of Helm value files to use when generating a template.",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
+		Attributes:          attributes,
+	}
+}
+
+// applicationSourceHelmSchemaAttributes returns the attributes of the Helm
+// options of an application source, excluding those holding nested objects.
+func applicationSourceHelmSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"ignore_missing_value_files": schema.BoolAttribute{
+			MarkdownDescription: "Prevents 'helm template' from failing when `value_files` do not exist locally by not appending them to 'helm template --values'.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"release_name": schema.StringAttribute{
+			MarkdownDescription: "Helm release name. If omitted it will use the application name.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"skip_crds": schema.BoolAttribute{
+			MarkdownDescription: "Whether to skip custom resource definition installation step (Helm's [--skip-crds](https://helm.sh/docs/chart_best_practices/custom_resource_definitions/)).",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"skip_tests": schema.BoolAttribute{
+			MarkdownDescription: "Whether to skip the rendering of the test manifests of the chart (Helm's `--skip-tests`).",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace to render the chart in, made available to templates as `.Release.Namespace`. Defaults to the namespace of the application destination.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"kube_version": schema.StringAttribute{
+			MarkdownDescription: "Kubernetes version used by Helm for `.Capabilities.KubeVersion`. Defaults to the version of the destination cluster.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"api_versions": schema.ListAttribute{
+			MarkdownDescription: "Kubernetes resource API versions used by Helm for `.Capabilities.APIVersions`, in the form `[group/]version/kind` (e.g. `monitoring.coreos.com/v1/ServiceMonitor`). Defaults to the API versions served by the destination cluster.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
+		"pass_credentials": schema.BoolAttribute{
+			MarkdownDescription: "If true then adds '--pass-credentials' to Helm commands to pass credentials to all domains.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"values": schema.StringAttribute{
+			MarkdownDescription: "Helm values to be passed to 'helm template', typically defined as a block. Conflicts with `values_object`.",
+			CustomType:          customtypes.YAMLStringType,
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"values_object": schema.StringAttribute{
+			MarkdownDescription: "JSON encoded Helm values to be passed to 'helm template', typically set using `jsonencode()`. Unlike `values`, differences in formatting or key ordering do not produce a diff. Conflicts with `values`.",
+			// JSON is a subset of YAML, so equivalent JSON documents are
+			// compared in the same way as YAML ones.
+			CustomType: customtypes.YAMLStringType,
+			Computed:   computed,
+			Optional:   !computed,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("values")),
+				validators.IsJSONObject(),
 			},
 		},
+		"value_files": schema.ListAttribute{
+			MarkdownDescription: "List of Helm value files to use when generating a template. Value files from another source can be referenced using `$<ref>/<path>`, where `<ref>` is the `ref` of that source.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
 	}
 }
 
@@ -513,15 +837,55 @@ func newApplicationSourceHelm(ash *v1alpha1.ApplicationSourceHelm) *applicationS
 	}
 
 	return &applicationSourceHelm{
+		APIVersions:             pie.Map(ash.APIVersions, types.StringValue),
 		FileParameters:          newApplicationSourceHelmFileParameters(ash.FileParameters),
 		IgnoreMissingValueFiles: types.BoolValue(ash.IgnoreMissingValueFiles),
+		KubeVersion:             types.StringValue(ash.KubeVersion),
+		Namespace:               types.StringValue(ash.Namespace),
 		Parameters:              newApplicationSourceHelmParameters(ash.Parameters),
 		PassCredentials:         types.BoolValue(ash.PassCredentials),
 		ReleaseName:             types.StringValue(ash.ReleaseName),
 		SkipCRDs:                types.BoolValue(ash.SkipCrds),
+		SkipTests:               types.BoolValue(ash.SkipTests),
 		ValueFiles:              pie.Map(ash.ValueFiles, types.StringValue),
-		Values:                  types.StringValue(ash.Values),
+		Values:                  customtypes.YAMLStringValue(ash.Values),
+		ValuesObject:            newApplicationSourceHelmValuesObject(ash.ValuesObject),
+	}
+}
+
+func newApplicationSourceHelmValuesObject(vo *runtime.RawExtension) customtypes.YAMLString {
+	if vo == nil {
+		return customtypes.YAMLStringNull()
 	}
+
+	return customtypes.YAMLStringValue(string(vo.Raw))
+}
+
+func (m *applicationSourceHelm) toApplicationSourceHelm() *v1alpha1.ApplicationSourceHelm {
+	if m == nil {
+		return nil
+	}
+
+	ash := &v1alpha1.ApplicationSourceHelm{
+		APIVersions:             pie.Map(m.APIVersions, types.String.ValueString),
+		FileParameters:          applicationHelmFileParametersToArgoCD(m.FileParameters),
+		IgnoreMissingValueFiles: m.IgnoreMissingValueFiles.ValueBool(),
+		KubeVersion:             m.KubeVersion.ValueString(),
+		Namespace:               m.Namespace.ValueString(),
+		Parameters:              applicationHelmParametersToArgoCD(m.Parameters),
+		PassCredentials:         m.PassCredentials.ValueBool(),
+		ReleaseName:             m.ReleaseName.ValueString(),
+		SkipCrds:                m.SkipCRDs.ValueBool(),
+		SkipTests:               m.SkipTests.ValueBool(),
+		ValueFiles:              pie.Map(m.ValueFiles, types.String.ValueString),
+		Values:                  m.Values.ValueYAMLString(),
+	}
+
+	if !m.ValuesObject.IsNull() {
+		ash.ValuesObject = &runtime.RawExtension{Raw: []byte(m.ValuesObject.ValueYAMLString())}
+	}
+
+	return ash
 }
 
 type applicationHelmFileParameter struct {
@@ -535,18 +899,22 @@ func applicationHelmFileParameterSchemaAttribute(computed bool) schema.Attribute
 		Computed:            computed,
 		Optional:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"name": schema.StringAttribute{
-					MarkdownDescription: "Name of the Helm parameters.",
-					Required:            !computed,
-					Computed:            computed,
-				},
-				"path": schema.StringAttribute{
-					MarkdownDescription: "Path to the file containing the values for the Helm parameters.",
-					Required:            !computed,
-					Computed:            computed,
-				},
-			},
+			Attributes: applicationHelmFileParameterSchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationHelmFileParameterSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the Helm parameter.",
+			Required:            !computed,
+			Computed:            computed,
+		},
+		"path": schema.StringAttribute{
+			MarkdownDescription: "Path to the file containing the values for the Helm parameter.",
+			Required:            !computed,
+			Computed:            computed,
 		},
 	}
 }
@@ -567,6 +935,22 @@ func newApplicationSourceHelmFileParameters(hfps []v1alpha1.HelmFileParameter) [
 	return fps
 }
 
+func applicationHelmFileParametersToArgoCD(fps []applicationHelmFileParameter) []v1alpha1.HelmFileParameter {
+	if fps == nil {
+		return nil
+	}
+
+	hfps := make([]v1alpha1.HelmFileParameter, len(fps))
+	for i, v := range fps {
+		hfps[i] = v1alpha1.HelmFileParameter{
+			Name: v.Name.ValueString(),
+			Path: v.Path.ValueString(),
+		}
+	}
+
+	return hfps
+}
+
 type applicationHelmParameter struct {
 	ForceString types.Bool   `tfsdk:"force_string"`
 	Name        types.String `tfsdk:"name"`
@@ -579,23 +963,27 @@ func applicationHelmParameterSchemaAttribute(computed bool) schema.Attribute {
 		Computed:            computed,
 		Optional:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"name": schema.StringAttribute{
-					MarkdownDescription: "Name of the Helm parameters.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"value": schema.StringAttribute{
-					MarkdownDescription: "Value of the Helm parameters.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"force_string": schema.BoolAttribute{
-					MarkdownDescription: "Determines whether to tell Helm to interpret booleans and numbers as strings.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-			},
+			Attributes: applicationHelmParameterSchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationHelmParameterSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the Helm parameter.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "Value of the Helm parameter.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"force_string": schema.BoolAttribute{
+			MarkdownDescription: "Determines whether to tell Helm to interpret booleans and numbers as strings.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -617,61 +1005,110 @@ func newApplicationSourceHelmParameters(hps []v1alpha1.HelmParameter) []applicat
 	return ps
 }
 
+func applicationHelmParametersToArgoCD(ps []applicationHelmParameter) []v1alpha1.HelmParameter {
+	if ps == nil {
+		return nil
+	}
+
+	hps := make([]v1alpha1.HelmParameter, len(ps))
+	for i, v := range ps {
+		hps[i] = v1alpha1.HelmParameter{
+			ForceString: v.ForceString.ValueBool(),
+			Name:        v.Name.ValueString(),
+			Value:       v.Value.ValueString(),
+		}
+	}
+
+	return hps
+}
+
 type applicationSourceKustomize struct {
-	CommonAnnotations map[string]types.String `tfsdk:"common_annotations"`
-	CommonLabels      map[string]types.String `tfsdk:"common_labels"`
-	Images            []types.String          `tfsdk:"images"`
-	NamePrefix        types.String            `tfsdk:"name_prefix"`
-	NameSuffix        types.String            `tfsdk:"name_suffix"`
-	Version           types.String            `tfsdk:"version"`
+	CommonAnnotations map[string]types.String       `tfsdk:"common_annotations"`
+	CommonLabels      map[string]types.String       `tfsdk:"common_labels"`
+	Components        []types.String                `tfsdk:"components"`
+	ForceCommonLabels types.Bool                    `tfsdk:"force_common_labels"`
+	Images            []types.String                `tfsdk:"images"`
+	NamePrefix        types.String                  `tfsdk:"name_prefix"`
+	NameSuffix        types.String                  `tfsdk:"name_suffix"`
+	Namespace         types.String                  `tfsdk:"namespace"`
+	Patches           []applicationKustomizePatch   `tfsdk:"patches"`
+	Replicas          []applicationKustomizeReplica `tfsdk:"replicas"`
+	Version           types.String                  `tfsdk:"version"`
 }
 
 func applicationSourceKustomizeSchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationSourceKustomizeSchemaAttributes(computed)
+	attributes["patches"] = applicationKustomizePatchesSchemaAttribute(computed)
+	attributes["replicas"] = applicationKustomizeReplicasSchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Kustomize specific options.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"name_prefix": schema.StringAttribute{
-				MarkdownDescription: "Prefix appended to resources for Kustomize apps.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"name_suffix": schema.StringAttribute{
-				MarkdownDescription: "Suffix appended to resources for Kustomize apps.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"version": schema.StringAttribute{
-				MarkdownDescription: "Version of Kustomize to use for rendering manifests.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"images": schema.SetAttribute{
-				MarkdownDescription: "List of Kustomize image override specifications.",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-			},
-			"common_labels": schema.MapAttribute{
-				MarkdownDescription: "List of additional labels to add to rendered manifests.",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-				Validators: []validator.Map{
-					validators.MetadataLabels(),
-				},
+		Attributes:          attributes,
+	}
+}
+
+// applicationSourceKustomizeSchemaAttributes returns the attributes of the
+// Kustomize options of an application source, excluding those holding nested
+// objects.
+func applicationSourceKustomizeSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name_prefix": schema.StringAttribute{
+			MarkdownDescription: "Prefix appended to resources for Kustomize apps.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"name_suffix": schema.StringAttribute{
+			MarkdownDescription: "Suffix appended to resources for Kustomize apps.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"version": schema.StringAttribute{
+			MarkdownDescription: "Version of Kustomize to use for rendering manifests.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"images": schema.SetAttribute{
+			MarkdownDescription: "List of Kustomize image override specifications.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
+		"common_labels": schema.MapAttribute{
+			MarkdownDescription: "List of additional labels to add to rendered manifests.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				validators.MetadataLabels(),
 			},
-			"common_annotations": schema.MapAttribute{
-				MarkdownDescription: "List of additional annotations to add to rendered manifests.",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-				Validators: []validator.Map{
-					validators.MetadataAnnotations(),
-				},
+		},
+		"common_annotations": schema.MapAttribute{
+			MarkdownDescription: "List of additional annotations to add to rendered manifests.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				validators.MetadataAnnotations(),
 			},
 		},
+		"force_common_labels": schema.BoolAttribute{
+			MarkdownDescription: "Whether to force applying common labels to resources, overriding existing labels with the same key.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace that Kustomize adds to all resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"components": schema.ListAttribute{
+			MarkdownDescription: "List of Kustomize components, relative to the application source path, to add to the kustomization before building.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
 	}
 }
 
@@ -683,8 +1120,13 @@ func newApplicationSourceKustomize(ask *v1alpha1.ApplicationSourceKustomize) *ap
 	k := &applicationSourceKustomize{
 		CommonAnnotations: utils.MapMap(ask.CommonAnnotations, types.StringValue),
 		CommonLabels:      utils.MapMap(ask.CommonLabels, types.StringValue),
+		Components:        pie.Map(ask.Components, types.StringValue),
+		ForceCommonLabels: types.BoolValue(ask.ForceCommonLabels),
 		NamePrefix:        types.StringValue(ask.NamePrefix),
 		NameSuffix:        types.StringValue(ask.NameSuffix),
+		Namespace:         types.StringValue(ask.Namespace),
+		Patches:           newApplicationKustomizePatches(ask.Patches),
+		Replicas:          newApplicationKustomizeReplicas(ask.Replicas),
 		Version:           types.StringValue(ask.Version),
 	}
 
@@ -698,6 +1140,268 @@ func newApplicationSourceKustomize(ask *v1alpha1.ApplicationSourceKustomize) *ap
 	return k
 }
 
+func (m *applicationSourceKustomize) toApplicationSourceKustomize() *v1alpha1.ApplicationSourceKustomize {
+	if m == nil {
+		return nil
+	}
+
+	ask := &v1alpha1.ApplicationSourceKustomize{
+		CommonAnnotations: utils.MapMap(m.CommonAnnotations, types.String.ValueString),
+		CommonLabels:      utils.MapMap(m.CommonLabels, types.String.ValueString),
+		Components:        pie.Map(m.Components, types.String.ValueString),
+		ForceCommonLabels: m.ForceCommonLabels.ValueBool(),
+		NamePrefix:        m.NamePrefix.ValueString(),
+		NameSuffix:        m.NameSuffix.ValueString(),
+		Namespace:         m.Namespace.ValueString(),
+		Patches:           applicationKustomizePatchesToArgoCD(m.Patches),
+		Replicas:          applicationKustomizeReplicasToArgoCD(m.Replicas),
+		Version:           m.Version.ValueString(),
+	}
+
+	for _, v := range m.Images {
+		ask.Images = append(ask.Images, v1alpha1.KustomizeImage(v.ValueString()))
+	}
+
+	return ask
+}
+
+type applicationKustomizePatch struct {
+	Options map[string]types.Bool       `tfsdk:"options"`
+	Patch   types.String                `tfsdk:"patch"`
+	Path    types.String                `tfsdk:"path"`
+	Target  *applicationKustomizeTarget `tfsdk:"target"`
+}
+
+func applicationKustomizePatchesSchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationKustomizePatchSchemaAttributes(computed)
+	attributes["target"] = schema.SingleNestedAttribute{
+		MarkdownDescription: "Selector for the resources to which the patch is applied.",
+		Computed:            computed,
+		Optional:            !computed,
+		Attributes:          applicationKustomizeTargetSchemaAttributes(computed),
+	}
+
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "List of Kustomize patches to apply to rendered manifests.",
+		Computed:            computed,
+		Optional:            !computed,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: attributes,
+		},
+	}
+}
+
+func applicationKustomizeTargetSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"group": schema.StringAttribute{
+			MarkdownDescription: "API group of the target resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"version": schema.StringAttribute{
+			MarkdownDescription: "API version of the target resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"kind": schema.StringAttribute{
+			MarkdownDescription: "Kind of the target resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the target resources. May be a regular expression.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace of the target resources. May be a regular expression.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"label_selector": schema.StringAttribute{
+			MarkdownDescription: "Label selector used to select the target resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"annotation_selector": schema.StringAttribute{
+			MarkdownDescription: "Annotation selector used to select the target resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+	}
+}
+
+// applicationKustomizePatchSchemaAttributes returns the attributes of a
+// Kustomize patch, excluding those holding nested objects.
+func applicationKustomizePatchSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"patch": schema.StringAttribute{
+			MarkdownDescription: "Inline patch, either a strategic merge patch or a JSON6902 patch.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"path": schema.StringAttribute{
+			MarkdownDescription: "Path to a file, relative to the application source path, containing the patch.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"options": schema.MapAttribute{
+			MarkdownDescription: "Additional options for the patch (e.g. `allowNameChange` and `allowKindChange`).",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.BoolType,
+		},
+	}
+}
+
+func newApplicationKustomizePatches(kps v1alpha1.KustomizePatches) []applicationKustomizePatch {
+	if kps == nil {
+		return nil
+	}
+
+	ps := make([]applicationKustomizePatch, len(kps))
+	for i, v := range kps {
+		ps[i] = applicationKustomizePatch{
+			Options: utils.MapMap(v.Options, types.BoolValue),
+			Patch:   types.StringValue(v.Patch),
+			Path:    types.StringValue(v.Path),
+			Target:  newApplicationKustomizeTarget(v.Target),
+		}
+	}
+
+	return ps
+}
+
+func applicationKustomizePatchesToArgoCD(ps []applicationKustomizePatch) v1alpha1.KustomizePatches {
+	if ps == nil {
+		return nil
+	}
+
+	kps := make(v1alpha1.KustomizePatches, len(ps))
+	for i, v := range ps {
+		kps[i] = v1alpha1.KustomizePatch{
+			Options: utils.MapMap(v.Options, types.Bool.ValueBool),
+			Patch:   v.Patch.ValueString(),
+			Path:    v.Path.ValueString(),
+			Target:  v.Target.toKustomizeSelector(),
+		}
+	}
+
+	return kps
+}
+
+type applicationKustomizeTarget struct {
+	AnnotationSelector types.String `tfsdk:"annotation_selector"`
+	Group              types.String `tfsdk:"group"`
+	Kind               types.String `tfsdk:"kind"`
+	LabelSelector      types.String `tfsdk:"label_selector"`
+	Name               types.String `tfsdk:"name"`
+	Namespace          types.String `tfsdk:"namespace"`
+	Version            types.String `tfsdk:"version"`
+}
+
+func newApplicationKustomizeTarget(ks *v1alpha1.KustomizeSelector) *applicationKustomizeTarget {
+	if ks == nil {
+		return nil
+	}
+
+	return &applicationKustomizeTarget{
+		AnnotationSelector: types.StringValue(ks.AnnotationSelector),
+		Group:              types.StringValue(ks.Group),
+		Kind:               types.StringValue(ks.Kind),
+		LabelSelector:      types.StringValue(ks.LabelSelector),
+		Name:               types.StringValue(ks.Name),
+		Namespace:          types.StringValue(ks.Namespace),
+		Version:            types.StringValue(ks.Version),
+	}
+}
+
+func (m *applicationKustomizeTarget) toKustomizeSelector() *v1alpha1.KustomizeSelector {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.KustomizeSelector{
+		KustomizeResId: v1alpha1.KustomizeResId{
+			KustomizeGvk: v1alpha1.KustomizeGvk{
+				Group:   m.Group.ValueString(),
+				Kind:    m.Kind.ValueString(),
+				Version: m.Version.ValueString(),
+			},
+			Name:      m.Name.ValueString(),
+			Namespace: m.Namespace.ValueString(),
+		},
+		AnnotationSelector: m.AnnotationSelector.ValueString(),
+		LabelSelector:      m.LabelSelector.ValueString(),
+	}
+}
+
+type applicationKustomizeReplica struct {
+	Count types.String `tfsdk:"count"`
+	Name  types.String `tfsdk:"name"`
+}
+
+func applicationKustomizeReplicasSchemaAttribute(computed bool) schema.Attribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "List of Kustomize replica count overrides.",
+		Computed:            computed,
+		Optional:            !computed,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: applicationKustomizeReplicaSchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationKustomizeReplicaSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the Deployment or StatefulSet.",
+			Required:            !computed,
+			Computed:            computed,
+		},
+		"count": schema.StringAttribute{
+			MarkdownDescription: "Number of replicas.",
+			Required:            !computed,
+			Computed:            computed,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\d+$`), "must be a non-negative integer"),
+			},
+		},
+	}
+}
+
+func newApplicationKustomizeReplicas(krs v1alpha1.KustomizeReplicas) []applicationKustomizeReplica {
+	if krs == nil {
+		return nil
+	}
+
+	rs := make([]applicationKustomizeReplica, len(krs))
+	for i, v := range krs {
+		rs[i] = applicationKustomizeReplica{
+			Count: types.StringValue(v.Count.String()),
+			Name:  types.StringValue(v.Name),
+		}
+	}
+
+	return rs
+}
+
+func applicationKustomizeReplicasToArgoCD(rs []applicationKustomizeReplica) v1alpha1.KustomizeReplicas {
+	if rs == nil {
+		return nil
+	}
+
+	krs := make(v1alpha1.KustomizeReplicas, len(rs))
+	for i, v := range rs {
+		krs[i] = v1alpha1.KustomizeReplica{
+			Count: intstr.Parse(v.Count.ValueString()),
+			Name:  v.Name.ValueString(),
+		}
+	}
+
+	return krs
+}
+
 type applicationSourcePlugin struct {
 	Env        []applicationEnvEntry              `tfsdk:"env"`
 	Name       types.String                       `tfsdk:"name"`
@@ -705,18 +1409,26 @@ type applicationSourcePlugin struct {
 }
 
 func applicationSourcePluginSchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationSourcePluginSchemaAttributes(computed)
+	attributes["env"] = applicationEnvEntriesSchemaAttribute(computed)
+	attributes["parameters"] = applicationSourcePluginParametersSchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Config management plugin specific options.",
 		Computed:            computed,
-		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"env": applicationEnvEntriesSchemaAttribute(computed),
-			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the plugin. Only set the plugin name if the plugin is defined in `argocd-cm`. If the plugin is defined as a sidecar, omit the name. The plugin will be automatically matched with the Application according to the plugin's discovery rules.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"parameters": applicationSourcePluginParametersSchemaAttribute(computed),
+		Optional:            !computed,
+		Attributes:          attributes,
+	}
+}
+
+// applicationSourcePluginSchemaAttributes returns the attributes of the plugin
+// options of an application source, excluding those holding nested objects.
+func applicationSourcePluginSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the plugin. Only set the plugin name if the plugin is defined in `argocd-cm`. If the plugin is defined as a sidecar, omit the name. The plugin will be automatically matched with the Application according to the plugin's discovery rules.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -733,6 +1445,26 @@ func newApplicationSourcePlugin(asp *v1alpha1.ApplicationSourcePlugin) *applicat
 	}
 }
 
+func (m *applicationSourcePlugin) toApplicationSourcePlugin() *v1alpha1.ApplicationSourcePlugin {
+	if m == nil {
+		return nil
+	}
+
+	asp := &v1alpha1.ApplicationSourcePlugin{
+		Name:       m.Name.ValueString(),
+		Parameters: applicationSourcePluginParametersToArgoCD(m.Parameters),
+	}
+
+	for _, v := range m.Env {
+		asp.Env = append(asp.Env, &v1alpha1.EnvEntry{
+			Name:  v.Name.ValueString(),
+			Value: v.Value.ValueString(),
+		})
+	}
+
+	return asp
+}
+
 type applicationEnvEntry struct {
 	Name  types.String `tfsdk:"name"`
 	Value types.String `tfsdk:"value"`
@@ -744,18 +1476,22 @@ func applicationEnvEntriesSchemaAttribute(computed bool) schema.Attribute {
 		Computed:            computed,
 		Optional:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"name": schema.StringAttribute{
-					MarkdownDescription: "Name of the environment variable.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"value": schema.StringAttribute{
-					MarkdownDescription: "Value of the environment variable.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-			},
+			Attributes: applicationEnvEntrySchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationEnvEntrySchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the environment variable.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "Value of the environment variable.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -794,30 +1530,34 @@ func applicationSourcePluginParametersSchemaAttribute(computed bool) schema.Attr
 		Computed:            computed,
 		Optional:            !computed,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"array": schema.ListAttribute{
-					MarkdownDescription: "Value of an array type parameters.",
-					Computed:            computed,
-					Optional:            !computed,
-					ElementType:         types.StringType,
-				},
-				"name": schema.StringAttribute{
-					MarkdownDescription: "Name identifying a parameters.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-				"map": schema.MapAttribute{
-					MarkdownDescription: "Value of a map type parameters.",
-					Computed:            computed,
-					Optional:            !computed,
-					ElementType:         types.StringType,
-				},
-				"string": schema.StringAttribute{
-					MarkdownDescription: "Value of a string type parameters.",
-					Computed:            computed,
-					Optional:            !computed,
-				},
-			},
+			Attributes: applicationSourcePluginParameterSchemaAttributes(computed),
+		},
+	}
+}
+
+func applicationSourcePluginParameterSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"array": schema.ListAttribute{
+			MarkdownDescription: "Value of an array type parameter.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name identifying a parameter.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"map": schema.MapAttribute{
+			MarkdownDescription: "Value of a map type parameter.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+		},
+		"string": schema.StringAttribute{
+			MarkdownDescription: "Value of a string type parameter.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -831,36 +1571,80 @@ func newApplicationSourcePluginParameters(aspps v1alpha1.ApplicationSourcePlugin
 
 	for i, v := range aspps {
 		pps[i] = applicationSourcePluginParameter{
-			Array:  pie.Map(v.Array, types.StringValue),
-			Map:    utils.MapMap(v.Map, types.StringValue),
 			Name:   types.StringValue(v.Name),
 			String: utils.OptionalString(v.String_),
 		}
+
+		if v.OptionalArray != nil {
+			pps[i].Array = pie.Map(v.Array, types.StringValue)
+		}
+
+		if v.OptionalMap != nil {
+			pps[i].Map = utils.MapMap(v.Map, types.StringValue)
+		}
 	}
 
 	return pps
 }
 
+func applicationSourcePluginParametersToArgoCD(pps []applicationSourcePluginParameter) v1alpha1.ApplicationSourcePluginParameters {
+	if pps == nil {
+		return nil
+	}
+
+	aspps := make(v1alpha1.ApplicationSourcePluginParameters, len(pps))
+	for i, v := range pps {
+		aspps[i] = v1alpha1.ApplicationSourcePluginParameter{
+			Name:    v.Name.ValueString(),
+			String_: v.String.ValueStringPointer(),
+		}
+
+		if v.Array != nil {
+			aspps[i].OptionalArray = &v1alpha1.OptionalArray{
+				Array: pie.Map(v.Array, types.String.ValueString),
+			}
+		}
+
+		if v.Map != nil {
+			aspps[i].OptionalMap = &v1alpha1.OptionalMap{
+				Map: utils.MapMap(v.Map, types.String.ValueString),
+			}
+		}
+	}
+
+	return aspps
+}
+
 type applicationSyncPolicy struct {
-	Automated   *applicationSyncPolicyAutomated `tfsdk:"automated"`
-	Retry       *applicationRetryStrategy       `tfsdk:"retry"`
-	SyncOptions []types.String                  `tfsdk:"sync_options"`
+	Automated                *applicationSyncPolicyAutomated      `tfsdk:"automated"`
+	ManagedNamespaceMetadata *applicationManagedNamespaceMetadata `tfsdk:"managed_namespace_metadata"`
+	Retry                    *applicationRetryStrategy            `tfsdk:"retry"`
+	SyncOptions              []types.String                       `tfsdk:"sync_options"`
 }
 
 func applicationSyncPolicySchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationSyncPolicySchemaAttributes(computed)
+	attributes["automated"] = applicationSyncPolicyAutomatedSchemaAttribute(computed)
+	attributes["managed_namespace_metadata"] = applicationManagedNamespaceMetadataSchemaAttribute(computed)
+	attributes["retry"] = applicationRetryStrategySchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Controls when and how a sync will be performed.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"automated": applicationSyncPolicyAutomatedSchemaAttribute(computed),
-			"retry":     applicationRetryStrategySchemaAttribute(computed),
-			"sync_options": schema.SetAttribute{
-				MarkdownDescription: "List of sync options. More info: https://argo-cd.readthedocs.io/en/stable/user-guide/sync-options/.",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-			},
+		Attributes:          attributes,
+	}
+}
+
+// applicationSyncPolicySchemaAttributes returns the attributes of the sync
+// policy of an application, excluding those holding nested objects.
+func applicationSyncPolicySchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"sync_options": schema.SetAttribute{
+			MarkdownDescription: "List of sync options. More info: https://argo-cd.readthedocs.io/en/stable/user-guide/sync-options/.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
 		},
 	}
 }
@@ -871,9 +1655,82 @@ func newApplicationSyncPolicy(sp *v1alpha1.SyncPolicy) *applicationSyncPolicy {
 	}
 
 	return &applicationSyncPolicy{
-		Automated:   newApplicationSyncPolicyAutomated(sp.Automated),
-		Retry:       newApplicationRetryStrategy(sp.Retry),
-		SyncOptions: pie.Map(sp.SyncOptions, types.StringValue),
+		Automated:                newApplicationSyncPolicyAutomated(sp.Automated),
+		ManagedNamespaceMetadata: newApplicationManagedNamespaceMetadata(sp.ManagedNamespaceMetadata),
+		Retry:                    newApplicationRetryStrategy(sp.Retry),
+		SyncOptions:              pie.Map(sp.SyncOptions, types.StringValue),
+	}
+}
+
+func (m *applicationSyncPolicy) toSyncPolicy() *v1alpha1.SyncPolicy {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.SyncPolicy{
+		Automated:                m.Automated.toSyncPolicyAutomated(),
+		ManagedNamespaceMetadata: m.ManagedNamespaceMetadata.toManagedNamespaceMetadata(),
+		Retry:                    m.Retry.toRetryStrategy(),
+		SyncOptions:              pie.Map(m.SyncOptions, types.String.ValueString),
+	}
+}
+
+type applicationManagedNamespaceMetadata struct {
+	Annotations map[string]types.String `tfsdk:"annotations"`
+	Labels      map[string]types.String `tfsdk:"labels"`
+}
+
+func applicationManagedNamespaceMetadataSchemaAttribute(computed bool) schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Controls metadata in the given namespace (if `CreateNamespace=true`).",
+		Computed:            computed,
+		Optional:            !computed,
+		Attributes:          applicationManagedNamespaceMetadataSchemaAttributes(computed),
+	}
+}
+
+func applicationManagedNamespaceMetadataSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"annotations": schema.MapAttribute{
+			MarkdownDescription: "Annotations to apply to the namespace.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				validators.MetadataAnnotations(),
+			},
+		},
+		"labels": schema.MapAttribute{
+			MarkdownDescription: "Labels to apply to the namespace.",
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				validators.MetadataLabels(),
+			},
+		},
+	}
+}
+
+func newApplicationManagedNamespaceMetadata(mnm *v1alpha1.ManagedNamespaceMetadata) *applicationManagedNamespaceMetadata {
+	if mnm == nil {
+		return nil
+	}
+
+	return &applicationManagedNamespaceMetadata{
+		Annotations: utils.MapMap(mnm.Annotations, types.StringValue),
+		Labels:      utils.MapMap(mnm.Labels, types.StringValue),
+	}
+}
+
+func (m *applicationManagedNamespaceMetadata) toManagedNamespaceMetadata() *v1alpha1.ManagedNamespaceMetadata {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.ManagedNamespaceMetadata{
+		Annotations: utils.MapMap(m.Annotations, types.String.ValueString),
+		Labels:      utils.MapMap(m.Labels, types.String.ValueString),
 	}
 }
 
@@ -888,22 +1745,26 @@ func applicationSyncPolicyAutomatedSchemaAttribute(computed bool) schema.Attribu
 		MarkdownDescription: "Whether to automatically keep an application synced to the target revision.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"allow_empty": schema.BoolAttribute{
-				MarkdownDescription: "Allows apps have zero live resources.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"prune": schema.BoolAttribute{
-				MarkdownDescription: "Whether to delete resources from the cluster that are not found in the sources anymore as part of automated sync.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"self_heal": schema.BoolAttribute{
-				MarkdownDescription: "Whether to revert resources back to their desired state upon modification in the cluster.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
+		Attributes:          applicationSyncPolicyAutomatedSchemaAttributes(computed),
+	}
+}
+
+func applicationSyncPolicyAutomatedSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"allow_empty": schema.BoolAttribute{
+			MarkdownDescription: "Allows apps have zero live resources.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"prune": schema.BoolAttribute{
+			MarkdownDescription: "Whether to delete resources from the cluster that are not found in the sources anymore as part of automated sync.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"self_heal": schema.BoolAttribute{
+			MarkdownDescription: "Whether to revert resources back to their desired state upon modification in the cluster.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -920,23 +1781,43 @@ func newApplicationSyncPolicyAutomated(spa *v1alpha1.SyncPolicyAutomated) *appli
 	}
 }
 
+func (m *applicationSyncPolicyAutomated) toSyncPolicyAutomated() *v1alpha1.SyncPolicyAutomated {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.SyncPolicyAutomated{
+		AllowEmpty: m.AllowEmpty.ValueBool(),
+		Prune:      m.Prune.ValueBool(),
+		SelfHeal:   m.SelfHeal.ValueBool(),
+	}
+}
+
 type applicationRetryStrategy struct {
 	Limit   types.Int64         `tfsdk:"limit"`
 	Backoff *applicationBackoff `tfsdk:"backoff"`
 }
 
 func applicationRetryStrategySchemaAttribute(computed bool) schema.Attribute {
+	attributes := applicationRetryStrategySchemaAttributes(computed)
+	attributes["backoff"] = applicationBackoffSchemaAttribute(computed)
+
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Controls failed sync retry behavior.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"backoff": applicationBackoffSchemaAttribute(computed),
-			"limit": schema.Int64Attribute{
-				MarkdownDescription: "Maximum number of attempts for retrying a failed sync. If set to 0, no retries will be performed.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
+		Attributes:          attributes,
+	}
+}
+
+// applicationRetryStrategySchemaAttributes returns the attributes of the retry
+// strategy of an application, excluding those holding nested objects.
+func applicationRetryStrategySchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"limit": schema.Int64Attribute{
+			MarkdownDescription: "Maximum number of attempts for retrying a failed sync. If set to 0, no retries will be performed.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -952,6 +1833,17 @@ func newApplicationRetryStrategy(rs *v1alpha1.RetryStrategy) *applicationRetrySt
 	}
 }
 
+func (m *applicationRetryStrategy) toRetryStrategy() *v1alpha1.RetryStrategy {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.RetryStrategy{
+		Backoff: m.Backoff.toBackoff(),
+		Limit:   m.Limit.ValueInt64(),
+	}
+}
+
 type applicationBackoff struct {
 	Duration    types.String `tfsdk:"duration"`
 	Factor      types.Int64  `tfsdk:"factor"`
@@ -963,22 +1855,26 @@ func applicationBackoffSchemaAttribute(computed bool) schema.Attribute {
 		MarkdownDescription: "Controls how to backoff on subsequent retries of failed syncs.",
 		Computed:            computed,
 		Optional:            !computed,
-		Attributes: map[string]schema.Attribute{
-			"duration": schema.StringAttribute{
-				MarkdownDescription: "Duration is the amount to back off. Default unit is seconds, but could also be a duration (e.g. `2m`, `1h`), as a string.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"factor": schema.Int64Attribute{
-				MarkdownDescription: "Factor to multiply the base duration after each failed retry.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
-			"max_duration": schema.StringAttribute{
-				MarkdownDescription: "Maximum amount of time allowed for the backoff strategy. Default unit is seconds, but could also be a duration (e.g. `2m`, `1h`), as a string.",
-				Computed:            computed,
-				Optional:            !computed,
-			},
+		Attributes:          applicationBackoffSchemaAttributes(computed),
+	}
+}
+
+func applicationBackoffSchemaAttributes(computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"duration": schema.StringAttribute{
+			MarkdownDescription: "Duration is the amount to back off. Default unit is seconds, but could also be a duration (e.g. `2m`, `1h`), as a string.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"factor": schema.Int64Attribute{
+			MarkdownDescription: "Factor to multiply the base duration after each failed retry.",
+			Computed:            computed,
+			Optional:            !computed,
+		},
+		"max_duration": schema.StringAttribute{
+			MarkdownDescription: "Maximum amount of time allowed for the backoff strategy. Default unit is seconds, but could also be a duration (e.g. `2m`, `1h`), as a string.",
+			Computed:            computed,
+			Optional:            !computed,
 		},
 	}
 }
@@ -995,6 +1891,18 @@ func newApplicationBackoff(b *v1alpha1.Backoff) *applicationBackoff {
 	}
 }
 
+func (m *applicationBackoff) toBackoff() *v1alpha1.Backoff {
+	if m == nil {
+		return nil
+	}
+
+	return &v1alpha1.Backoff{
+		Duration:    m.Duration.ValueString(),
+		Factor:      m.Factor.ValueInt64Pointer(),
+		MaxDuration: m.MaxDuration.ValueString(),
+	}
+}
+
 type applicationStatus struct {
 	Conditions     []applicationCondition      `tfsdk:"conditions"`
 	Health         applicationHealthStatus     `tfsdk:"health"`
@@ -1132,27 +2040,31 @@ func applicationOperationStateSchemaAttribute() schema.Attribute {
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Information about any ongoing operations, such as a sync.",
 		Computed:            true,
-		Attributes: map[string]schema.Attribute{
-			"finished_at": schema.StringAttribute{
-				MarkdownDescription: "Time of operation completion.",
-				Computed:            true,
-			},
-			"message": schema.StringAttribute{
-				MarkdownDescription: "Any pertinent messages when attempting to perform operation (typically errors).",
-				Computed:            true,
-			},
-			"phase": schema.StringAttribute{
-				MarkdownDescription: "The current phase of the operation.",
-				Computed:            true,
-			},
-			"retry_count": schema.Int64Attribute{
-				MarkdownDescription: "Count of operation retries.",
-				Computed:            true,
-			},
-			"started_at": schema.StringAttribute{
-				MarkdownDescription: "Time of operation start.",
-				Computed:            true,
-			},
+		Attributes:          applicationOperationStateSchemaAttributes(),
+	}
+}
+
+func applicationOperationStateSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"finished_at": schema.StringAttribute{
+			MarkdownDescription: "Time of operation completion.",
+			Computed:            true,
+		},
+		"message": schema.StringAttribute{
+			MarkdownDescription: "Any pertinent messages when attempting to perform operation (typically errors).",
+			Computed:            true,
+		},
+		"phase": schema.StringAttribute{
+			MarkdownDescription: "The current phase of the operation.",
+			Computed:            true,
+		},
+		"retry_count": schema.Int64Attribute{
+			MarkdownDescription: "Count of operation retries.",
+			Computed:            true,
+		},
+		"started_at": schema.StringAttribute{
+			MarkdownDescription: "Time of operation start.",
+			Computed:            true,
 		},
 	}
 }
@@ -1185,53 +2097,62 @@ type applicationResourceStatus struct {
 }
 
 func applicationResourceStatusSchemaAttribute() schema.Attribute {
+	attributes := applicationResourceStatusSchemaAttributes()
+	attributes["health"] = schema.SingleNestedAttribute{
+		MarkdownDescription: "Resource health status.",
+		Computed:            true,
+		Attributes:          applicationHealthStatusSchemaAttributes(),
+	}
+
 	return schema.ListNestedAttribute{
 		MarkdownDescription: "List of Kubernetes resources managed by this application.",
 		Computed:            true,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"group": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Group.",
-					Computed:            true,
-				},
-				"health": schema.SingleNestedAttribute{
-					MarkdownDescription: "Resource health status.",
-					Computed:            true,
-					Attributes:          applicationHealthStatusSchemaAttributes(),
-				},
-				"kind": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Kind.",
-					Computed:            true,
-				},
-				"hook": schema.BoolAttribute{
-					MarkdownDescription: "Indicates whether or not this resource has a hook annotation.",
-					Computed:            true,
-				},
-				"name": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Name.",
-					Computed:            true,
-				},
-				"namespace": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Namespace.",
-					Computed:            true,
-				},
-				"requires_pruning": schema.BoolAttribute{
-					MarkdownDescription: "Indicates if the resources requires pruning or not.",
-					Computed:            true,
-				},
-				"status": schema.StringAttribute{
-					MarkdownDescription: "Resource sync status.",
-					Computed:            true,
-				},
-				"sync_wave": schema.Int64Attribute{
-					MarkdownDescription: "Sync wave.",
-					Computed:            true,
-				},
-				"version": schema.StringAttribute{
-					MarkdownDescription: "The Kubernetes resource Version.",
-					Computed:            true,
-				},
-			},
+			Attributes: attributes,
+		},
+	}
+}
+
+// applicationResourceStatusSchemaAttributes returns the attributes of the
+// status of a resource managed by an application, excluding those holding
+// nested objects.
+func applicationResourceStatusSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"group": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Group.",
+			Computed:            true,
+		},
+		"kind": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Kind.",
+			Computed:            true,
+		},
+		"hook": schema.BoolAttribute{
+			MarkdownDescription: "Indicates whether or not this resource has a hook annotation.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Name.",
+			Computed:            true,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Namespace.",
+			Computed:            true,
+		},
+		"requires_pruning": schema.BoolAttribute{
+			MarkdownDescription: "Indicates if the resources requires pruning or not.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "Resource sync status.",
+			Computed:            true,
+		},
+		"sync_wave": schema.Int64Attribute{
+			MarkdownDescription: "Sync wave.",
+			Computed:            true,
+		},
+		"version": schema.StringAttribute{
+			MarkdownDescription: "The Kubernetes resource Version.",
+			Computed:            true,
 		},
 	}
 }
@@ -1270,17 +2191,21 @@ func applicationSummarySchemaAttribute() schema.Attribute {
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "List of URLs and container images used by this application.",
 		Computed:            true,
-		Attributes: map[string]schema.Attribute{
-			"external_urls": schema.ListAttribute{
-				MarkdownDescription: "All external URLs of application child resources.",
-				Computed:            true,
-				ElementType:         types.StringType,
-			},
-			"images": schema.ListAttribute{
-				MarkdownDescription: "All images of application child resources.",
-				Computed:            true,
-				ElementType:         types.StringType,
-			},
+		Attributes:          applicationSummarySchemaAttributes(),
+	}
+}
+
+func applicationSummarySchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"external_urls": schema.ListAttribute{
+			MarkdownDescription: "All external URLs of application child resources.",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"images": schema.ListAttribute{
+			MarkdownDescription: "All images of application child resources.",
+			Computed:            true,
+			ElementType:         types.StringType,
 		},
 	}
 }
@@ -1301,16 +2226,20 @@ func applicationSyncStatusSchemaAttribute() schema.Attribute {
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "Application's current sync status",
 		Computed:            true,
-		Attributes: map[string]schema.Attribute{
-			"revisions": schema.ListAttribute{
-				MarkdownDescription: "Information about the revision(s) the comparison has been performed to.",
-				Computed:            true,
-				ElementType:         types.StringType,
-			},
-			"status": schema.StringAttribute{
-				MarkdownDescription: "Sync state of the comparison.",
-				Computed:            true,
-			},
+		Attributes:          applicationSyncStatusSchemaAttributes(),
+	}
+}
+
+func applicationSyncStatusSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"revisions": schema.ListAttribute{
+			MarkdownDescription: "Information about the revision(s) the comparison has been performed to.",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "Sync state of the comparison.",
+			Computed:            true,
 		},
 	}
 }
@@ -0,0 +1,798 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/elliotchance/pie/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	customtypes "github.com/oboukili/terraform-provider-argocd/internal/types"
+	"github.com/oboukili/terraform-provider-argocd/internal/utils"
+)
+
+// The `argocd_application` resource retains the shape of its SDK
+// implementation, in which the spec and the objects nested within it are
+// configured as (list or set) blocks. The types below hold these blocks and
+// are converted to and from the nested models shared with the data source so
+// that the conversion to and from the ArgoCD API types is only implemented
+// once.
+
+// blockList returns the list of blocks holding the object pointed to by `v`,
+// or nil if it is not set.
+func blockList[T any](v *T) []T {
+	if v == nil {
+		return nil
+	}
+
+	return []T{*v}
+}
+
+// blockValue returns a pointer to the object held by the first of the blocks,
+// or nil if there are none.
+func blockValue[T any](bs []T) *T {
+	if len(bs) == 0 {
+		return nil
+	}
+
+	return &bs[0]
+}
+
+type applicationSpecBlock struct {
+	Destination          []applicationDestination               `tfsdk:"destination"`
+	IgnoreDifference     []applicationResourceIgnoreDifferences `tfsdk:"ignore_difference"`
+	Info                 []applicationInfo                      `tfsdk:"info"`
+	Project              types.String                           `tfsdk:"project"`
+	RevisionHistoryLimit types.Int64                            `tfsdk:"revision_history_limit"`
+	Source               []applicationSourceBlock               `tfsdk:"source"`
+	SyncPolicy           []applicationSyncPolicyBlock           `tfsdk:"sync_policy"`
+}
+
+func applicationSpecSchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "The application specification.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSpecSchemaAttributes(false),
+			Blocks: map[string]schema.Block{
+				"destination": schema.SetNestedBlock{
+					MarkdownDescription: "Reference to the Kubernetes server and namespace in which the application will be deployed.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationDestinationSchemaAttributes(false),
+					},
+					Validators: []validator.Set{
+						setvalidator.IsRequired(),
+						setvalidator.SizeAtMost(1),
+					},
+				},
+				"ignore_difference": schema.ListNestedBlock{
+					MarkdownDescription: "Resources and their fields which should be ignored during comparison. More info: https://argo-cd.readthedocs.io/en/stable/user-guide/diffing/#application-level-configuration.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationResourceIgnoreDifferencesSchemaAttributes(false),
+					},
+				},
+				"info": schema.SetNestedBlock{
+					MarkdownDescription: "List of information (URLs, email addresses, and plain text) that relates to the application.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationInfoSchemaAttributes(false),
+					},
+				},
+				"source":      applicationSourceSchemaBlock(),
+				"sync_policy": applicationSyncPolicySchemaBlock(),
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.IsRequired(),
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func newApplicationSpecBlock(s *applicationSpec) *applicationSpecBlock {
+	if s == nil {
+		return nil
+	}
+
+	return &applicationSpecBlock{
+		Destination:          []applicationDestination{s.Destination},
+		IgnoreDifference:     s.IgnoreDifferences,
+		Info:                 s.Infos,
+		Project:              s.Project,
+		RevisionHistoryLimit: s.RevisionHistoryLimit,
+		Source:               pie.Map(s.Sources, newApplicationSourceBlock),
+		SyncPolicy:           blockList(newApplicationSyncPolicyBlock(s.SyncPolicy)),
+	}
+}
+
+func (b *applicationSpecBlock) applicationSpec() *applicationSpec {
+	if b == nil {
+		return nil
+	}
+
+	s := &applicationSpec{
+		IgnoreDifferences:    b.IgnoreDifference,
+		Infos:                b.Info,
+		Project:              b.Project,
+		RevisionHistoryLimit: b.RevisionHistoryLimit,
+		Sources:              pie.Map(b.Source, applicationSourceBlock.applicationSource),
+		SyncPolicy:           blockValue(b.SyncPolicy).applicationSyncPolicy(),
+	}
+
+	if d := blockValue(b.Destination); d != nil {
+		s.Destination = *d
+	}
+
+	return s
+}
+
+type applicationSourceBlock struct {
+	Chart          types.String                      `tfsdk:"chart"`
+	Directory      []applicationSourceDirectoryBlock `tfsdk:"directory"`
+	Helm           []applicationSourceHelmBlock      `tfsdk:"helm"`
+	Kustomize      []applicationSourceKustomizeBlock `tfsdk:"kustomize"`
+	Path           types.String                      `tfsdk:"path"`
+	Plugin         []applicationSourcePluginBlock    `tfsdk:"plugin"`
+	Ref            types.String                      `tfsdk:"ref"`
+	RepoURL        types.String                      `tfsdk:"repo_url"`
+	TargetRevision types.String                      `tfsdk:"target_revision"`
+}
+
+func applicationSourceSchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Location of the application's manifests or chart.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSourceSchemaAttributes(false, false),
+			Blocks: map[string]schema.Block{
+				"directory": applicationSourceDirectorySchemaBlock(),
+				"helm":      applicationSourceHelmSchemaBlock(),
+				"kustomize": applicationSourceKustomizeSchemaBlock(),
+				"plugin":    applicationSourcePluginSchemaBlock(),
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.IsRequired(),
+		},
+	}
+}
+
+func newApplicationSourceBlock(s applicationSource) applicationSourceBlock {
+	return applicationSourceBlock{
+		Chart:          s.Chart,
+		Directory:      blockList(newApplicationSourceDirectoryBlock(s.Directory)),
+		Helm:           blockList(newApplicationSourceHelmBlock(s.Helm)),
+		Kustomize:      blockList(newApplicationSourceKustomizeBlock(s.Kustomize)),
+		Path:           s.Path,
+		Plugin:         blockList(newApplicationSourcePluginBlock(s.Plugin)),
+		Ref:            s.Ref,
+		RepoURL:        s.RepoURL,
+		TargetRevision: s.TargetRevision,
+	}
+}
+
+func (b applicationSourceBlock) applicationSource() applicationSource {
+	return applicationSource{
+		Chart:          b.Chart,
+		Directory:      blockValue(b.Directory).applicationSourceDirectory(),
+		Helm:           blockValue(b.Helm).applicationSourceHelm(),
+		Kustomize:      blockValue(b.Kustomize).applicationSourceKustomize(),
+		Path:           b.Path,
+		Plugin:         blockValue(b.Plugin).applicationSourcePlugin(),
+		Ref:            b.Ref,
+		RepoURL:        b.RepoURL,
+		TargetRevision: b.TargetRevision,
+	}
+}
+
+type applicationSourceDirectoryBlock struct {
+	Exclude types.String                    `tfsdk:"exclude"`
+	Include types.String                    `tfsdk:"include"`
+	Jsonnet []applicationSourceJsonnetBlock `tfsdk:"jsonnet"`
+	Recurse types.Bool                      `tfsdk:"recurse"`
+}
+
+func applicationSourceDirectorySchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Path/directory specific options.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSourceDirectorySchemaAttributes(false),
+			Blocks: map[string]schema.Block{
+				"jsonnet": schema.ListNestedBlock{
+					MarkdownDescription: "Jsonnet specific options.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationSourceJsonnetSchemaAttributes(false),
+						Blocks: map[string]schema.Block{
+							"ext_var": schema.ListNestedBlock{
+								MarkdownDescription: "List of Jsonnet External Variables.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: applicationJsonnetVarSchemaNestedAttributeObject(false).Attributes,
+								},
+							},
+							"tla": schema.SetNestedBlock{
+								MarkdownDescription: "List of Jsonnet Top-level Arguments",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: applicationJsonnetVarSchemaNestedAttributeObject(false).Attributes,
+								},
+							},
+						},
+					},
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func newApplicationSourceDirectoryBlock(d *applicationSourceDirectory) *applicationSourceDirectoryBlock {
+	if d == nil {
+		return nil
+	}
+
+	return &applicationSourceDirectoryBlock{
+		Exclude: d.Exclude,
+		Include: d.Include,
+		Jsonnet: blockList(newApplicationSourceJsonnetBlock(d.Jsonnet)),
+		Recurse: d.Recurse,
+	}
+}
+
+func (b *applicationSourceDirectoryBlock) applicationSourceDirectory() *applicationSourceDirectory {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationSourceDirectory{
+		Exclude: b.Exclude,
+		Include: b.Include,
+		Jsonnet: blockValue(b.Jsonnet).applicationSourceJsonnet(),
+		Recurse: b.Recurse,
+	}
+}
+
+type applicationSourceJsonnetBlock struct {
+	ExtVar []applicationJsonnetVar `tfsdk:"ext_var"`
+	Libs   []types.String          `tfsdk:"libs"`
+	TLA    []applicationJsonnetVar `tfsdk:"tla"`
+}
+
+func newApplicationSourceJsonnetBlock(j *applicationSourceJsonnet) *applicationSourceJsonnetBlock {
+	if j == nil {
+		return nil
+	}
+
+	return &applicationSourceJsonnetBlock{
+		ExtVar: j.ExtVars,
+		Libs:   j.Libs,
+		TLA:    j.TLAs,
+	}
+}
+
+func (b *applicationSourceJsonnetBlock) applicationSourceJsonnet() *applicationSourceJsonnet {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationSourceJsonnet{
+		ExtVars: b.ExtVar,
+		Libs:    b.Libs,
+		TLAs:    b.TLA,
+	}
+}
+
+type applicationSourceHelmBlock struct {
+	APIVersions             []types.String                 `tfsdk:"api_versions"`
+	FileParameter           []applicationHelmFileParameter `tfsdk:"file_parameter"`
+	IgnoreMissingValueFiles types.Bool                     `tfsdk:"ignore_missing_value_files"`
+	KubeVersion             types.String                   `tfsdk:"kube_version"`
+	Namespace               types.String                   `tfsdk:"namespace"`
+	Parameter               []applicationHelmParameter     `tfsdk:"parameter"`
+	PassCredentials         types.Bool                     `tfsdk:"pass_credentials"`
+	ReleaseName             types.String                   `tfsdk:"release_name"`
+	SkipCRDs                types.Bool                     `tfsdk:"skip_crds"`
+	SkipTests               types.Bool                     `tfsdk:"skip_tests"`
+	ValueFiles              []types.String                 `tfsdk:"value_files"`
+	Values                  customtypes.YAMLString         `tfsdk:"values"`
+	ValuesObject            customtypes.YAMLString         `tfsdk:"values_object"`
+}
+
+func applicationSourceHelmSchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Helm specific options.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSourceHelmSchemaAttributes(false),
+			Blocks: map[string]schema.Block{
+				"file_parameter": schema.SetNestedBlock{
+					MarkdownDescription: "File parameters for the helm template.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationHelmFileParameterSchemaAttributes(false),
+					},
+				},
+				"parameter": schema.SetNestedBlock{
+					MarkdownDescription: "Helm parameters which are passed to the helm template command upon manifest generation.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationHelmParameterSchemaAttributes(false),
+					},
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func newApplicationSourceHelmBlock(h *applicationSourceHelm) *applicationSourceHelmBlock {
+	if h == nil {
+		return nil
+	}
+
+	return &applicationSourceHelmBlock{
+		APIVersions:             h.APIVersions,
+		FileParameter:           h.FileParameters,
+		IgnoreMissingValueFiles: h.IgnoreMissingValueFiles,
+		KubeVersion:             h.KubeVersion,
+		Namespace:               h.Namespace,
+		Parameter:               h.Parameters,
+		PassCredentials:         h.PassCredentials,
+		ReleaseName:             h.ReleaseName,
+		SkipCRDs:                h.SkipCRDs,
+		SkipTests:               h.SkipTests,
+		ValueFiles:              h.ValueFiles,
+		Values:                  h.Values,
+		ValuesObject:            h.ValuesObject,
+	}
+}
+
+func (b *applicationSourceHelmBlock) applicationSourceHelm() *applicationSourceHelm {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationSourceHelm{
+		APIVersions:             b.APIVersions,
+		FileParameters:          b.FileParameter,
+		IgnoreMissingValueFiles: b.IgnoreMissingValueFiles,
+		KubeVersion:             b.KubeVersion,
+		Namespace:               b.Namespace,
+		Parameters:              b.Parameter,
+		PassCredentials:         b.PassCredentials,
+		ReleaseName:             b.ReleaseName,
+		SkipCRDs:                b.SkipCRDs,
+		SkipTests:               b.SkipTests,
+		ValueFiles:              b.ValueFiles,
+		Values:                  b.Values,
+		ValuesObject:            b.ValuesObject,
+	}
+}
+
+type applicationSourceKustomizeBlock struct {
+	CommonAnnotations map[string]types.String          `tfsdk:"common_annotations"`
+	CommonLabels      map[string]types.String          `tfsdk:"common_labels"`
+	Components        []types.String                   `tfsdk:"components"`
+	ForceCommonLabels types.Bool                       `tfsdk:"force_common_labels"`
+	Images            []types.String                   `tfsdk:"images"`
+	NamePrefix        types.String                     `tfsdk:"name_prefix"`
+	NameSuffix        types.String                     `tfsdk:"name_suffix"`
+	Namespace         types.String                     `tfsdk:"namespace"`
+	Patches           []applicationKustomizePatchBlock `tfsdk:"patches"`
+	Replicas          []applicationKustomizeReplica    `tfsdk:"replicas"`
+	Version           types.String                     `tfsdk:"version"`
+}
+
+func applicationSourceKustomizeSchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Kustomize specific options.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSourceKustomizeSchemaAttributes(false),
+			Blocks: map[string]schema.Block{
+				"patches": schema.ListNestedBlock{
+					MarkdownDescription: "List of Kustomize patches to apply to rendered manifests.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationKustomizePatchSchemaAttributes(false),
+						Blocks: map[string]schema.Block{
+							"target": schema.ListNestedBlock{
+								MarkdownDescription: "Selector for the resources to which the patch is applied.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: applicationKustomizeTargetSchemaAttributes(false),
+								},
+								Validators: []validator.List{
+									listvalidator.SizeAtMost(1),
+								},
+							},
+						},
+					},
+				},
+				"replicas": schema.ListNestedBlock{
+					MarkdownDescription: "List of Kustomize replica count overrides.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationKustomizeReplicaSchemaAttributes(false),
+					},
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func newApplicationSourceKustomizeBlock(k *applicationSourceKustomize) *applicationSourceKustomizeBlock {
+	if k == nil {
+		return nil
+	}
+
+	return &applicationSourceKustomizeBlock{
+		CommonAnnotations: k.CommonAnnotations,
+		CommonLabels:      k.CommonLabels,
+		Components:        k.Components,
+		ForceCommonLabels: k.ForceCommonLabels,
+		Images:            k.Images,
+		NamePrefix:        k.NamePrefix,
+		NameSuffix:        k.NameSuffix,
+		Namespace:         k.Namespace,
+		Patches:           pie.Map(k.Patches, newApplicationKustomizePatchBlock),
+		Replicas:          k.Replicas,
+		Version:           k.Version,
+	}
+}
+
+func (b *applicationSourceKustomizeBlock) applicationSourceKustomize() *applicationSourceKustomize {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationSourceKustomize{
+		CommonAnnotations: b.CommonAnnotations,
+		CommonLabels:      b.CommonLabels,
+		Components:        b.Components,
+		ForceCommonLabels: b.ForceCommonLabels,
+		Images:            b.Images,
+		NamePrefix:        b.NamePrefix,
+		NameSuffix:        b.NameSuffix,
+		Namespace:         b.Namespace,
+		Patches:           pie.Map(b.Patches, applicationKustomizePatchBlock.applicationKustomizePatch),
+		Replicas:          b.Replicas,
+		Version:           b.Version,
+	}
+}
+
+type applicationKustomizePatchBlock struct {
+	Options map[string]types.Bool        `tfsdk:"options"`
+	Patch   types.String                 `tfsdk:"patch"`
+	Path    types.String                 `tfsdk:"path"`
+	Target  []applicationKustomizeTarget `tfsdk:"target"`
+}
+
+func newApplicationKustomizePatchBlock(p applicationKustomizePatch) applicationKustomizePatchBlock {
+	return applicationKustomizePatchBlock{
+		Options: p.Options,
+		Patch:   p.Patch,
+		Path:    p.Path,
+		Target:  blockList(p.Target),
+	}
+}
+
+func (b applicationKustomizePatchBlock) applicationKustomizePatch() applicationKustomizePatch {
+	return applicationKustomizePatch{
+		Options: b.Options,
+		Patch:   b.Patch,
+		Path:    b.Path,
+		Target:  blockValue(b.Target),
+	}
+}
+
+type applicationSourcePluginBlock struct {
+	Env       []applicationEnvEntry              `tfsdk:"env"`
+	Name      types.String                       `tfsdk:"name"`
+	Parameter []applicationSourcePluginParameter `tfsdk:"parameter"`
+}
+
+func applicationSourcePluginSchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Config management plugin specific options.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSourcePluginSchemaAttributes(false),
+			Blocks: map[string]schema.Block{
+				"env": schema.SetNestedBlock{
+					MarkdownDescription: "Environment variables passed to the plugin.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationEnvEntrySchemaAttributes(false),
+					},
+				},
+				"parameter": schema.ListNestedBlock{
+					MarkdownDescription: "Parameters to supply to config management plugin.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationSourcePluginParameterSchemaAttributes(false),
+					},
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func newApplicationSourcePluginBlock(p *applicationSourcePlugin) *applicationSourcePluginBlock {
+	if p == nil {
+		return nil
+	}
+
+	return &applicationSourcePluginBlock{
+		Env:       p.Env,
+		Name:      p.Name,
+		Parameter: p.Parameters,
+	}
+}
+
+func (b *applicationSourcePluginBlock) applicationSourcePlugin() *applicationSourcePlugin {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationSourcePlugin{
+		Env:        b.Env,
+		Name:       b.Name,
+		Parameters: b.Parameter,
+	}
+}
+
+type applicationSyncPolicyBlock struct {
+	Automated                []applicationSyncPolicyAutomated      `tfsdk:"automated"`
+	ManagedNamespaceMetadata []applicationManagedNamespaceMetadata `tfsdk:"managed_namespace_metadata"`
+	Retry                    []applicationRetryStrategyBlock       `tfsdk:"retry"`
+	SyncOptions              []types.String                        `tfsdk:"sync_options"`
+}
+
+func applicationSyncPolicySchemaBlock() schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: "Controls when and how a sync will be performed.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: applicationSyncPolicySchemaAttributes(false),
+			Blocks: map[string]schema.Block{
+				"automated": schema.SetNestedBlock{
+					MarkdownDescription: "Whether to automatically keep an application synced to the target revision.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationSyncPolicyAutomatedSchemaAttributes(false),
+					},
+					Validators: []validator.Set{
+						setvalidator.SizeAtMost(1),
+					},
+				},
+				"managed_namespace_metadata": schema.ListNestedBlock{
+					MarkdownDescription: "Controls metadata in the given namespace (if `CreateNamespace=true`).",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationManagedNamespaceMetadataSchemaAttributes(false),
+					},
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+				},
+				"retry": schema.ListNestedBlock{
+					MarkdownDescription: "Controls failed sync retry behavior.",
+					NestedObject: schema.NestedBlockObject{
+						Attributes: applicationRetryStrategySchemaAttributes(false),
+						Blocks: map[string]schema.Block{
+							"backoff": schema.SetNestedBlock{
+								MarkdownDescription: "Controls how to backoff on subsequent retries of failed syncs.",
+								NestedObject: schema.NestedBlockObject{
+									Attributes: applicationBackoffSchemaAttributes(false),
+								},
+								Validators: []validator.Set{
+									setvalidator.SizeAtMost(1),
+								},
+							},
+						},
+					},
+					Validators: []validator.List{
+						listvalidator.SizeAtMost(1),
+					},
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func newApplicationSyncPolicyBlock(sp *applicationSyncPolicy) *applicationSyncPolicyBlock {
+	if sp == nil {
+		return nil
+	}
+
+	return &applicationSyncPolicyBlock{
+		Automated:                blockList(sp.Automated),
+		ManagedNamespaceMetadata: blockList(sp.ManagedNamespaceMetadata),
+		Retry:                    blockList(newApplicationRetryStrategyBlock(sp.Retry)),
+		SyncOptions:              sp.SyncOptions,
+	}
+}
+
+func (b *applicationSyncPolicyBlock) applicationSyncPolicy() *applicationSyncPolicy {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationSyncPolicy{
+		Automated:                blockValue(b.Automated),
+		ManagedNamespaceMetadata: blockValue(b.ManagedNamespaceMetadata),
+		Retry:                    blockValue(b.Retry).applicationRetryStrategy(),
+		SyncOptions:              b.SyncOptions,
+	}
+}
+
+type applicationRetryStrategyBlock struct {
+	Backoff []applicationBackoff `tfsdk:"backoff"`
+	Limit   types.Int64          `tfsdk:"limit"`
+}
+
+func newApplicationRetryStrategyBlock(rs *applicationRetryStrategy) *applicationRetryStrategyBlock {
+	if rs == nil {
+		return nil
+	}
+
+	return &applicationRetryStrategyBlock{
+		Backoff: blockList(rs.Backoff),
+		Limit:   rs.Limit,
+	}
+}
+
+func (b *applicationRetryStrategyBlock) applicationRetryStrategy() *applicationRetryStrategy {
+	if b == nil {
+		return nil
+	}
+
+	return &applicationRetryStrategy{
+		Backoff: blockValue(b.Backoff),
+		Limit:   b.Limit,
+	}
+}
+
+// applicationStatusBlock holds the status of the application in the shape of
+// the SDK implementation of the resource, i.e. as a list of a single object
+// in which nested objects are lists of a single object as well.
+type applicationStatusBlock struct {
+	Conditions     []applicationCondition           `tfsdk:"conditions"`
+	Health         []applicationHealthStatus        `tfsdk:"health"`
+	OperationState []applicationOperationState      `tfsdk:"operation_state"`
+	ReconciledAt   types.String                     `tfsdk:"reconciled_at"`
+	Resources      []applicationResourceStatusBlock `tfsdk:"resources"`
+	Summary        []applicationSummary             `tfsdk:"summary"`
+	Sync           []applicationSyncStatusBlock     `tfsdk:"sync"`
+}
+
+func applicationStatusBlockSchemaAttribute() schema.Attribute {
+	resources := applicationResourceStatusSchemaAttributes()
+	resources["health"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Resource health status.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: applicationHealthStatusSchemaAttributes(),
+		},
+	}
+
+	sync := applicationSyncStatusSchemaAttributes()
+	sync["revision"] = schema.StringAttribute{
+		MarkdownDescription: "Information about the revision the comparison has been performed to.",
+		Computed:            true,
+	}
+
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Status information for the application. **Note**: this is not guaranteed to be up to date immediately after creating/updating an application unless `wait=true`.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"conditions": applicationConditionSchemaAttribute(),
+				"health": schema.ListNestedAttribute{
+					MarkdownDescription: "Application's current health status.",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: applicationHealthStatusSchemaAttributes(),
+					},
+				},
+				"operation_state": schema.ListNestedAttribute{
+					MarkdownDescription: "Information about any ongoing operations, such as a sync.",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: applicationOperationStateSchemaAttributes(),
+					},
+				},
+				"reconciled_at": schema.StringAttribute{
+					MarkdownDescription: "When the application state was reconciled using the latest git version.",
+					Computed:            true,
+				},
+				"resources": schema.ListNestedAttribute{
+					MarkdownDescription: "List of Kubernetes resources managed by this application.",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: resources,
+					},
+				},
+				"summary": schema.ListNestedAttribute{
+					MarkdownDescription: "List of URLs and container images used by this application.",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: applicationSummarySchemaAttributes(),
+					},
+				},
+				"sync": schema.ListNestedAttribute{
+					MarkdownDescription: "Application's current sync status",
+					Computed:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: sync,
+					},
+				},
+			},
+		},
+	}
+}
+
+func applicationStatusBlockType() types.ObjectType {
+	return applicationStatusBlockSchemaAttribute().GetType().(types.ListType).ElemType.(types.ObjectType)
+}
+
+func newApplicationStatusList(ctx context.Context, as v1alpha1.ApplicationStatus) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, applicationStatusBlockType(), []applicationStatusBlock{newApplicationStatusBlock(as)})
+}
+
+func newApplicationStatusBlock(as v1alpha1.ApplicationStatus) applicationStatusBlock {
+	return applicationStatusBlock{
+		Conditions:     newApplicationConditions(as.Conditions),
+		Health:         blockList(newApplicationHealthStatus(&as.Health)),
+		OperationState: blockList(newApplicationOperationState(as.OperationState)),
+		ReconciledAt:   utils.OptionalTimeString(as.ReconciledAt),
+		Resources:      pie.Map(as.Resources, newApplicationResourceStatusBlock),
+		Summary:        []applicationSummary{newApplicationSummary(as.Summary)},
+		Sync:           []applicationSyncStatusBlock{newApplicationSyncStatusBlock(as.Sync)},
+	}
+}
+
+type applicationResourceStatusBlock struct {
+	Group           types.String              `tfsdk:"group"`
+	Health          []applicationHealthStatus `tfsdk:"health"`
+	Hook            types.Bool                `tfsdk:"hook"`
+	Kind            types.String              `tfsdk:"kind"`
+	Name            types.String              `tfsdk:"name"`
+	Namespace       types.String              `tfsdk:"namespace"`
+	RequiresPruning types.Bool                `tfsdk:"requires_pruning"`
+	Status          types.String              `tfsdk:"status"`
+	SyncWave        types.Int64               `tfsdk:"sync_wave"`
+	Version         types.String              `tfsdk:"version"`
+}
+
+func newApplicationResourceStatusBlock(rs v1alpha1.ResourceStatus) applicationResourceStatusBlock {
+	return applicationResourceStatusBlock{
+		Group:           types.StringValue(rs.Group),
+		Health:          blockList(newApplicationHealthStatus(rs.Health)),
+		Hook:            types.BoolValue(rs.Hook),
+		Kind:            types.StringValue(rs.Kind),
+		Name:            types.StringValue(rs.Name),
+		Namespace:       types.StringValue(rs.Namespace),
+		RequiresPruning: types.BoolValue(rs.RequiresPruning),
+		Status:          types.StringValue(string(rs.Status)),
+		SyncWave:        types.Int64Value(rs.SyncWave),
+		Version:         types.StringValue(rs.Version),
+	}
+}
+
+type applicationSyncStatusBlock struct {
+	Revision  types.String   `tfsdk:"revision"`
+	Revisions []types.String `tfsdk:"revisions"`
+	Status    types.String   `tfsdk:"status"`
+}
+
+func newApplicationSyncStatusBlock(ss v1alpha1.SyncStatus) applicationSyncStatusBlock {
+	return applicationSyncStatusBlock{
+		Revision:  types.StringValue(ss.Revision),
+		Revisions: pie.Map(ss.Revisions, types.StringValue),
+		Status:    types.StringValue(string(ss.Status)),
+	}
+}
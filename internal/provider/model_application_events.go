@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type applicationEventsModel struct {
+	ID                   types.String       `tfsdk:"id"`
+	ApplicationName      types.String       `tfsdk:"application_name"`
+	ApplicationNamespace types.String       `tfsdk:"application_namespace"`
+	Project              types.String       `tfsdk:"project"`
+	ResourceName         types.String       `tfsdk:"resource_name"`
+	ResourceNamespace    types.String       `tfsdk:"resource_namespace"`
+	ResourceUID          types.String       `tfsdk:"resource_uid"`
+	Events               []applicationEvent `tfsdk:"events"`
+}
+
+func applicationEventsSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Application events identifier",
+			Computed:            true,
+		},
+		"application_name": schema.StringAttribute{
+			MarkdownDescription: "Name of the application.",
+			Required:            true,
+		},
+		"application_namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace of the application. Defaults to the namespace of the ArgoCD control plane.",
+			Optional:            true,
+		},
+		"project": schema.StringAttribute{
+			MarkdownDescription: "Project to which the application belongs.",
+			Optional:            true,
+		},
+		"resource_name": schema.StringAttribute{
+			MarkdownDescription: "Name of a resource managed by the application. When set, events for this resource are returned rather than those of the application itself.",
+			Optional:            true,
+		},
+		"resource_namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace of the resource managed by the application. Only relevant when `resource_name` is set.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("resource_name")),
+			},
+		},
+		"resource_uid": schema.StringAttribute{
+			MarkdownDescription: "UID of the resource managed by the application. Only relevant when `resource_name` is set.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot("resource_name")),
+			},
+		},
+		"events": schema.ListNestedAttribute{
+			MarkdownDescription: "Kubernetes events, ordered from oldest to most recent.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"count": schema.Int64Attribute{
+						MarkdownDescription: "The number of times this event has occurred.",
+						Computed:            true,
+					},
+					"first_timestamp": schema.StringAttribute{
+						MarkdownDescription: "The time at which the event was first recorded.",
+						Computed:            true,
+					},
+					"last_timestamp": schema.StringAttribute{
+						MarkdownDescription: "The time at which the most recent occurrence of this event was recorded.",
+						Computed:            true,
+					},
+					"message": schema.StringAttribute{
+						MarkdownDescription: "Human-readable description of the status of this operation.",
+						Computed:            true,
+					},
+					"reason": schema.StringAttribute{
+						MarkdownDescription: "Short, machine understandable string that gives the reason for the transition into the object's current status.",
+						Computed:            true,
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Type of this event (`Normal` or `Warning`).",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+type applicationEvent struct {
+	Count          types.Int64  `tfsdk:"count"`
+	FirstTimestamp types.String `tfsdk:"first_timestamp"`
+	LastTimestamp  types.String `tfsdk:"last_timestamp"`
+	Message        types.String `tfsdk:"message"`
+	Reason         types.String `tfsdk:"reason"`
+	Type           types.String `tfsdk:"type"`
+}
+
+func newApplicationEvents(es []corev1.Event) []applicationEvent {
+	if es == nil {
+		return nil
+	}
+
+	events := make([]applicationEvent, len(es))
+
+	for i, e := range es {
+		events[i] = applicationEvent{
+			Count:          types.Int64Value(int64(e.Count)),
+			FirstTimestamp: eventTimeString(e.FirstTimestamp),
+			LastTimestamp:  eventTimeString(eventLastTimestamp(e)),
+			Message:        types.StringValue(e.Message),
+			Reason:         types.StringValue(e.Reason),
+			Type:           types.StringValue(e.Type),
+		}
+	}
+
+	return events
+}
+
+func eventTimeString(t metav1.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.String())
+}
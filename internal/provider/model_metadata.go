@@ -2,7 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -14,6 +17,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const objectMetaDescription = "Standard Kubernetes object metadata. For more info see the [Kubernetes reference](https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata)."
+
 type objectMeta struct {
 	Name            types.String            `tfsdk:"name"`
 	Namespace       types.String            `tfsdk:"namespace"`
@@ -26,59 +31,83 @@ type objectMeta struct {
 
 func objectMetaSchemaAttribute(objectName string, computed bool) schema.Attribute {
 	return schema.SingleNestedAttribute{
-		MarkdownDescription: "Standard Kubernetes object metadata. For more info see the [Kubernetes reference](https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata).",
+		MarkdownDescription: objectMetaDescription,
 		Required:            true,
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("Name of the %s, must be unique. Cannot be updated. More info: http://kubernetes.io/docs/user-guide/identifiers#names", objectName),
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Validators: []validator.String{
-					validators.IsDNSSubdomain(),
-				},
+		Attributes:          objectMetaSchemaAttributes(objectName, computed),
+	}
+}
+
+// objectMetaSchemaBlock returns the metadata as a list block holding a single
+// element, for resources that retain the block shape of their SDK
+// implementation.
+func objectMetaSchemaBlock(objectName string) schema.Block {
+	return schema.ListNestedBlock{
+		MarkdownDescription: objectMetaDescription,
+		NestedObject: schema.NestedBlockObject{
+			Attributes: objectMetaSchemaAttributes(objectName, false),
+		},
+		Validators: []validator.List{
+			listvalidator.IsRequired(),
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func objectMetaSchemaAttributes(objectName string, computed bool) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Name of the %s, must be unique. Cannot be updated. More info: http://kubernetes.io/docs/user-guide/identifiers#names", objectName),
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
 			},
-			"namespace": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("Namespace of the %s, must be unique. Cannot be updated. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/", objectName),
-				Optional:            true,
-				Computed:            computed,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
-				Validators: []validator.String{
-					validators.IsDNSSubdomain(),
-				},
+			Validators: []validator.String{
+				validators.IsDNSSubdomain(),
 			},
-			"annotations": schema.MapAttribute{
-				MarkdownDescription: "An unstructured key value map stored with the cluster secret that may be used to store arbitrary metadata. More info: http://kubernetes.io/docs/user-guide/annotations",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-				Validators: []validator.Map{
-					validators.MetadataAnnotations(),
-				},
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Namespace of the %s, must be unique. Cannot be updated. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/", objectName),
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
-			"labels": schema.MapAttribute{
-				MarkdownDescription: "Map of string keys and values that can be used to organize and categorize (scope and select) the cluster secret. May match selectors of replication controllers and services. More info: http://kubernetes.io/docs/user-guide/labels",
-				Computed:            computed,
-				Optional:            !computed,
-				ElementType:         types.StringType,
-				Validators: []validator.Map{
-					validators.MetadataLabels(),
-				},
+			Validators: []validator.String{
+				validators.IsDNSSubdomain(),
 			},
-			"generation": schema.Int64Attribute{
-				MarkdownDescription: "A sequence number representing a specific generation of the desired state.",
-				Computed:            true,
+		},
+		"annotations": schema.MapAttribute{
+			MarkdownDescription: fmt.Sprintf("An unstructured key value map stored with the %s that may be used to store arbitrary metadata. More info: http://kubernetes.io/docs/user-guide/annotations", objectName),
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				validators.MetadataAnnotations(),
 			},
-			"resource_version": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("An opaque value that represents the internal version of this %s that can be used by clients to determine when %s has changed. Read more: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#concurrency-control-and-consistency", objectName, objectName),
-				Computed:            true,
+		},
+		"labels": schema.MapAttribute{
+			MarkdownDescription: fmt.Sprintf("Map of string keys and values that can be used to organize and categorize (scope and select) the %s. May match selectors of replication controllers and services. More info: http://kubernetes.io/docs/user-guide/labels", objectName),
+			Computed:            computed,
+			Optional:            !computed,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				validators.MetadataLabels(),
 			},
-			"uid": schema.StringAttribute{
-				MarkdownDescription: fmt.Sprintf("The unique in time and space value for this %s. More info: http://kubernetes.io/docs/user-guide/identifiers#uids", objectName),
-				Computed:            true,
+		},
+		"generation": schema.Int64Attribute{
+			MarkdownDescription: "A sequence number representing a specific generation of the desired state.",
+			Computed:            true,
+		},
+		"resource_version": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("An opaque value that represents the internal version of this %s that can be used by clients to determine when %s has changed. Read more: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#concurrency-control-and-consistency", objectName, objectName),
+			Computed:            true,
+		},
+		"uid": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("The unique in time and space value for this %s. More info: http://kubernetes.io/docs/user-guide/identifiers#uids", objectName),
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
 	}
@@ -95,3 +124,34 @@ func newObjectMeta(om metav1.ObjectMeta) objectMeta {
 		UID:             types.StringValue(string(om.UID)),
 	}
 }
+
+func (m objectMeta) toObjectMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Annotations: utils.MapMap(m.Annotations, types.String.ValueString),
+		Labels:      utils.MapMap(m.Labels, types.String.ValueString),
+		Name:        m.Name.ValueString(),
+		Namespace:   m.Namespace.ValueString(),
+	}
+}
+
+// metadataRemoveInternalKeys removes the internal keys (see
+// metadataIsInternalKey) from the annotations or labels returned by the API,
+// unless they are explicitly managed.
+func metadataRemoveInternalKeys(m map[string]types.String, managed map[string]types.String) map[string]types.String {
+	for k := range m {
+		if _, ok := managed[k]; !ok && metadataIsInternalKey(k) {
+			delete(m, k)
+		}
+	}
+
+	return m
+}
+
+func metadataIsInternalKey(key string) bool {
+	u, err := url.Parse("//" + key)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasSuffix(u.Hostname(), "kubernetes.io") || key == "notified.notifications.argoproj.io"
+}
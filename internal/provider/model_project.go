@@ -0,0 +1,543 @@
+package provider
+
+import (
+	"regexp"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/elliotchance/pie/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/oboukili/terraform-provider-argocd/internal/validators"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type projectModel struct {
+	ID       types.String `tfsdk:"id"`
+	Metadata objectMeta   `tfsdk:"metadata"`
+	Spec     *projectSpec `tfsdk:"spec"`
+}
+
+func (m projectModel) toAppProject() *v1alpha1.AppProject {
+	return &v1alpha1.AppProject{
+		ObjectMeta: m.Metadata.toObjectMeta(),
+		Spec:       m.Spec.toAppProjectSpec(),
+	}
+}
+
+type projectSpec struct {
+	ClusterResourceBlacklist   []projectGroupKind        `tfsdk:"cluster_resource_blacklist"`
+	ClusterResourceWhitelist   []projectGroupKind        `tfsdk:"cluster_resource_whitelist"`
+	Description                types.String              `tfsdk:"description"`
+	Destinations               []projectDestination      `tfsdk:"destinations"`
+	NamespaceResourceBlacklist []projectGroupKind        `tfsdk:"namespace_resource_blacklist"`
+	NamespaceResourceWhitelist []projectGroupKind        `tfsdk:"namespace_resource_whitelist"`
+	OrphanedResources          *projectOrphanedResources `tfsdk:"orphaned_resources"`
+	Roles                      []projectRole             `tfsdk:"roles"`
+	SignatureKeys              []types.String            `tfsdk:"signature_keys"`
+	SourceNamespaces           []types.String            `tfsdk:"source_namespaces"`
+	SourceRepos                []types.String            `tfsdk:"source_repos"`
+	SyncWindows                []projectSyncWindow       `tfsdk:"sync_windows"`
+}
+
+func projectSpecSchemaAttribute() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "ArgoCD AppProject spec.",
+		Required:            true,
+		Attributes: map[string]schema.Attribute{
+			"cluster_resource_blacklist": projectGroupKindsSchemaAttribute("Blacklisted cluster level resources.", true),
+			"cluster_resource_whitelist": projectGroupKindsSchemaAttribute("Whitelisted cluster level resources.", true),
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Project description.",
+				Optional:            true,
+			},
+			"destinations":                 projectDestinationsSchemaAttribute(),
+			"namespace_resource_blacklist": projectGroupKindsSchemaAttribute("Blacklisted namespace level resources.", false),
+			"namespace_resource_whitelist": projectGroupKindsSchemaAttribute("Whitelisted namespace level resources.", false),
+			"orphaned_resources":           projectOrphanedResourcesSchemaAttribute(),
+			"roles":                        projectRolesSchemaAttribute(),
+			"signature_keys": schema.ListAttribute{
+				MarkdownDescription: "List of PGP key IDs that commits in Git must be signed with in order to be allowed for sync.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"source_namespaces": schema.SetAttribute{
+				MarkdownDescription: "List of namespaces that application resources are allowed to be created in.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"source_repos": schema.ListAttribute{
+				MarkdownDescription: "List of repository URLs which can be used for deployment. Can be set to `[\"*\"]` to allow all configured repositories configured in ArgoCD.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"sync_windows": projectSyncWindowsSchemaAttribute(),
+		},
+	}
+}
+
+func newProjectSpec(ps v1alpha1.AppProjectSpec) *projectSpec {
+	return &projectSpec{
+		ClusterResourceBlacklist:   newProjectGroupKinds(ps.ClusterResourceBlacklist),
+		ClusterResourceWhitelist:   newProjectGroupKinds(ps.ClusterResourceWhitelist),
+		Description:                types.StringValue(ps.Description),
+		Destinations:               newProjectDestinations(ps.Destinations),
+		NamespaceResourceBlacklist: newProjectGroupKinds(ps.NamespaceResourceBlacklist),
+		NamespaceResourceWhitelist: newProjectGroupKinds(ps.NamespaceResourceWhitelist),
+		OrphanedResources:          newProjectOrphanedResources(ps.OrphanedResources),
+		Roles:                      newProjectRoles(ps.Roles),
+		SignatureKeys:              pie.Map(ps.SignatureKeys, func(k v1alpha1.SignatureKey) types.String { return types.StringValue(k.KeyID) }),
+		SourceNamespaces:           pie.Map(ps.SourceNamespaces, types.StringValue),
+		SourceRepos:                pie.Map(ps.SourceRepos, types.StringValue),
+		SyncWindows:                newProjectSyncWindows(ps.SyncWindows),
+	}
+}
+
+func (m *projectSpec) toAppProjectSpec() v1alpha1.AppProjectSpec {
+	if m == nil {
+		return v1alpha1.AppProjectSpec{}
+	}
+
+	return v1alpha1.AppProjectSpec{
+		ClusterResourceBlacklist:   projectGroupKindsToArgoCD(m.ClusterResourceBlacklist),
+		ClusterResourceWhitelist:   projectGroupKindsToArgoCD(m.ClusterResourceWhitelist),
+		Description:                m.Description.ValueString(),
+		Destinations:               projectDestinationsToArgoCD(m.Destinations),
+		NamespaceResourceBlacklist: projectGroupKindsToArgoCD(m.NamespaceResourceBlacklist),
+		NamespaceResourceWhitelist: projectGroupKindsToArgoCD(m.NamespaceResourceWhitelist),
+		OrphanedResources:          m.OrphanedResources.toOrphanedResourcesMonitorSettings(),
+		Roles:                      projectRolesToArgoCD(m.Roles),
+		SignatureKeys:              pie.Map(m.SignatureKeys, func(k types.String) v1alpha1.SignatureKey { return v1alpha1.SignatureKey{KeyID: k.ValueString()} }),
+		SourceNamespaces:           pie.Map(m.SourceNamespaces, types.String.ValueString),
+		SourceRepos:                pie.Map(m.SourceRepos, types.String.ValueString),
+		SyncWindows:                projectSyncWindowsToArgoCD(m.SyncWindows),
+	}
+}
+
+type projectGroupKind struct {
+	Group types.String `tfsdk:"group"`
+	Kind  types.String `tfsdk:"kind"`
+}
+
+func projectGroupKindsSchemaAttribute(description string, validateGroup bool) schema.Attribute {
+	group := schema.StringAttribute{
+		MarkdownDescription: "The Kubernetes resource Group to match for.",
+		Optional:            true,
+	}
+
+	if validateGroup {
+		group.Validators = []validator.String{
+			projectGroupNameValidator(),
+		}
+	}
+
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"group": group,
+				"kind": schema.StringAttribute{
+					MarkdownDescription: "The Kubernetes resource Kind to match for.",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func newProjectGroupKinds(gks []metav1.GroupKind) []projectGroupKind {
+	if gks == nil {
+		return nil
+	}
+
+	m := make([]projectGroupKind, len(gks))
+	for i, v := range gks {
+		m[i] = projectGroupKind{
+			Group: types.StringValue(v.Group),
+			Kind:  types.StringValue(v.Kind),
+		}
+	}
+
+	return m
+}
+
+func projectGroupKindsToArgoCD(gks []projectGroupKind) []metav1.GroupKind {
+	if gks == nil {
+		return nil
+	}
+
+	r := make([]metav1.GroupKind, len(gks))
+	for i, v := range gks {
+		r[i] = metav1.GroupKind{
+			Group: v.Group.ValueString(),
+			Kind:  v.Kind.ValueString(),
+		}
+	}
+
+	return r
+}
+
+type projectDestination struct {
+	Server    types.String `tfsdk:"server"`
+	Namespace types.String `tfsdk:"namespace"`
+	Name      types.String `tfsdk:"name"`
+}
+
+func projectDestinationsSchemaAttribute() schema.Attribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "Destinations available for deployment.",
+		Required:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"server": schema.StringAttribute{
+					MarkdownDescription: "URL of the target cluster and must be set to the Kubernetes control plane API.",
+					Optional:            true,
+				},
+				"namespace": schema.StringAttribute{
+					MarkdownDescription: "Target namespace for applications' resources.",
+					Required:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the destination cluster which can be used instead of server.",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+func newProjectDestinations(ds []v1alpha1.ApplicationDestination) []projectDestination {
+	if ds == nil {
+		return nil
+	}
+
+	m := make([]projectDestination, len(ds))
+	for i, v := range ds {
+		m[i] = projectDestination{
+			Name:      types.StringValue(v.Name),
+			Namespace: types.StringValue(v.Namespace),
+			Server:    types.StringValue(v.Server),
+		}
+	}
+
+	return m
+}
+
+func projectDestinationsToArgoCD(ds []projectDestination) []v1alpha1.ApplicationDestination {
+	if ds == nil {
+		return nil
+	}
+
+	r := make([]v1alpha1.ApplicationDestination, len(ds))
+	for i, v := range ds {
+		r[i] = v1alpha1.ApplicationDestination{
+			Name:      v.Name.ValueString(),
+			Namespace: v.Namespace.ValueString(),
+			Server:    v.Server.ValueString(),
+		}
+	}
+
+	return r
+}
+
+type projectOrphanedResources struct {
+	Ignore []projectOrphanedResourcesIgnore `tfsdk:"ignore"`
+	Warn   types.Bool                       `tfsdk:"warn"`
+}
+
+func projectOrphanedResourcesSchemaAttribute() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Settings specifying if controller should monitor orphaned resources of apps in this project.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"ignore": schema.SetNestedAttribute{
+				MarkdownDescription: "Resources that should be excluded from being considered as orphaned.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							MarkdownDescription: "The Kubernetes resource Group to match for.",
+							Optional:            true,
+							Validators: []validator.String{
+								projectGroupNameValidator(),
+							},
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "The Kubernetes resource Kind to match for.",
+							Optional:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The Kubernetes resource name to match for.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"warn": schema.BoolAttribute{
+				MarkdownDescription: "Whether a warning condition should be created for apps which have orphaned resources.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func newProjectOrphanedResources(ors *v1alpha1.OrphanedResourcesMonitorSettings) *projectOrphanedResources {
+	if ors == nil {
+		return nil
+	}
+
+	m := &projectOrphanedResources{
+		Warn: types.BoolPointerValue(ors.Warn),
+	}
+
+	if ors.Ignore != nil {
+		m.Ignore = make([]projectOrphanedResourcesIgnore, len(ors.Ignore))
+		for i, v := range ors.Ignore {
+			m.Ignore[i] = projectOrphanedResourcesIgnore{
+				Group: types.StringValue(v.Group),
+				Kind:  types.StringValue(v.Kind),
+				Name:  types.StringValue(v.Name),
+			}
+		}
+	}
+
+	return m
+}
+
+func (m *projectOrphanedResources) toOrphanedResourcesMonitorSettings() *v1alpha1.OrphanedResourcesMonitorSettings {
+	if m == nil {
+		return nil
+	}
+
+	ors := &v1alpha1.OrphanedResourcesMonitorSettings{
+		Warn: m.Warn.ValueBoolPointer(),
+	}
+
+	if m.Ignore != nil {
+		ors.Ignore = make([]v1alpha1.OrphanedResourceKey, len(m.Ignore))
+		for i, v := range m.Ignore {
+			ors.Ignore[i] = v1alpha1.OrphanedResourceKey{
+				Group: v.Group.ValueString(),
+				Kind:  v.Kind.ValueString(),
+				Name:  v.Name.ValueString(),
+			}
+		}
+	}
+
+	return ors
+}
+
+type projectOrphanedResourcesIgnore struct {
+	Group types.String `tfsdk:"group"`
+	Kind  types.String `tfsdk:"kind"`
+	Name  types.String `tfsdk:"name"`
+}
+
+type projectRole struct {
+	Description types.String   `tfsdk:"description"`
+	Groups      []types.String `tfsdk:"groups"`
+	Name        types.String   `tfsdk:"name"`
+	Policies    []types.String `tfsdk:"policies"`
+}
+
+func projectRolesSchemaAttribute() schema.Attribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: "User defined RBAC roles associated with this project.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Description of the role.",
+					Optional:            true,
+				},
+				"groups": schema.ListAttribute{
+					MarkdownDescription: "List of OIDC group claims bound to this role.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the role.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(
+							regexp.MustCompile(`^[a-zA-Z0-9]([-_a-zA-Z0-9]*[a-zA-Z0-9])?$`),
+							"must consist of alphanumeric characters, '-' or '_', and must start and end with an alphanumeric character",
+						),
+					},
+				},
+				"policies": schema.ListAttribute{
+					MarkdownDescription: "List of casbin formatted strings that define access policies for the role in the project. For more information, see the [ArgoCD RBAC reference](https://argoproj.github.io/argo-cd/operator-manual/rbac/#rbac-permission-structure).",
+					Required:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+}
+
+func newProjectRoles(prs []v1alpha1.ProjectRole) []projectRole {
+	if prs == nil {
+		return nil
+	}
+
+	m := make([]projectRole, len(prs))
+	for i, v := range prs {
+		m[i] = projectRole{
+			Description: types.StringValue(v.Description),
+			Groups:      pie.Map(v.Groups, types.StringValue),
+			Name:        types.StringValue(v.Name),
+			Policies:    pie.Map(v.Policies, types.StringValue),
+		}
+	}
+
+	return m
+}
+
+func projectRolesToArgoCD(rs []projectRole) []v1alpha1.ProjectRole {
+	if rs == nil {
+		return nil
+	}
+
+	r := make([]v1alpha1.ProjectRole, len(rs))
+	for i, v := range rs {
+		r[i] = v1alpha1.ProjectRole{
+			Description: v.Description.ValueString(),
+			Groups:      pie.Map(v.Groups, types.String.ValueString),
+			Name:        v.Name.ValueString(),
+			Policies:    pie.Map(v.Policies, types.String.ValueString),
+		}
+	}
+
+	return r
+}
+
+type projectSyncWindow struct {
+	Applications []types.String `tfsdk:"applications"`
+	Clusters     []types.String `tfsdk:"clusters"`
+	Duration     types.String   `tfsdk:"duration"`
+	Kind         types.String   `tfsdk:"kind"`
+	ManualSync   types.Bool     `tfsdk:"manual_sync"`
+	Namespaces   []types.String `tfsdk:"namespaces"`
+	Schedule     types.String   `tfsdk:"schedule"`
+	Timezone     types.String   `tfsdk:"timezone"`
+}
+
+func projectSyncWindowsSchemaAttribute() schema.Attribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "Settings controlling when syncs can be run for apps in this project.",
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"applications": schema.ListAttribute{
+					MarkdownDescription: "List of applications that the window will apply to.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"clusters": schema.ListAttribute{
+					MarkdownDescription: "List of clusters that the window will apply to.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"duration": schema.StringAttribute{
+					MarkdownDescription: "Amount of time the sync window will be open.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.IsDuration(),
+					},
+				},
+				"kind": schema.StringAttribute{
+					MarkdownDescription: "Defines if the window allows or blocks syncs, allowed values are `allow` or `deny`.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("allow", "deny"),
+					},
+				},
+				"manual_sync": schema.BoolAttribute{
+					MarkdownDescription: "Enables manual syncs when they would otherwise be blocked.",
+					Optional:            true,
+				},
+				"namespaces": schema.ListAttribute{
+					MarkdownDescription: "List of namespaces that the window will apply to.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+				"schedule": schema.StringAttribute{
+					MarkdownDescription: "Time the window will begin, specified in cron format.",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.IsCronSchedule(),
+					},
+				},
+				"timezone": schema.StringAttribute{
+					MarkdownDescription: "Timezone that the schedule will be evaluated in.",
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("UTC"),
+					Validators: []validator.String{
+						validators.IsTimezone(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func newProjectSyncWindows(sws v1alpha1.SyncWindows) []projectSyncWindow {
+	if sws == nil {
+		return nil
+	}
+
+	m := make([]projectSyncWindow, 0, len(sws))
+	for _, v := range sws {
+		if v == nil {
+			continue
+		}
+
+		m = append(m, projectSyncWindow{
+			Applications: pie.Map(v.Applications, types.StringValue),
+			Clusters:     pie.Map(v.Clusters, types.StringValue),
+			Duration:     types.StringValue(v.Duration),
+			Kind:         types.StringValue(v.Kind),
+			ManualSync:   types.BoolValue(v.ManualSync),
+			Namespaces:   pie.Map(v.Namespaces, types.StringValue),
+			Schedule:     types.StringValue(v.Schedule),
+			Timezone:     types.StringValue(v.TimeZone),
+		})
+	}
+
+	return m
+}
+
+func projectSyncWindowsToArgoCD(sws []projectSyncWindow) v1alpha1.SyncWindows {
+	if sws == nil {
+		return nil
+	}
+
+	r := make(v1alpha1.SyncWindows, len(sws))
+	for i, v := range sws {
+		r[i] = &v1alpha1.SyncWindow{
+			Applications: pie.Map(v.Applications, types.String.ValueString),
+			Clusters:     pie.Map(v.Clusters, types.String.ValueString),
+			Duration:     v.Duration.ValueString(),
+			Kind:         v.Kind.ValueString(),
+			ManualSync:   v.ManualSync.ValueBool(),
+			Namespaces:   pie.Map(v.Namespaces, types.String.ValueString),
+			Schedule:     v.Schedule.ValueString(),
+			TimeZone:     v.Timezone.ValueString(),
+		}
+	}
+
+	return r
+}
+
+// projectGroupNameValidator ensures that Kubernetes resource groups do not
+// contain characters that would break the casbin policies generated by ArgoCD.
+func projectGroupNameValidator() validator.String {
+	return stringvalidator.RegexMatches(
+		regexp.MustCompile("^[^,\n\r\t]*$"),
+		"must not contain commas, carriage returns, newlines or tabs",
+	)
+}
@@ -3,20 +3,22 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/url"
+	"time"
 
-	"github.com/argoproj/argo-cd/v2/cmd/argocd/commands/headless"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/session"
-	"github.com/argoproj/argo-cd/v2/util/cache"
 	"github.com/argoproj/argo-cd/v2/util/io"
 	"github.com/argoproj/argo-cd/v2/util/localconfig"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
+	"golang.org/x/net/http/httpproxy"
 	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -28,6 +30,11 @@ type ArgoCDProviderConfig struct {
 	Username  types.String `tfsdk:"username"`
 	Password  types.String `tfsdk:"password"`
 
+	// Obtain the authentication token from an external command or an OIDC
+	// issuer
+	AuthExec []AuthExec `tfsdk:"auth_exec"`
+	OIDC     []OIDC     `tfsdk:"oidc"`
+
 	// When using standard login either server address or port forwarding must be used
 	ServerAddr               types.String `tfsdk:"server_addr"`
 	PortForward              types.Bool   `tfsdk:"port_forward"`
@@ -43,15 +50,33 @@ type ArgoCDProviderConfig struct {
 	Context        types.String `tfsdk:"context"`
 
 	// Other configuration
+	CertData        types.String `tfsdk:"cert_data"`
 	CertFile        types.String `tfsdk:"cert_file"`
+	ClientCertData  types.String `tfsdk:"client_cert_data"`
 	ClientCertFile  types.String `tfsdk:"client_cert_file"`
 	ClientCertKey   types.String `tfsdk:"client_cert_key"`
+	ClientKeyData   types.String `tfsdk:"client_key_data"`
 	GRPCWeb         types.Bool   `tfsdk:"grpc_web"`
 	GRPCWebRootPath types.String `tfsdk:"grpc_web_root_path"`
 	Headers         types.Set    `tfsdk:"headers"`
 	Insecure        types.Bool   `tfsdk:"insecure"`
+	NoProxy         types.String `tfsdk:"no_proxy"`
 	PlainText       types.Bool   `tfsdk:"plain_text"`
+	ProxyURL        types.String `tfsdk:"proxy_url"`
+	ReadCache       types.Bool   `tfsdk:"read_cache"`
+	Retry           []Retry      `tfsdk:"retry"`
 	UserAgent       types.String `tfsdk:"user_agent"`
+
+	// Metadata merged into that of every object managed by the provider
+	DefaultMetadata []DefaultMetadata `tfsdk:"default_metadata"`
+
+	// Limits on the requests sent to the ArgoCD API server
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Int64 `tfsdk:"requests_per_second"`
+
+	// Whether any of the configuration is unknown, i.e. depends on resources
+	// that are yet to be created. Not part of the schema.
+	Unknown bool `tfsdk:"-"`
 }
 
 func (p ArgoCDProviderConfig) getApiClientOptions(ctx context.Context) (*apiclient.ClientOptions, diag.Diagnostics) {
@@ -80,7 +105,7 @@ func (p ArgoCDProviderConfig) getApiClientOptions(ctx context.Context) (*apiclie
 		opts.Headers = h
 	}
 
-	coreEnabled, d := p.setCoreOpts(opts)
+	coreEnabled, d := p.setCoreOpts(ctx, opts)
 
 	diags.Append(d...)
 
@@ -96,72 +121,196 @@ func (p ArgoCDProviderConfig) getApiClientOptions(ctx context.Context) (*apiclie
 	password := getDefaultString(p.Password, "ARGOCD_AUTH_PASSWORD")
 
 	usernameAndPasswordSet := username != "" && password != ""
+	authExecSet := len(p.AuthExec) > 0
+	oidcSet := len(p.OIDC) > 0
+	tokenSourceSet := authExecSet || oidcSet
 
 	switch {
 	// Provider configuration errors
 	case !coreEnabled && !portForwardingEnabled && !localConfigEnabled && opts.ServerAddr == "":
 		diags.Append(diagnostics.Error("invalid provider configuration: one of `core,port_forward,port_forward_with_namespace,use_local_config,server_addr` must be specified", nil)...)
-	case portForwardingEnabled && opts.AuthToken == "" && !usernameAndPasswordSet:
-		diags.Append(diagnostics.Error("invalid provider configuration: either `username/password` or `auth_token` must be specified when port forwarding is enabled", nil)...)
-	case opts.ServerAddr != "" && !coreEnabled && opts.AuthToken == "" && !usernameAndPasswordSet:
-		diags.Append(diagnostics.Error("invalid provider configuration: either `username/password` or `auth_token` must be specified if `server_addr` is specified", nil)...)
+	case authExecSet && oidcSet:
+		diags.Append(diagnostics.Error("invalid provider configuration: `auth_exec` and `oidc` cannot be specified together", nil)...)
+	case tokenSourceSet && (!p.AuthToken.IsNull() || !p.Username.IsNull() || !p.Password.IsNull() || coreEnabled || localConfigEnabled):
+		diags.Append(diagnostics.Error("invalid provider configuration: `auth_exec` and `oidc` cannot be specified alongside `auth_token`, `username/password`, `core` or `use_local_config`", nil)...)
+	case portForwardingEnabled && opts.AuthToken == "" && !usernameAndPasswordSet && !tokenSourceSet:
+		diags.Append(diagnostics.Error("invalid provider configuration: one of `username/password`, `auth_token`, `auth_exec` or `oidc` must be specified when port forwarding is enabled", nil)...)
+	case opts.ServerAddr != "" && !coreEnabled && opts.AuthToken == "" && !usernameAndPasswordSet && !tokenSourceSet:
+		diags.Append(diagnostics.Error("invalid provider configuration: one of `username/password`, `auth_token`, `auth_exec` or `oidc` must be specified if `server_addr` is specified", nil)...)
 	}
 
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	switch {
 	// Handle "special" configuration use-cases
-	case coreEnabled:
-		// HACK: `headless.StartLocalServer` manipulates this global variable
-		// when starting the local server without checking it's length/contents
-		// which leads to a panic if called multiple times. So, we need to
-		// ensure we "reset" it before calling the method.
-		if runtimeErrorHandlers == nil {
-			runtimeErrorHandlers = runtime.ErrorHandlers
-		} else {
-			runtime.ErrorHandlers = runtimeErrorHandlers
-		}
-
-		err := headless.MaybeStartLocalServer(ctx, opts, "", nil, nil, cache.RedisCompressionNone, nil)
+	if coreEnabled {
+		err := startLocalServer(ctx, opts)
 		if err != nil {
 			diags.Append(diagnostics.Error("failed to start local server", err)...)
 			return nil, diags
 		}
-	case opts.ServerAddr != "" && opts.AuthToken == "" && usernameAndPasswordSet:
-		apiClient, err := apiclient.NewClient(opts)
-		if err != nil {
-			diags.Append(diagnostics.Error("failed to create new API client", err)...)
+	}
+
+	return opts, diags
+}
+
+// getTokenSource returns the source of the (renewable) authentication token,
+// if any, given the API client options derived from the provider
+// configuration. The token is either obtained from the `auth_exec` command,
+// from the OIDC issuer or in exchange for the username and password.
+func (p ArgoCDProviderConfig) getTokenSource(ctx context.Context, opts *apiclient.ClientOptions, connOpts *connOptions) (tokenSource, diag.Diagnostics) {
+	if len(p.AuthExec) > 0 {
+		o, diags := p.AuthExec[0].options(ctx)
+		if diags.HasError() {
 			return nil, diags
 		}
 
-		closer, sc, err := apiClient.NewSessionClient()
-		if err != nil {
-			diags.Append(diagnostics.Error("failed to create new session client", err)...)
+		return o.token, diags
+	}
+
+	if len(p.OIDC) > 0 {
+		o, diags := p.OIDC[0].options(ctx)
+		if diags.HasError() {
 			return nil, diags
 		}
 
-		defer io.Close(closer)
+		// The OIDC issuer is reached through the same proxy as the ArgoCD API
+		// server.
+		o.httpClient = newHTTPClient(connOpts.proxy)
 
-		sessionOpts := session.SessionCreateRequest{
-			Username: username,
-			Password: password,
-		}
+		return o.token, diags
+	}
 
-		resp, err := sc.Create(ctx, &sessionOpts)
+	username := getDefaultString(p.Username, "ARGOCD_AUTH_USERNAME")
+	password := getDefaultString(p.Password, "ARGOCD_AUTH_PASSWORD")
+
+	if opts.Core || opts.ServerAddr == "" || opts.AuthToken != "" || username == "" || password == "" {
+		return nil, nil
+	}
+
+	return func(ctx context.Context) (string, time.Time, error) {
+		token, err := createSession(ctx, connOpts, username, password)
 		if err != nil {
-			diags.Append(diagnostics.Error("failed to create new session", err)...)
-			return nil, diags
+			return "", time.Time{}, fmt.Errorf("failed to create new session: %w", err)
 		}
 
-		opts.AuthToken = resp.Token
+		return token, time.Time{}, nil
+	}, nil
+}
+
+// createSession exchanges the username and password for a session token.
+func createSession(ctx context.Context, connOpts *connOptions, username, password string) (string, error) {
+	conn, closer, err := connOpts.dial(tokenCredentials(""))
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to ArgoCD API server: %w", err)
 	}
 
-	return opts, diags
+	defer io.Close(closer)
+
+	sc := session.NewSessionServiceClient(conn)
+
+	sessionOpts := session.SessionCreateRequest{
+		Username: username,
+		Password: password,
+	}
+
+	resp, err := sc.Create(ctx, &sessionOpts)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
 }
 
-func (p ArgoCDProviderConfig) setCoreOpts(opts *apiclient.ClientOptions) (bool, diag.Diagnostics) {
+// getClientTLSData returns the TLS material of the API client that is set
+// inline, after validating it.
+func (p ArgoCDProviderConfig) getClientTLSData() (clientTLSData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	t := clientTLSData{
+		certData:       getDefaultString(p.CertData, "ARGOCD_CERT_DATA"),
+		clientCertData: getDefaultString(p.ClientCertData, "ARGOCD_CLIENT_CERT_DATA"),
+		clientKeyData:  getDefaultString(p.ClientKeyData, "ARGOCD_CLIENT_KEY_DATA"),
+	}
+
+	switch {
+	case t.certData == "":
+	case p.CertFile.ValueString() != "":
+		diags.AddAttributeError(path.Root("cert_data"), "Conflicting TLS configuration", "`cert_data` cannot be specified alongside `cert_file`")
+	case !x509.NewCertPool().AppendCertsFromPEM([]byte(t.certData)):
+		diags.AddAttributeError(path.Root("cert_data"), "Invalid certificate", "must hold one or more PEM encoded certificates")
+	}
+
+	switch {
+	case t.clientCertData == "" && t.clientKeyData == "":
+	case p.ClientCertFile.ValueString() != "" || p.ClientCertKey.ValueString() != "":
+		diags.AddAttributeError(path.Root("client_cert_data"), "Conflicting TLS configuration", "`client_cert_data` and `client_key_data` cannot be specified alongside `client_cert_file` and `client_cert_key`")
+	case t.clientCertData == "":
+		diags.AddAttributeError(path.Root("client_cert_data"), "Missing client certificate", "`client_cert_data` must be specified alongside `client_key_data`")
+	case t.clientKeyData == "":
+		diags.AddAttributeError(path.Root("client_key_data"), "Missing client key", "`client_key_data` must be specified alongside `client_cert_data`")
+	default:
+		if _, err := tls.X509KeyPair([]byte(t.clientCertData), []byte(t.clientKeyData)); err != nil {
+			diags.AddAttributeError(path.Root("client_cert_data"), "Invalid client certificate", fmt.Sprintf("`client_cert_data` and `client_key_data` must hold a PEM encoded certificate and its private key: %s", err))
+		}
+	}
+
+	return t, diags
+}
+
+// getProxyOptions returns the options of the proxy through which the ArgoCD
+// API server is reached, if any.
+func (p ArgoCDProviderConfig) getProxyOptions() (*proxyOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Both upper and lower case environment variables are honoured, as by
+	// the Kubernetes client.
+	env := httpproxy.FromEnvironment()
+
+	proxyURL := env.HTTPSProxy
+	if !p.ProxyURL.IsNull() && !p.ProxyURL.IsUnknown() {
+		proxyURL = p.ProxyURL.ValueString()
+	}
+
+	if proxyURL == "" {
+		return nil, diags
+	}
+
+	noProxy := env.NoProxy
+	if !p.NoProxy.IsNull() && !p.NoProxy.IsUnknown() {
+		noProxy = p.NoProxy.ValueString()
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err == nil && u.Host != "" {
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+			return &proxyOptions{url: u, noProxy: noProxy}, diags
+		}
+	}
+
+	diags.AddAttributeError(path.Root("proxy_url"), "Invalid proxy URL", "must be an absolute URL using one of the `http`, `https`, `socks5` or `socks5h` schemes")
+
+	return nil, diags
+}
+
+// setKubeProxy sets the proxy through which the Kubernetes API is reached, if
+// `proxy_url` is set. The Kubernetes client honours the `HTTPS_PROXY` and
+// `NO_PROXY` environment variables on its own otherwise.
+func (p ArgoCDProviderConfig) setKubeProxy(opts *apiclient.ClientOptions) {
+	if p.ProxyURL.ValueString() == "" {
+		return
+	}
+
+	if opts.KubeOverrides == nil {
+		opts.KubeOverrides = &clientcmd.ConfigOverrides{}
+	}
+
+	opts.KubeOverrides.ClusterInfo.ProxyURL = p.ProxyURL.ValueString()
+}
+
+func (p ArgoCDProviderConfig) setCoreOpts(ctx context.Context, opts *apiclient.ClientOptions) (bool, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	coreEnabled := p.Core.ValueBool()
@@ -176,6 +325,15 @@ func (p ArgoCDProviderConfig) setCoreOpts(opts *apiclient.ClientOptions) (bool,
 		if !p.Username.IsNull() {
 			diags.AddWarning("`username` is ignored when `core = true`.", "")
 		}
+
+		if p.Kubernetes != nil {
+			var d diag.Diagnostics
+
+			opts.KubeOverrides, d = p.Kubernetes[0].overrides(ctx)
+			diags.Append(d...)
+		}
+
+		p.setKubeProxy(opts)
 	}
 
 	return coreEnabled, diags
@@ -237,82 +395,64 @@ func (p ArgoCDProviderConfig) setPortForwardingOpts(ctx context.Context, opts *a
 
 		opts.ServerAddr = "localhost" // will be overwritten by ArgoCD module when we initialize the API client but needs to be set here to ensure we
 
-		if p.Kubernetes == nil {
-			break
-		}
+		if p.Kubernetes != nil {
+			var d diag.Diagnostics
 
-		k := p.Kubernetes[0]
-		opts.KubeOverrides = &clientcmd.ConfigOverrides{
-			AuthInfo: api.AuthInfo{
-				ClientCertificateData: bytes.NewBufferString(getDefaultString(k.ClientCertificate, "KUBE_CLIENT_CERT_DATA")).Bytes(),
-				Username:              getDefaultString(k.Username, "KUBE_USER"),
-				Password:              getDefaultString(k.Password, "KUBE_PASSWORD"),
-				ClientKeyData:         bytes.NewBufferString(getDefaultString(k.ClientKey, "KUBE_CLIENT_KEY_DATA")).Bytes(),
-				Token:                 getDefaultString(k.Token, "KUBE_TOKEN"),
-			},
-			ClusterInfo: api.Cluster{
-				InsecureSkipTLSVerify:    getDefaultBool(ctx, k.Insecure, "KUBE_INSECURE"),
-				CertificateAuthorityData: bytes.NewBufferString(getDefaultString(k.ClusterCACertificate, "KUBE_CLUSTER_CA_CERT_DATA")).Bytes(),
-			},
-			CurrentContext: getDefaultString(k.ConfigContext, "KUBE_CTX"),
-			Context: api.Context{
-				AuthInfo: getDefaultString(k.ConfigContextAuthInfo, "KUBE_CTX_AUTH_INFO"),
-				Cluster:  getDefaultString(k.ConfigContextCluster, "KUBE_CTX_CLUSTER"),
-			},
+			opts.KubeOverrides, d = p.Kubernetes[0].overrides(ctx)
+			diags.Append(d...)
 		}
 
-		h := getDefaultString(k.Host, "KUBE_HOST")
-		if h != "" {
-			// Server has to be the complete address of the Kubernetes cluster (scheme://hostname:port), not just the hostname,
-			// because `overrides` are processed too late to be taken into account by `defaultServerUrlFor()`.
-			// This basically replicates what defaultServerUrlFor() does with config but for overrides,
-			// see https://github.com/Kubernetes/client-go/blob/v12.0.0/rest/url_utils.go#L85-L87
-			hasCA := len(opts.KubeOverrides.ClusterInfo.CertificateAuthorityData) != 0
-			hasCert := len(opts.KubeOverrides.AuthInfo.ClientCertificateData) != 0
-			defaultTLS := hasCA || hasCert || opts.KubeOverrides.ClusterInfo.InsecureSkipTLSVerify
-
-			var host *url.URL
-
-			host, _, err := rest.DefaultServerURL(h, "", apimachineryschema.GroupVersion{}, defaultTLS)
-			if err == nil {
-				opts.KubeOverrides.ClusterInfo.Server = host.String()
-			} else {
-				diags.Append(diagnostics.Error(fmt.Sprintf("failed to extract default server URL for host %s", h), err)...)
-			}
+		p.setKubeProxy(opts)
+	case false:
+		if p.Kubernetes != nil && !p.Core.ValueBool() {
+			diags.AddWarning("`Kubernetes` configuration block is ignored by provider unless `core`, `port_forward` or `port_forward_with_namespace` are configured.", "")
 		}
+	}
 
-		if k.Exec == nil {
-			break
-		}
+	return portForwardingEnabled, diags
+}
 
-		e := k.Exec[0]
-		exec := &api.ExecConfig{
-			InteractiveMode: api.IfAvailableExecInteractiveMode,
-			APIVersion:      e.APIVersion.ValueString(),
-			Command:         e.Command.ValueString(),
-		}
+func (p ArgoCDProviderConfig) getRequestLimitOptions() requestLimitOptions {
+	return requestLimitOptions{
+		maxConcurrentRequests: p.MaxConcurrentRequests.ValueInt64(),
+		requestsPerSecond:     p.RequestsPerSecond.ValueInt64(),
+	}
+}
 
-		var a []string
+func (p ArgoCDProviderConfig) getRetryOptions() (retryOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := defaultRetryOptions()
 
-		diags.Append(e.Args.ElementsAs(ctx, &a, false)...)
-		exec.Args = a
+	if len(p.Retry) == 0 {
+		return opts, diags
+	}
 
-		var env map[string]string
+	r := p.Retry[0]
 
-		diags.Append(e.Env.ElementsAs(ctx, &env, false)...)
+	if !r.MaxAttempts.IsNull() {
+		opts.maxAttempts = int(r.MaxAttempts.ValueInt64())
+	}
 
-		for k, v := range env {
-			exec.Env = append(exec.Env, api.ExecEnvVar{Name: k, Value: v})
+	if !r.InitialBackoff.IsNull() {
+		d, err := time.ParseDuration(r.InitialBackoff.ValueString())
+		if err != nil {
+			diags.Append(diagnostics.Error("invalid provider configuration: failed to parse `retry.initial_backoff`", err)...)
 		}
 
-		opts.KubeOverrides.AuthInfo.Exec = exec
-	case false:
-		if p.Kubernetes != nil {
-			diags.AddWarning("`Kubernetes` configuration block is ignored by provider unless `port_forward` or `port_forward_with_namespace` are configured.", "")
+		opts.initialBackoff = d
+	}
+
+	if !r.MaxBackoff.IsNull() {
+		d, err := time.ParseDuration(r.MaxBackoff.ValueString())
+		if err != nil {
+			diags.Append(diagnostics.Error("invalid provider configuration: failed to parse `retry.max_backoff`", err)...)
 		}
+
+		opts.maxBackoff = d
 	}
 
-	return portForwardingEnabled, diags
+	return opts, diags
 }
 
 type Kubernetes struct {
@@ -336,3 +476,129 @@ type KubernetesExec struct {
 	Env        types.Map    `tfsdk:"env"`
 	Args       types.List   `tfsdk:"args"`
 }
+
+// overrides returns the overrides of the kubeconfig used to connect to the
+// Kubernetes API, either to port forward to the ArgoCD API server or to run the
+// local API server in core mode.
+func (k Kubernetes) overrides(ctx context.Context) (*clientcmd.ConfigOverrides, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	o := &clientcmd.ConfigOverrides{
+		AuthInfo: api.AuthInfo{
+			ClientCertificateData: bytes.NewBufferString(getDefaultString(k.ClientCertificate, "KUBE_CLIENT_CERT_DATA")).Bytes(),
+			Username:              getDefaultString(k.Username, "KUBE_USER"),
+			Password:              getDefaultString(k.Password, "KUBE_PASSWORD"),
+			ClientKeyData:         bytes.NewBufferString(getDefaultString(k.ClientKey, "KUBE_CLIENT_KEY_DATA")).Bytes(),
+			Token:                 getDefaultString(k.Token, "KUBE_TOKEN"),
+		},
+		ClusterInfo: api.Cluster{
+			InsecureSkipTLSVerify:    getDefaultBool(ctx, k.Insecure, "KUBE_INSECURE"),
+			CertificateAuthorityData: bytes.NewBufferString(getDefaultString(k.ClusterCACertificate, "KUBE_CLUSTER_CA_CERT_DATA")).Bytes(),
+		},
+		CurrentContext: getDefaultString(k.ConfigContext, "KUBE_CTX"),
+		Context: api.Context{
+			AuthInfo: getDefaultString(k.ConfigContextAuthInfo, "KUBE_CTX_AUTH_INFO"),
+			Cluster:  getDefaultString(k.ConfigContextCluster, "KUBE_CTX_CLUSTER"),
+		},
+	}
+
+	h := getDefaultString(k.Host, "KUBE_HOST")
+	if h != "" {
+		// Server has to be the complete address of the Kubernetes cluster (scheme://hostname:port), not just the hostname,
+		// because `overrides` are processed too late to be taken into account by `defaultServerUrlFor()`.
+		// This basically replicates what defaultServerUrlFor() does with config but for overrides,
+		// see https://github.com/Kubernetes/client-go/blob/v12.0.0/rest/url_utils.go#L85-L87
+		hasCA := len(o.ClusterInfo.CertificateAuthorityData) != 0
+		hasCert := len(o.AuthInfo.ClientCertificateData) != 0
+		defaultTLS := hasCA || hasCert || o.ClusterInfo.InsecureSkipTLSVerify
+
+		var host *url.URL
+
+		host, _, err := rest.DefaultServerURL(h, "", apimachineryschema.GroupVersion{}, defaultTLS)
+		if err == nil {
+			o.ClusterInfo.Server = host.String()
+		} else {
+			diags.Append(diagnostics.Error(fmt.Sprintf("failed to extract default server URL for host %s", h), err)...)
+		}
+	}
+
+	if k.Exec == nil {
+		return o, diags
+	}
+
+	e := k.Exec[0]
+	exec := &api.ExecConfig{
+		InteractiveMode: api.IfAvailableExecInteractiveMode,
+		APIVersion:      e.APIVersion.ValueString(),
+		Command:         e.Command.ValueString(),
+	}
+
+	var a []string
+
+	diags.Append(e.Args.ElementsAs(ctx, &a, false)...)
+	exec.Args = a
+
+	var env map[string]string
+
+	diags.Append(e.Env.ElementsAs(ctx, &env, false)...)
+
+	for k, v := range env {
+		exec.Env = append(exec.Env, api.ExecEnvVar{Name: k, Value: v})
+	}
+
+	o.AuthInfo.Exec = exec
+
+	return o, diags
+}
+
+type AuthExec struct {
+	Command      types.String `tfsdk:"command"`
+	Args         types.List   `tfsdk:"args"`
+	Env          types.Map    `tfsdk:"env"`
+	OutputFormat types.String `tfsdk:"output_format"`
+}
+
+func (e AuthExec) options(ctx context.Context) (authExecOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	o := authExecOptions{
+		command:      e.Command.ValueString(),
+		outputFormat: e.OutputFormat.ValueString(),
+	}
+
+	diags.Append(e.Args.ElementsAs(ctx, &o.args, false)...)
+	diags.Append(e.Env.ElementsAs(ctx, &o.env, false)...)
+
+	return o, diags
+}
+
+type OIDC struct {
+	IssuerURL        types.String `tfsdk:"issuer_url"`
+	ClientID         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	Scopes           types.List   `tfsdk:"scopes"`
+	Audience         types.String `tfsdk:"audience"`
+	SubjectTokenFile types.String `tfsdk:"subject_token_file"`
+}
+
+func (o OIDC) options(ctx context.Context) (oidcOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := oidcOptions{
+		issuerURL:        o.IssuerURL.ValueString(),
+		clientID:         o.ClientID.ValueString(),
+		clientSecret:     o.ClientSecret.ValueString(),
+		audience:         o.Audience.ValueString(),
+		subjectTokenFile: o.SubjectTokenFile.ValueString(),
+	}
+
+	diags.Append(o.Scopes.ElementsAs(ctx, &opts.scopes, false)...)
+
+	return opts, diags
+}
+
+type Retry struct {
+	MaxAttempts    types.Int64  `tfsdk:"max_attempts"`
+	InitialBackoff types.String `tfsdk:"initial_backoff"`
+	MaxBackoff     types.String `tfsdk:"max_backoff"`
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	oidcGrantTypeClientCredentials = "client_credentials"
+	oidcGrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oidcTokenTypeIDToken           = "urn:ietf:params:oauth:token-type:id_token"
+	oidcTokenTypeJWT               = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// oidcOptions configure the OAuth2 flow performed against an OIDC issuer to
+// obtain an ArgoCD authentication token. The token exchange flow is used if a
+// subject token file is configured, the client credentials flow otherwise.
+type oidcOptions struct {
+	issuerURL        string
+	clientID         string
+	clientSecret     string
+	scopes           []string
+	audience         string
+	subjectTokenFile string
+
+	// httpClient sends the requests to the OIDC issuer.
+	httpClient *http.Client
+}
+
+// oidcTokenResponse is the response of the token endpoint, see
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5 and
+// https://datatracker.ietf.org/doc/html/rfc8693#section-2.2.
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// token performs the OAuth2 flow and returns the resulting ID token (or, if
+// the issuer did not return one, access token), along with its expiry time
+// (zero if unknown).
+func (o oidcOptions) token(ctx context.Context) (string, time.Time, error) {
+	endpoint, err := o.tokenEndpoint(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	form := url.Values{}
+
+	if o.subjectTokenFile != "" {
+		// The file is read each time as workload identity tokens are
+		// typically rotated on disk.
+		subjectToken, err := os.ReadFile(o.subjectTokenFile)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read subject token: %w", err)
+		}
+
+		form.Set("grant_type", oidcGrantTypeTokenExchange)
+		form.Set("subject_token", strings.TrimSpace(string(subjectToken)))
+		form.Set("subject_token_type", oidcTokenTypeJWT)
+		form.Set("requested_token_type", oidcTokenTypeIDToken)
+	} else {
+		form.Set("grant_type", oidcGrantTypeClientCredentials)
+	}
+
+	if len(o.scopes) > 0 {
+		form.Set("scope", strings.Join(o.scopes, " "))
+	}
+
+	if o.audience != "" {
+		form.Set("audience", o.audience)
+	}
+
+	if o.clientSecret == "" {
+		form.Set("client_id", o.clientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if o.clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(o.clientID), url.QueryEscape(o.clientSecret))
+	}
+
+	var tr oidcTokenResponse
+
+	status, err := o.do(req, &tr)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request token from %s: %w", endpoint, err)
+	}
+
+	if status != http.StatusOK || tr.Error != "" {
+		return "", time.Time{}, fmt.Errorf("failed to request token from %s: %s", endpoint, oidcErrorMessage(status, tr))
+	}
+
+	token := tr.IDToken
+	if token == "" {
+		token = tr.AccessToken
+	}
+
+	if token == "" {
+		return "", time.Time{}, fmt.Errorf("no token returned by %s", endpoint)
+	}
+
+	if tr.ExpiresIn <= 0 {
+		return token, time.Time{}, nil
+	}
+
+	return token, time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second), nil
+}
+
+// tokenEndpoint discovers the token endpoint of the issuer, see
+// https://openid.net/specs/openid-connect-discovery-1_0.html.
+func (o oidcOptions) tokenEndpoint(ctx context.Context) (string, error) {
+	u := strings.TrimSuffix(o.issuerURL, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var config struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+
+	status, err := o.do(req, &config)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover OIDC configuration of %s: %w", o.issuerURL, err)
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to discover OIDC configuration of %s: unexpected status %d", o.issuerURL, status)
+	}
+
+	if config.TokenEndpoint == "" {
+		return "", fmt.Errorf("OIDC configuration of %s does not include a token endpoint", o.issuerURL)
+	}
+
+	return config.TokenEndpoint, nil
+}
+
+// do sends the request and decodes the JSON response body into `v`, unless
+// empty. The status code of the response is returned.
+func (o oidcOptions) do(req *http.Request, v interface{}) (int, error) {
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if len(body) > 0 && json.Unmarshal(body, v) != nil && resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %s", body)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func oidcErrorMessage(status int, tr oidcTokenResponse) string {
+	switch {
+	case tr.Error != "" && tr.ErrorDescription != "":
+		return fmt.Sprintf("%s: %s", tr.Error, tr.ErrorDescription)
+	case tr.Error != "":
+		return tr.Error
+	default:
+		return fmt.Sprintf("unexpected status %d", status)
+	}
+}
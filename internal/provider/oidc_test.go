@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startStubIssuer starts an OIDC issuer that records the token requests it
+// receives and responds to them using `respond`.
+func startStubIssuer(t *testing.T, respond func(w http.ResponseWriter, form url.Values)) (*httptest.Server, *[]*http.Request) {
+	t.Helper()
+
+	var requests []*http.Request
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         srv.URL,
+			"token_endpoint": srv.URL + "/token",
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		requests = append(requests, r)
+
+		respond(w, r.PostForm)
+	})
+
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestOIDCOptions_tokenClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := startStubIssuer(t, func(w http.ResponseWriter, _ url.Values) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"id_token":     "id",
+			"expires_in":   3600,
+		})
+	})
+
+	before := time.Now()
+
+	token, expiry, err := oidcOptions{
+		httpClient:   srv.Client(),
+		issuerURL:    srv.URL + "/",
+		clientID:     "argocd",
+		clientSecret: "s3cr3t",
+		scopes:       []string{"openid", "groups"},
+		audience:     "argo-cd",
+	}.token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "id", token)
+	assert.WithinDuration(t, before.Add(time.Hour), expiry, time.Minute)
+
+	require.Len(t, *requests, 1)
+
+	r := (*requests)[0]
+
+	username, password, ok := r.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "argocd", username)
+	assert.Equal(t, "s3cr3t", password)
+
+	assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+	assert.Equal(t, "openid groups", r.PostForm.Get("scope"))
+	assert.Equal(t, "argo-cd", r.PostForm.Get("audience"))
+	assert.Empty(t, r.PostForm.Get("client_id"))
+}
+
+func TestOIDCOptions_tokenExchange(t *testing.T) {
+	t.Parallel()
+
+	srv, requests := startStubIssuer(t, func(w http.ResponseWriter, _ url.Values) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":      "exchanged",
+			"issued_token_type": oidcTokenTypeIDToken,
+		})
+	})
+
+	subjectTokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(subjectTokenFile, []byte("federated\n"), 0o600))
+
+	token, expiry, err := oidcOptions{
+		httpClient:       srv.Client(),
+		issuerURL:        srv.URL,
+		clientID:         "argocd",
+		subjectTokenFile: subjectTokenFile,
+	}.token(context.Background())
+	require.NoError(t, err)
+
+	// Access token is used when no ID token is returned
+	assert.Equal(t, "exchanged", token)
+	assert.True(t, expiry.IsZero())
+
+	require.Len(t, *requests, 1)
+
+	r := (*requests)[0]
+
+	_, _, ok := r.BasicAuth()
+	assert.False(t, ok)
+
+	assert.Equal(t, "urn:ietf:params:oauth:grant-type:token-exchange", r.PostForm.Get("grant_type"))
+	assert.Equal(t, "federated", r.PostForm.Get("subject_token"))
+	assert.Equal(t, "urn:ietf:params:oauth:token-type:jwt", r.PostForm.Get("subject_token_type"))
+	assert.Equal(t, "urn:ietf:params:oauth:token-type:id_token", r.PostForm.Get("requested_token_type"))
+	assert.Equal(t, "argocd", r.PostForm.Get("client_id"))
+}
+
+func TestOIDCOptions_tokenErrors(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := startStubIssuer(t, func(w http.ResponseWriter, _ url.Values) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error":             "invalid_client",
+			"error_description": "client authentication failed",
+		})
+	})
+
+	_, _, err := oidcOptions{httpClient: srv.Client(), issuerURL: srv.URL, clientID: "argocd", clientSecret: "wrong"}.token(context.Background())
+	assert.ErrorContains(t, err, "invalid_client: client authentication failed")
+
+	_, _, err = oidcOptions{httpClient: srv.Client(), issuerURL: srv.URL + "/unknown", clientID: "argocd"}.token(context.Background())
+	assert.ErrorContains(t, err, "failed to discover OIDC configuration")
+
+	_, _, err = oidcOptions{httpClient: srv.Client(), issuerURL: srv.URL, clientID: "argocd", subjectTokenFile: filepath.Join(t.TempDir(), "missing")}.token(context.Background())
+	assert.ErrorContains(t, err, "failed to read subject token")
+}
@@ -3,8 +3,10 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -12,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/oboukili/terraform-provider-argocd/internal/validators"
 )
 
 // Ensure ArgoCDProvider satisfies various provider interfaces.
@@ -53,13 +56,11 @@ func (p *ArgoCDProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			},
 			"core": schema.BoolAttribute{
 				Description: "Configure direct access using Kubernetes API server.\n\n  " +
-					"**Warning**: this feature works by starting a local ArgoCD API server that talks directly to the Kubernetes API using the **current context " +
-					"in the default kubeconfig** (`~/.kube/config`). This behavior cannot be overridden using either environment variables or the `kubernetes` block " +
-					"in the provider configuration at present).\n\n  If the server fails to start (e.g. your kubeconfig is misconfigured) then the provider will " +
-					"fail as a result of the `argocd` module forcing it to exit and no logs will be available to help you debug this. The error message will be " +
-					"similar to\n  > `The plugin encountered an error, and failed to respond to the plugin.(*GRPCProvider).ReadResource call. The plugin logs may " +
-					"contain more details.`\n\n  To debug this, you will need to login via the ArgoCD CLI using `argocd login --core` and then running an operation. " +
-					"E.g. `argocd app list`.",
+					"**Note**: this feature works by starting a local ArgoCD API server that talks directly to the Kubernetes API using the current context " +
+					"in the default kubeconfig (`~/.kube/config`, which can be overridden using the `KUBECONFIG` environment variable) or, if there is none, the " +
+					"in-cluster configuration. Both can be overridden using the `kubernetes` block.\n\n  If the server fails to start (e.g. your kubeconfig is " +
+					"misconfigured), the error is reported by the provider. To debug this further, you can login via the ArgoCD CLI using `argocd login --core` and " +
+					"then run an operation. E.g. `argocd app list`.",
 				Optional: true,
 			},
 			"server_addr": schema.StringAttribute{
@@ -86,10 +87,24 @@ func (p *ArgoCDProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Description: "Context to choose when using a local ArgoCD config file. Only relevant when `use_local_config`. Can be set through `ARGOCD_CONTEXT` environment variable.",
 				Optional:    true,
 			},
+			"cert_data": schema.StringAttribute{
+				Description: "PEM encoded additional root CA certificates to add to the client TLS connection pool. Conflicts with `cert_file`. Can be set through the `ARGOCD_CERT_DATA` environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.IsPEMCertificate(),
+				},
+			},
 			"cert_file": schema.StringAttribute{
 				Description: "Additional root CA certificates file to add to the client TLS connection pool.",
 				Optional:    true,
 			},
+			"client_cert_data": schema.StringAttribute{
+				Description: "PEM encoded client certificate. Must be specified alongside `client_key_data`. Conflicts with `client_cert_file`. Can be set through the `ARGOCD_CLIENT_CERT_DATA` environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					validators.IsPEMCertificate(),
+				},
+			},
 			"client_cert_file": schema.StringAttribute{
 				Description: "Client certificate.",
 				Optional:    true,
@@ -98,6 +113,14 @@ func (p *ArgoCDProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Description: "Client certificate key.",
 				Optional:    true,
 			},
+			"client_key_data": schema.StringAttribute{
+				Description: "PEM encoded client certificate key. Must be specified alongside `client_cert_data`. Conflicts with `client_cert_key`. Can be set through the `ARGOCD_CLIENT_KEY_DATA` environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					validators.IsPEMPrivateKey(),
+				},
+			},
 			"grpc_web": schema.BoolAttribute{
 				Description: "Whether to use gRPC web proxy client. Useful if Argo CD server is behind proxy which does not support HTTP2.",
 				Optional:    true,
@@ -115,21 +138,47 @@ func (p *ArgoCDProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Description: "Whether to skip TLS server certificate. Can be set through the `ARGOCD_INSECURE` environment variable.",
 				Optional:    true,
 			},
+			"no_proxy": schema.StringAttribute{
+				Description: "Comma-separated list of hosts, domains (e.g. `.example.com`) and CIDR ranges of ArgoCD API servers that are reached without going through the proxy. Not applied to the Kubernetes API when `proxy_url` is set. Can be set through the `NO_PROXY` (or `no_proxy`) environment variable.",
+				Optional:    true,
+			},
 			"plain_text": schema.BoolAttribute{
 				Description: "Whether to initiate an unencrypted connection to ArgoCD server.",
 				Optional:    true,
 			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the HTTP(S) or SOCKS5 proxy through which the ArgoCD API server (using either gRPC or gRPC-Web) and the OIDC issuer are reached, as well as the Kubernetes API when `core` or port forwarding is enabled, e.g. `http://proxy.local:3128`. Can be set through the `HTTPS_PROXY` (or `https_proxy`) environment variable.",
+				Optional:    true,
+			},
+			"read_cache": schema.BoolAttribute{
+				Description: "Whether to serve the reads of applications, projects, repositories and clusters performed when refreshing resources from a snapshot of all such objects, listed at most once every 30 seconds and discarded whenever the provider modifies an object of the same kind. Reduces the number of requests sent to the ArgoCD API server when managing many objects. Objects missing from a snapshot are read directly.",
+				Optional:    true,
+			},
 			"user_agent": schema.StringAttribute{
 				Description: "User-Agent request header override.",
 				Optional:    true,
 			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of requests sent concurrently to the ArgoCD API server. A quarter of this budget (and at least one request) is reserved to heavy requests (i.e. requests that generate manifests, trigger syncs or list all applications), which are limited separately. Unlimited by default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Int64Attribute{
+				Description: "Maximum number of requests sent to the ArgoCD API server per second. A quarter of this budget (and at least one request per second) is reserved to heavy requests (i.e. requests that generate manifests, trigger syncs or list all applications), which are limited separately. Unlimited by default.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"kubernetes": schema.ListNestedBlock{
 				Validators: []validator.List{
 					listvalidator.SizeAtMost(1),
 				},
-				Description: "Kubernetes configuration overrides.  Only relevant when `port_forward = true` or `port_forward_with_namespace = \"foo\"`. The kubeconfig file that is used can be overridden using the [`KUBECONFIG` environment variable](https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/#the-kubeconfig-environment-variable)).",
+				Description: "Kubernetes configuration overrides.  Only relevant when `core = true`, `port_forward = true` or `port_forward_with_namespace = \"foo\"`. The kubeconfig file that is used can be overridden using the [`KUBECONFIG` environment variable](https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/#the-kubeconfig-environment-variable)).",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"host": schema.StringAttribute{
@@ -212,6 +261,130 @@ func (p *ArgoCDProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 					},
 				},
 			},
+			"auth_exec": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				Description: "Configuration block to obtain the ArgoCD authentication token by executing an external command, e.g. to fetch a short-lived token from a secret broker. The token is renewed when it expires or, if its expiry is unknown, once rejected by the ArgoCD API server. Conflicts with `auth_token`, `oidc`, `username`, `password`, `core` and `use_local_config`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"command": schema.StringAttribute{
+							Description: "Command to execute.",
+							Required:    true,
+						},
+						"args": schema.ListAttribute{
+							Description: "Arguments to pass to the command.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"env": schema.MapAttribute{
+							Description: "Environment variables to set, in addition to those of the provider, when executing the command.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"output_format": schema.StringAttribute{
+							Description: "Format of the standard output of the command, one of `plain` (the token only) or `json` (an object holding the token in its `token` key and, optionally, its expiry time in [RFC 3339](https://datatracker.ietf.org/doc/html/rfc3339) format in its `expiration_timestamp` key). Defaults to `plain`.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(authExecOutputFormatPlain, authExecOutputFormatJSON),
+							},
+						},
+					},
+				},
+			},
+			"oidc": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				Description: "Configuration block to obtain the ArgoCD authentication token from an OIDC issuer, using the OAuth2 client credentials flow or, if `subject_token_file` is set, the token exchange flow (e.g. to exchange the federated identity token of a CI runner). The resulting ID token (or access token, if the issuer does not return an ID token) must be accepted by the OIDC configuration of the ArgoCD API server. The token is renewed before it expires. Conflicts with `auth_exec`, `auth_token`, `username`, `password`, `core` and `use_local_config`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"issuer_url": schema.StringAttribute{
+							Description: "URL of the OIDC issuer, used to discover its token endpoint.",
+							Required:    true,
+						},
+						"client_id": schema.StringAttribute{
+							Description: "OAuth2 client ID.",
+							Required:    true,
+						},
+						"client_secret": schema.StringAttribute{
+							Description: "OAuth2 client secret, sent using HTTP basic authentication.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"scopes": schema.ListAttribute{
+							Description: "Scopes to request, e.g. `openid`.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"audience": schema.StringAttribute{
+							Description: "Audience of the requested token.",
+							Optional:    true,
+						},
+						"subject_token_file": schema.StringAttribute{
+							Description: "Path to a file holding a JWT (e.g. a workload identity token) to exchange for the ArgoCD authentication token using the [OAuth 2.0 token exchange](https://datatracker.ietf.org/doc/html/rfc8693) flow. The file is read each time a token is requested.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"default_metadata": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				Description: "Configuration block of the annotations and labels merged into the `metadata` of every `argocd_application`, `argocd_application_set`, `argocd_cluster` and `argocd_project`, similarly to the `default_tags` of other providers. Annotations and labels set on a resource take precedence. Default annotations and labels are only tracked by a resource when they are set on it explicitly or when the object holds a different value, e.g. after the default value has changed. **Note**: annotations and labels added to `default_metadata` are only set on existing objects the next time these are updated.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"annotations": schema.MapAttribute{
+							Description: "Annotations merged into the annotations of every object.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								validators.MetadataAnnotations(),
+							},
+						},
+						"labels": schema.MapAttribute{
+							Description: "Labels merged into the labels of every object.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.Map{
+								validators.MetadataLabels(),
+							},
+						},
+					},
+				},
+			},
+			"retry": schema.ListNestedBlock{
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				Description: "Configuration for retrying requests that fail with a transient error, i.e. with a gRPC status code of `Unavailable`, `ResourceExhausted` or `Aborted`. Only requests that do not modify any object are retried, along with requests that failed before being sent to the server because it was unavailable. Unless configured otherwise, requests are attempted up to 3 times.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"max_attempts": schema.Int64Attribute{
+							Description: "Maximum number of attempts made for each request, including the first one. Set to `1` to disable retries. Defaults to `3`.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"initial_backoff": schema.StringAttribute{
+							Description: "Amount of time to wait before retrying a request for the first time, doubled after each subsequent attempt (e.g. `500ms`). Defaults to `1s`.",
+							Optional:    true,
+							Validators: []validator.String{
+								validators.IsDuration(),
+							},
+						},
+						"max_backoff": schema.StringAttribute{
+							Description: "Maximum amount of time to wait between two attempts (e.g. `1m`). Defaults to `30s`.",
+							Optional:    true,
+							Validators: []validator.String{
+								validators.IsDuration(),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -246,6 +419,11 @@ func (p *ArgoCDProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	// The configuration may depend on resources that are yet to be created
+	// (e.g. those installing ArgoCD), in which case the clients are only
+	// initialized at apply time.
+	config.Unknown = !req.Config.Raw.IsFullyKnown()
+
 	server := NewServerInterface(config)
 
 	resp.DataSourceData = server
@@ -254,6 +432,8 @@ func (p *ArgoCDProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 func (p *ArgoCDProvider) Resources(context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewApplicationResource,
+		NewProjectResource,
 		NewGPGKeyResource,
 	}
 }
@@ -261,5 +441,6 @@ func (p *ArgoCDProvider) Resources(context.Context) []func() resource.Resource {
 func (p *ArgoCDProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewArgoCDApplicationDataSource,
+		NewArgoCDApplicationEventsDataSource,
 	}
 }
@@ -1,14 +1,21 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -38,6 +45,72 @@ func TestProvider_headers(t *testing.T) {
 	})
 }
 
+// nullObjectValue returns an object of type `typ` whose attributes are null,
+// unless set in `values`.
+func nullObjectValue(typ tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+
+	for name, t := range typ.AttributeTypes {
+		if v, ok := values[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = tftypes.NewValue(t, nil)
+		}
+	}
+
+	return tftypes.NewValue(typ, vals)
+}
+
+func TestProvider_ConfigureUnknown(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := New("test")
+
+	sr := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, sr)
+
+	// e.g. the address of an ArgoCD API server installed in the same apply
+	config := tfsdk.Config{
+		Schema: sr.Schema,
+		Raw: nullObjectValue(sr.Schema.Type().TerraformType(ctx).(tftypes.Object), map[string]tftypes.Value{
+			"server_addr": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"auth_token":  tftypes.NewValue(tftypes.String, "foo"),
+		}),
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	si, ok := resp.ResourceData.(*ServerInterface)
+	require.True(t, ok)
+	assert.False(t, si.IsConfigKnown())
+
+	diags := si.InitClients(ctx)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Unknown provider configuration", diags.Errors()[0].Summary())
+
+	// Resources retain their prior state rather than being refreshed
+	r := &gpgKeyResource{si: si}
+
+	rsr := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, rsr)
+
+	state := tfsdk.State{
+		Schema: rsr.Schema,
+		Raw: nullObjectValue(rsr.Schema.Type().TerraformType(ctx).(tftypes.Object), map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "ABCDEF0123456789"),
+		}),
+	}
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.Equal(state.Raw))
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("ARGOCD_AUTH_USERNAME"); v == "" {
 		t.Fatal("ARGOCD_AUTH_USERNAME must be set for acceptance tests")
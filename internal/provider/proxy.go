@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+// proxyOptions configure the proxy through which the ArgoCD API server is
+// reached.
+type proxyOptions struct {
+	url     *url.URL
+	noProxy string
+}
+
+// proxyFunc returns the proxy to use for a request, or nil if the request
+// should not be sent through the proxy (see `httpproxy.Config`).
+func (p *proxyOptions) proxyFunc() func(*url.URL) (*url.URL, error) {
+	return (&httpproxy.Config{
+		HTTPProxy:  p.url.String(),
+		HTTPSProxy: p.url.String(),
+		NoProxy:    p.noProxy,
+	}).ProxyFunc()
+}
+
+// requestProxyFunc returns the proxy to use for an HTTP request, see
+// proxyFunc.
+func (p *proxyOptions) requestProxyFunc() func(*http.Request) (*url.URL, error) {
+	proxyFunc := p.proxyFunc()
+
+	return func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+}
+
+// newHTTPClient returns an HTTP client sending requests through `p`, if set,
+// and otherwise through the proxy configured using environment variables, if
+// any.
+func newHTTPClient(p *proxyOptions) *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if p != nil {
+		t.Proxy = p.requestProxyFunc()
+	}
+
+	return &http.Client{Transport: t}
+}
+
+// dialContext establishes a connection to `addr` through the proxy, unless
+// it is excluded by `no_proxy`.
+func (p *proxyOptions) dialContext(ctx context.Context, addr string) (net.Conn, error) {
+	proxyURL, err := p.proxyFunc()(&url.URL{Scheme: "https", Host: addr})
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL == nil {
+		var nd net.Dialer
+
+		return nd.DialContext(ctx, "tcp", addr)
+	}
+
+	return dialThroughProxy(ctx, proxyURL, addr)
+}
+
+// dialThroughProxy establishes a connection to `addr` through an HTTP(S)
+// proxy, using the CONNECT method, or through a SOCKS5 proxy.
+func dialThroughProxy(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		d, err := proxy.FromURL(proxyURL, proxy.Direct)
+		if err != nil {
+			return nil, err
+		}
+
+		return d.(proxy.ContextDialer).DialContext(ctx, "tcp", addr)
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), map[string]string{"http": "80", "https": "443"}[proxyURL.Scheme])
+	}
+
+	var nd net.Dialer
+
+	conn, err := nd.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Redacted(), err)
+	}
+
+	if proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Redacted(), err)
+		}
+
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() {
+			_ = conn.SetDeadline(time.Time{})
+		}()
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+
+	if u := proxyURL.User; u != nil {
+		password, _ := u.Password()
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(u.Username()+":"+password)))
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT request to proxy %s: %w", proxyURL.Redacted(), err)
+	}
+
+	br := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy %s: %w", proxyURL.Redacted(), err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", proxyURL.Redacted(), addr, resp.Status)
+	}
+
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes have already been read into
+// a buffer.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/cluster"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+)
+
+// readCacheTTL is the maximum age of the snapshots served by the read cache.
+const readCacheTTL = 30 * time.Second
+
+// snapshot holds the list of all objects of a given kind, as returned by the
+// ArgoCD API server.
+type snapshot[T any] struct {
+	// refresh serializes the (expensive) listing of the objects so that
+	// concurrent reads share a single request.
+	refresh sync.Mutex
+
+	mu         sync.Mutex
+	items      []T
+	takenAt    time.Time
+	generation uint64
+}
+
+// get returns the snapshotted objects, listing them using `list` if the
+// snapshot is missing or older than `ttl`.
+func (s *snapshot[T]) get(ctx context.Context, now func() time.Time, ttl time.Duration, list func(context.Context) ([]T, error)) ([]T, error) {
+	s.refresh.Lock()
+	defer s.refresh.Unlock()
+
+	s.mu.Lock()
+	if s.items != nil && now().Sub(s.takenAt) < ttl {
+		items := s.items
+		s.mu.Unlock()
+
+		return items, nil
+	}
+
+	generation := s.generation
+	s.mu.Unlock()
+
+	items, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Discard the objects if the snapshot has been invalidated in the
+	// meantime as they may predate the modification.
+	if s.generation == generation {
+		s.items = items
+		s.takenAt = now()
+	}
+
+	return items, nil
+}
+
+func (s *snapshot[T]) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+	s.items = nil
+}
+
+// readCache serves reads of applications, projects, repositories and clusters
+// from snapshots of all such objects so that refreshing many resources does
+// not require a request per resource. Snapshots are discarded whenever an
+// object of the same kind is modified through the provider.
+type readCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	applications snapshot[v1alpha1.Application]
+	clusters     snapshot[v1alpha1.Cluster]
+	projects     snapshot[v1alpha1.AppProject]
+	repositories snapshot[*v1alpha1.Repository]
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// invalidate discards the snapshot of the objects served by the given gRPC
+// service (e.g. `application.ApplicationService`), if any.
+func (c *readCache) invalidate(service string) {
+	switch service {
+	case "application.ApplicationService":
+		c.applications.invalidate()
+	case "cluster.ClusterService":
+		c.clusters.invalidate()
+	case "project.ProjectService":
+		c.projects.invalidate()
+	case "repository.RepositoryService":
+		c.repositories.invalidate()
+	}
+}
+
+// isReadOnlyMethod returns whether the gRPC method `name` (e.g. `List`) does
+// not modify any object.
+func isReadOnlyMethod(name string) bool {
+	for _, p := range []string{"Get", "List", "Watch", "CanI", "Version"} {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readCacheUnaryClientInterceptor returns a gRPC client interceptor that
+// invalidates the snapshots of the read cache following any request that may
+// have modified the objects they hold.
+func readCacheUnaryClientInterceptor(c *readCache) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+		if isReadOnlyMethod(name) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		// Invalidate once the request has completed, regardless of its
+		// outcome, so that no snapshot taken in the meantime is retained.
+		defer c.invalidate(service)
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// CachedApplication returns the application with the given name and namespace
+// (any namespace if empty) from the read cache. The returned boolean is false
+// if the read cache is disabled or the application could not be found in it, in
+// which case the application should be read directly.
+func (si *ServerInterface) CachedApplication(ctx context.Context, name, namespace string) (*v1alpha1.Application, bool) {
+	if si.readCache == nil {
+		return nil, false
+	}
+
+	apps, err := si.readCache.applications.get(ctx, si.readCache.now, si.readCache.ttl, func(ctx context.Context) ([]v1alpha1.Application, error) {
+		l, err := si.ApplicationClient.List(ctx, &application.ApplicationQuery{})
+		if err != nil {
+			return nil, err
+		}
+
+		return l.Items, nil
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to list applications for read cache: %s", err))
+		return nil, false
+	}
+
+	var found *v1alpha1.Application
+
+	for i := range apps {
+		if apps[i].Name != name || (namespace != "" && apps[i].Namespace != namespace) {
+			continue
+		}
+
+		// Let the direct read report the ambiguity
+		if found != nil {
+			return nil, false
+		}
+
+		found = &apps[i]
+	}
+
+	if found == nil {
+		return nil, false
+	}
+
+	return found.DeepCopy(), true
+}
+
+// CachedCluster returns the cluster with the given server address or, if
+// empty, name from the read cache. The returned boolean is false if the read
+// cache is disabled or the cluster could not be found in it, in which case the
+// cluster should be read directly.
+func (si *ServerInterface) CachedCluster(ctx context.Context, server, name string) (*v1alpha1.Cluster, bool) {
+	if si.readCache == nil {
+		return nil, false
+	}
+
+	clusters, err := si.readCache.clusters.get(ctx, si.readCache.now, si.readCache.ttl, func(ctx context.Context) ([]v1alpha1.Cluster, error) {
+		l, err := si.ClusterClient.List(ctx, &cluster.ClusterQuery{})
+		if err != nil {
+			return nil, err
+		}
+
+		return l.Items, nil
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to list clusters for read cache: %s", err))
+		return nil, false
+	}
+
+	for i := range clusters {
+		if (server != "" && clusters[i].Server == server) || (server == "" && clusters[i].Name == name) {
+			return clusters[i].DeepCopy(), true
+		}
+	}
+
+	return nil, false
+}
+
+// CachedProject returns the project with the given name from the read cache.
+// The returned boolean is false if the read cache is disabled or the project
+// could not be found in it, in which case the project should be read directly.
+func (si *ServerInterface) CachedProject(ctx context.Context, name string) (*v1alpha1.AppProject, bool) {
+	if si.readCache == nil {
+		return nil, false
+	}
+
+	projects, err := si.readCache.projects.get(ctx, si.readCache.now, si.readCache.ttl, func(ctx context.Context) ([]v1alpha1.AppProject, error) {
+		l, err := si.ProjectClient.List(ctx, &project.ProjectQuery{})
+		if err != nil {
+			return nil, err
+		}
+
+		// Unlike `Get`, `List` does not normalize the tokens of the projects
+		for i := range l.Items {
+			l.Items[i].NormalizeJWTTokens()
+		}
+
+		return l.Items, nil
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to list projects for read cache: %s", err))
+		return nil, false
+	}
+
+	for i := range projects {
+		if projects[i].Name == name {
+			return projects[i].DeepCopy(), true
+		}
+	}
+
+	return nil, false
+}
+
+// CachedRepository returns the repository with the given URL from the read
+// cache. The returned boolean is false if the read cache is disabled or the
+// repository could not be found in it, in which case the repository should be
+// read directly.
+//
+// Note: unlike `Get`, `ListRepositories` does not return the GitHub App
+// settings of repositories. The snapshot is also listed without forcing a
+// refresh, so that it does not make the server test the connection to every
+// repository; the connection state of the returned repository is therefore the
+// one last cached by the server.
+func (si *ServerInterface) CachedRepository(ctx context.Context, repo string) (*v1alpha1.Repository, bool) {
+	if si.readCache == nil {
+		return nil, false
+	}
+
+	repos, err := si.readCache.repositories.get(ctx, si.readCache.now, si.readCache.ttl, func(ctx context.Context) ([]*v1alpha1.Repository, error) {
+		l, err := si.RepositoryClient.ListRepositories(ctx, &repository.RepoQuery{})
+		if err != nil {
+			return nil, err
+		}
+
+		return l.Items, nil
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("failed to list repositories for read cache: %s", err))
+		return nil, false
+	}
+
+	for _, r := range repos {
+		if r != nil && r.Repo == repo {
+			return r.DeepCopy(), true
+		}
+	}
+
+	return nil, false
+}
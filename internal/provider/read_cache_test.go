@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSnapshot_get(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+
+	list := func(context.Context) ([]string, error) {
+		calls++
+		return []string{"foo"}, nil
+	}
+
+	clock := time.Now()
+	now := func() time.Time { return clock }
+
+	var s snapshot[string]
+
+	items, err := s.get(context.Background(), now, time.Minute, list)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, items)
+	assert.Equal(t, 1, calls)
+
+	// Served from the snapshot
+	_, err = s.get(context.Background(), now, time.Minute, list)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Expired
+	clock = clock.Add(time.Minute)
+
+	_, err = s.get(context.Background(), now, time.Minute, list)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Invalidated
+	s.invalidate()
+
+	_, err = s.get(context.Background(), now, time.Minute, list)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestSnapshot_getInvalidatedWhileListing(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s     snapshot[string]
+		calls int
+	)
+
+	list := func(context.Context) ([]string, error) {
+		calls++
+
+		// Mimic a concurrent modification
+		s.invalidate()
+
+		return []string{"foo"}, nil
+	}
+
+	items, err := s.get(context.Background(), time.Now, time.Minute, list)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, items)
+
+	// The listed items are not retained as they may predate the modification
+	_, err = s.get(context.Background(), time.Now, time.Minute, list)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestReadCacheUnaryClientInterceptor(t *testing.T) {
+	t.Parallel()
+
+	c := newReadCache(time.Minute)
+	interceptor := readCacheUnaryClientInterceptor(c)
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Internal, "boom")
+	}
+
+	list := func(context.Context) ([]v1alpha1.AppProject, error) {
+		return []v1alpha1.AppProject{}, nil
+	}
+
+	snapshotted := func() bool {
+		c.projects.mu.Lock()
+		defer c.projects.mu.Unlock()
+
+		return c.projects.items != nil
+	}
+
+	_, err := c.projects.get(context.Background(), c.now, c.ttl, list)
+	require.NoError(t, err)
+
+	// Reads and modifications of other kinds of objects are ignored
+	for _, method := range []string{"/project.ProjectService/Get", "/project.ProjectService/List", "/project.ProjectService/GetSyncWindowsState", "/application.ApplicationService/Update"} {
+		_ = interceptor(context.Background(), method, nil, nil, nil, invoker)
+		assert.True(t, snapshotted(), method)
+	}
+
+	// Modifications invalidate the snapshot, even if they fail
+	err = interceptor(context.Background(), "/project.ProjectService/Update", nil, nil, nil, invoker)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.False(t, snapshotted())
+}
+
+// fakeProjectServer serves the given projects.
+type fakeProjectServer struct {
+	project.UnimplementedProjectServiceServer
+
+	projects  []v1alpha1.AppProject
+	listCalls atomic.Int32
+}
+
+func (s *fakeProjectServer) List(context.Context, *project.ProjectQuery) (*v1alpha1.AppProjectList, error) {
+	s.listCalls.Add(1)
+
+	return &v1alpha1.AppProjectList{Items: s.projects}, nil
+}
+
+func (s *fakeProjectServer) Update(_ context.Context, req *project.ProjectUpdateRequest) (*v1alpha1.AppProject, error) {
+	return req.Project, nil
+}
+
+func TestServerInterface_CachedProject(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeProjectServer{
+		projects: []v1alpha1.AppProject{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+		},
+	}
+
+	si := NewServerInterface(ArgoCDProviderConfig{})
+	si.readCache = newReadCache(time.Minute)
+
+	conn := startFakeServer(t, func(s *grpc.Server) {
+		project.RegisterProjectServiceServer(s, srv)
+	}, grpc.WithUnaryInterceptor(readCacheUnaryClientInterceptor(si.readCache)))
+
+	si.ProjectClient = project.NewProjectServiceClient(conn)
+
+	p, ok := si.CachedProject(context.Background(), "foo")
+	require.True(t, ok)
+	assert.Equal(t, "foo", p.Name)
+
+	p, ok = si.CachedProject(context.Background(), "bar")
+	require.True(t, ok)
+	assert.Equal(t, "bar", p.Name)
+
+	_, ok = si.CachedProject(context.Background(), "baz")
+	assert.False(t, ok)
+
+	assert.Equal(t, int32(1), srv.listCalls.Load())
+
+	// Modifying the returned project does not affect the snapshot
+	p.Name = "qux"
+
+	_, ok = si.CachedProject(context.Background(), "bar")
+	assert.True(t, ok)
+
+	// Projects are listed again following a modification
+	_, err := si.ProjectClient.Update(context.Background(), &project.ProjectUpdateRequest{Project: p})
+	require.NoError(t, err)
+
+	_, ok = si.CachedProject(context.Background(), "foo")
+	assert.True(t, ok)
+	assert.Equal(t, int32(2), srv.listCalls.Load())
+}
+
+func TestServerInterface_CachedProjectDisabled(t *testing.T) {
+	t.Parallel()
+
+	_, ok := NewServerInterface(ArgoCDProviderConfig{}).CachedProject(context.Background(), "foo")
+	assert.False(t, ok)
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+// heavyRequestsShare is the fraction (i.e. one in heavyRequestsShare) of the
+// configured request budget that is allocated to heavy requests. Heavy
+// requests are limited separately from light ones so that they cannot starve
+// the latter.
+const heavyRequestsShare = 4
+
+// heavyMethods are the ArgoCD API methods that are expensive for the server to
+// serve, e.g. because they require manifests to be generated by the repo
+// server.
+var heavyMethods = map[string]bool{
+	"/application.ApplicationService/GetManifests": true,
+	"/application.ApplicationService/Sync":         true,
+}
+
+type requestLimitOptions struct {
+	maxConcurrentRequests int64
+	requestsPerSecond     int64
+}
+
+func (o requestLimitOptions) enabled() bool {
+	return o.maxConcurrentRequests > 0 || o.requestsPerSecond > 0
+}
+
+// heavy returns the share of the budget allocated to heavy requests.
+func (o requestLimitOptions) heavy() requestLimitOptions {
+	share := func(v int64) int64 {
+		if v <= 0 {
+			return v
+		}
+
+		if s := v / heavyRequestsShare; s > 1 {
+			return s
+		}
+
+		return 1
+	}
+
+	return requestLimitOptions{
+		maxConcurrentRequests: share(o.maxConcurrentRequests),
+		requestsPerSecond:     share(o.requestsPerSecond),
+	}
+}
+
+// isHeavyRequest returns whether the request to `method` is expensive for the
+// server to serve.
+func isHeavyRequest(method string, req interface{}) bool {
+	if heavyMethods[method] {
+		return true
+	}
+
+	// Listing applications without filtering on their name returns all
+	// applications known to the server.
+	if q, ok := req.(*application.ApplicationQuery); ok && method == "/application.ApplicationService/List" {
+		return q.GetName() == ""
+	}
+
+	return false
+}
+
+// requestLimiter limits the number of concurrent requests and the rate at
+// which requests are sent. A nil semaphore or rate limiter means no limit.
+type requestLimiter struct {
+	concurrency *semaphore.Weighted
+	rate        *rate.Limiter
+}
+
+func newRequestLimiter(o requestLimitOptions) *requestLimiter {
+	l := &requestLimiter{}
+
+	if o.maxConcurrentRequests > 0 {
+		l.concurrency = semaphore.NewWeighted(o.maxConcurrentRequests)
+	}
+
+	if o.requestsPerSecond > 0 {
+		l.rate = rate.NewLimiter(rate.Limit(o.requestsPerSecond), int(o.requestsPerSecond))
+	}
+
+	return l
+}
+
+// acquire waits until a request can be sent and returns a function to be
+// called once the request has completed.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.concurrency == nil {
+		return func() {}, nil
+	}
+
+	if err := l.concurrency.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	return func() { l.concurrency.Release(1) }, nil
+}
+
+// requestLimitUnaryClientInterceptor returns a gRPC client interceptor that
+// limits the number of concurrent requests and the rate at which requests are
+// sent, with separate budgets for heavy and light requests.
+func requestLimitUnaryClientInterceptor(o requestLimitOptions) grpc.UnaryClientInterceptor {
+	light := newRequestLimiter(o)
+	heavy := newRequestLimiter(o.heavy())
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		l := light
+		if isHeavyRequest(method, req) {
+			l = heavy
+		}
+
+		release, err := l.acquire(ctx)
+		if err != nil {
+			return err
+		}
+
+		defer release()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
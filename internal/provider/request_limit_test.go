@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsHeavyRequest(t *testing.T) {
+	t.Parallel()
+
+	name := "foo"
+	blank := ""
+
+	assert.True(t, isHeavyRequest("/application.ApplicationService/Sync", &application.ApplicationSyncRequest{Name: &name}))
+	assert.True(t, isHeavyRequest("/application.ApplicationService/GetManifests", &application.ApplicationManifestQuery{Name: &name}))
+	assert.True(t, isHeavyRequest("/application.ApplicationService/List", &application.ApplicationQuery{}))
+	assert.True(t, isHeavyRequest("/application.ApplicationService/List", &application.ApplicationQuery{Name: &blank}))
+	assert.False(t, isHeavyRequest("/application.ApplicationService/List", &application.ApplicationQuery{Name: &name}))
+	assert.False(t, isHeavyRequest("/application.ApplicationService/Get", &application.ApplicationQuery{Name: &name}))
+	assert.False(t, isHeavyRequest("/version.VersionService/Version", &empty.Empty{}))
+}
+
+func TestRequestLimitOptions_heavy(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, requestLimitOptions{maxConcurrentRequests: 5, requestsPerSecond: 1}, requestLimitOptions{maxConcurrentRequests: 20, requestsPerSecond: 2}.heavy())
+	assert.Equal(t, requestLimitOptions{maxConcurrentRequests: 1, requestsPerSecond: 0}, requestLimitOptions{maxConcurrentRequests: 1}.heavy())
+}
+
+// concurrencyTracker is a gRPC invoker recording the maximum number of
+// concurrent invocations, each of which lasts `duration`.
+type concurrencyTracker struct {
+	duration time.Duration
+	current  atomic.Int32
+	max      atomic.Int32
+}
+
+func (c *concurrencyTracker) invoke(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+	n := c.current.Add(1)
+	defer c.current.Add(-1)
+
+	for {
+		m := c.max.Load()
+		if n <= m || c.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+
+	time.Sleep(c.duration)
+
+	return nil
+}
+
+func TestRequestLimitUnaryClientInterceptor_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	interceptor := requestLimitUnaryClientInterceptor(requestLimitOptions{maxConcurrentRequests: 8})
+
+	name := "foo"
+	light := &concurrencyTracker{duration: 20 * time.Millisecond}
+	heavy := &concurrencyTracker{duration: 20 * time.Millisecond}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 32; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			err := interceptor(context.Background(), "/application.ApplicationService/Get", &application.ApplicationQuery{Name: &name}, nil, nil, light.invoke)
+			assert.NoError(t, err)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			err := interceptor(context.Background(), "/application.ApplicationService/Sync", &application.ApplicationSyncRequest{Name: &name}, nil, nil, heavy.invoke)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.LessOrEqual(t, light.max.Load(), int32(8))
+	assert.LessOrEqual(t, heavy.max.Load(), int32(2))
+
+	// Light requests are not held back by heavy ones
+	assert.Greater(t, light.max.Load(), int32(2))
+}
+
+func TestRequestLimitUnaryClientInterceptor_Rate(t *testing.T) {
+	t.Parallel()
+
+	interceptor := requestLimitUnaryClientInterceptor(requestLimitOptions{requestsPerSecond: 20})
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return nil
+	}
+
+	start := time.Now()
+
+	// The first 20 requests use the burst, the next 10 are spread over half a
+	// second.
+	for i := 0; i < 30; i++ {
+		require.NoError(t, interceptor(context.Background(), "/version.VersionService/Version", &empty.Empty{}, nil, nil, invoker))
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestRequestLimitUnaryClientInterceptor_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	interceptor := requestLimitUnaryClientInterceptor(requestLimitOptions{maxConcurrentRequests: 1})
+
+	block := make(chan struct{})
+	blocking := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		<-block
+		return nil
+	}
+
+	done := make(chan error)
+
+	go func() {
+		done <- interceptor(context.Background(), "/version.VersionService/Version", &empty.Empty{}, nil, nil, blocking)
+	}()
+
+	// Wait for the first request to hold the only slot
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := interceptor(ctx, "/version.VersionService/Version", &empty.Empty{}, nil, nil, blocking)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(block)
+	assert.NoError(t, <-done)
+}
+
+func TestRequestLimitUnaryClientInterceptor_WithRetries(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeVersionServer{code: codes.Unavailable, failures: 2}
+	conn := startFakeVersionServer(t, srv, grpc.WithChainUnaryInterceptor(
+		retryUnaryClientInterceptor(retryOptions{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond}),
+		requestLimitUnaryClientInterceptor(requestLimitOptions{maxConcurrentRequests: 1, requestsPerSecond: 100}),
+	))
+
+	_, err := version.NewVersionServiceClient(conn).Version(context.Background(), &empty.Empty{})
+
+	assert.Equal(t, codes.OK, status.Code(err))
+	assert.Equal(t, int32(3), srv.calls.Load())
+}
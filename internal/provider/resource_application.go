@@ -0,0 +1,889 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/gitops-engine/pkg/health"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
+	customtypes "github.com/oboukili/terraform-provider-argocd/internal/types"
+	"github.com/oboukili/terraform-provider-argocd/internal/utils"
+	"github.com/oboukili/terraform-provider-argocd/internal/validators"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// applicationDefaultTimeout is the default timeout for creating, updating
+	// and deleting applications (including any wait).
+	applicationDefaultTimeout = 5 * time.Minute
+
+	// applicationWaitMaxWarningEvents is the maximum number of warning events
+	// included in diagnostics when waiting for an application fails.
+	applicationWaitMaxWarningEvents = 5
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                 = &applicationResource{}
+	_ resource.ResourceWithImportState  = &applicationResource{}
+	_ resource.ResourceWithModifyPlan   = &applicationResource{}
+	_ resource.ResourceWithUpgradeState = &applicationResource{}
+)
+
+func NewApplicationResource() resource.Resource {
+	return &applicationResource{}
+}
+
+// applicationResource defines the resource implementation.
+type applicationResource struct {
+	si *ServerInterface
+}
+
+func (r *applicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+func (r *applicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages [applications](https://argo-cd.readthedocs.io/en/stable/operator-manual/declarative-setup/#applications) within ArgoCD.",
+		Version:             5,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ArgoCD application identifier, in the format `<name>:<namespace>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"spec_override_json": schema.StringAttribute{
+				MarkdownDescription: "JSON encoded (partial) [ApplicationSpec](https://argo-cd.readthedocs.io/en/stable/operator-manual/declarative-setup/#applications), typically set using `jsonencode()`, that is strategically merged into the application spec built from `spec` before the application is created or updated. Only the keys set in the override are read back from ArgoCD. This allows fields that are not (yet) exposed in `spec`, including fields unknown to the ArgoCD API client bundled with the provider, to be managed. When set, the application is sent to the REST API of the ArgoCD API server rather than the gRPC API, so that these fields are passed through as is. **Note**: fields that are also managed through `spec` should not be overridden as this will result in perpetual diffs. Lists are compared as a whole.",
+				CustomType:          customtypes.JSONStringType,
+				Optional:            true,
+				Validators: []validator.String{
+					validators.IsJSONObject(),
+				},
+			},
+			"status": applicationStatusBlockSchemaAttribute(),
+			"cascade": schema.BoolAttribute{
+				MarkdownDescription: "Whether to applying cascading deletion when application is removed.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"wait": schema.BoolAttribute{
+				MarkdownDescription: "Upon application creation or update, wait for application health/sync status to be healthy/Synced, upon application deletion, wait for application to be removed, when set to true. Wait timeouts are controlled by the `timeouts` block (all default to 5 minutes). **Note**: if the application cannot be synced because a `sync_window` of the project to which the application belongs is currently blocking automated syncs, then the wait will fail immediately unless `wait_through_sync_windows = true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"wait_through_sync_windows": schema.BoolAttribute{
+				MarkdownDescription: "When `wait = true` and the application is currently blocked from syncing by one or more project sync windows, wait for the sync window(s) to close rather than failing immediately. **Note**: waiting remains bounded by the create and update timeouts, which should be increased to cover the duration of the sync window(s).",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"metadata": objectMetaSchemaBlock("applications.argoproj.io"),
+			"spec":     applicationSpecSchemaBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *applicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	si, ok := req.ProviderData.(*ServerInterface)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *ServerInterface, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.si = si
+}
+
+// ModifyPlan validates the metadata of the planned application once merged
+// with the default metadata of the provider, and checks the attributes guarded
+// by features, as well as the namespace of the application, against the server
+// so that unsupported ones are reported at plan time rather than halfway
+// through an apply.
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePlannedMetadata(ctx, r.si, req.Plan, path.Root("metadata").AtListIndex(0))...)
+	resp.Diagnostics.Append(checkFeatures(ctx, r.si, "argocd_application", req.Config)...)
+	resp.Diagnostics.Append(checkApplicationNamespace(ctx, r.si, req.Config)...)
+}
+
+func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data applicationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Create(ctx, applicationDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	app, diags := data.toApplication()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app.ObjectMeta = r.si.withDefaultMetadata(app.ObjectMeta)
+
+	resp.Diagnostics.Append(checkFeatures(ctx, r.si, "argocd_application", req.Config)...)
+	resp.Diagnostics.Append(checkApplicationNamespace(ctx, r.si, req.Config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apps, err := r.si.ApplicationClient.List(ctx, &application.ApplicationQuery{
+		Name:         &app.Name,
+		AppNamespace: &app.Namespace,
+	})
+	if err != nil && !diagnostics.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("failed to list existing applications when creating application %s", app.Name), err)...)
+		return
+	}
+
+	if apps != nil {
+		switch l := len(apps.Items); {
+		case l == 1 && apps.Items[0].DeletionTimestamp != nil:
+			// Pre-existing app is still in Kubernetes soft deletion queue
+			if p := apps.Items[0].DeletionGracePeriodSeconds; p != nil {
+				select {
+				case <-ctx.Done():
+					resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("interrupted while waiting for the deletion of the existing application %s", app.Name), ctx.Err())...)
+					return
+				case <-time.After(time.Duration(*p) * time.Second):
+				}
+			}
+		case l > 1:
+			resp.Diagnostics.AddError(fmt.Sprintf("found multiple applications matching name '%s' and namespace '%s'", app.Name, app.Namespace), "")
+			return
+		}
+	}
+
+	var created *v1alpha1.Application
+
+	if data.SpecOverrideJSON.IsNull() {
+		created, err = r.si.ApplicationClient.Create(ctx, &application.ApplicationCreateRequest{
+			Application: app,
+		})
+	} else {
+		body, diags := data.applicationJSON(app)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		created, err = r.si.createApplicationJSON(ctx, body)
+	}
+
+	if err != nil {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("create", "application", app.Name, err)...)
+		return
+	} else if created == nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("application %s could not be created: unknown reason", app.Name), "")
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created application %s", created.Name))
+
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", created.Name, created.Namespace))
+
+	if data.Wait.ValueBool() {
+		resp.Diagnostics.Append(waitForApplication(ctx, r.si, created.Name, created.Namespace, nil, timeout, data.WaitThroughSyncWindows.ValueBool(), "created")...)
+	}
+
+	// State is saved even if waiting failed so that the application is not
+	// leaked (Terraform will mark the resource as tainted).
+	resp.Diagnostics.Append(readAppliedApplication(ctx, r.si, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data applicationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	// The prior state is retained until the provider configuration is known
+	if !r.si.IsConfigKnown() {
+		tflog.Debug(ctx, fmt.Sprintf("provider configuration is unknown, skipping refresh of %s", data.ID.ValueString()))
+		return
+	}
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, namespace := applicationIDParts(data.ID.ValueString())
+
+	app, ok := r.si.CachedApplication(ctx, name, namespace)
+	if !ok {
+		var diags diag.Diagnostics
+
+		app, diags = getApplication(ctx, r.si, name, namespace)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if app == nil {
+		tflog.Trace(ctx, fmt.Sprintf("application %s no longer exists, removing from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	// The prior metadata and spec are empty following an import.
+	var priorMetadata objectMeta
+	if m := blockValue(data.Metadata); m != nil {
+		priorMetadata = *m
+	}
+
+	priorSpec := blockValue(data.Spec).applicationSpec()
+
+	// Default annotations and labels, as well as internal ones (e.g. those set
+	// by ArgoCD notifications), are only tracked if they are explicitly
+	// managed.
+	metadata := newObjectMeta(r.si.withoutDefaultMetadata(app.ObjectMeta, priorMetadata))
+
+	metadata.Annotations = metadataRemoveInternalKeys(metadata.Annotations, priorMetadata.Annotations)
+	metadata.Labels = metadataRemoveInternalKeys(metadata.Labels, priorMetadata.Labels)
+
+	// Distinguish values that are omitted by ArgoCD from those that have been
+	// explicitly set to their zero value.
+	spec := newApplicationSpec(app.Spec)
+
+	utils.ReconcileWithPriorState(&metadata, &priorMetadata)
+	utils.ReconcileWithPriorState(spec, priorSpec)
+
+	if !data.SpecOverrideJSON.IsNull() {
+		// Fields unknown to the bundled ArgoCD API client can only be read
+		// back through the REST API.
+		rawSpec, err := r.si.getApplicationSpecJSON(ctx, app.Name, app.Namespace)
+		if err != nil {
+			resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("read", "application", name, err)...)
+			return
+		}
+
+		override, err := projectApplicationSpecOverride(rawSpec, data.SpecOverrideJSON.ValueJSONString())
+		if err != nil {
+			resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("failed to read spec_override_json of application %s", name), err)...)
+			return
+		}
+
+		data.SpecOverrideJSON = customtypes.JSONStringValue(override)
+	}
+
+	status, diags := newApplicationStatusList(ctx, app.Status)
+	resp.Diagnostics.Append(diags...)
+
+	data.ID = types.StringValue(fmt.Sprintf("%s:%s", app.Name, app.Namespace))
+	data.Metadata = []objectMeta{metadata}
+	data.Spec = blockList(newApplicationSpecBlock(spec))
+	data.Status = status
+
+	// Attributes that only affect the behaviour of the provider are not set
+	// following an import.
+	if data.Cascade.IsNull() {
+		data.Cascade = types.BoolValue(true)
+	}
+
+	if data.Wait.IsNull() {
+		data.Wait = types.BoolValue(false)
+	}
+
+	if data.WaitThroughSyncWindows.IsNull() {
+		data.WaitThroughSyncWindows = types.BoolValue(false)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data applicationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Update(ctx, applicationDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	app, diags := data.toApplication()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkFeatures(ctx, r.si, "argocd_application", req.Config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, namespace := applicationIDParts(data.ID.ValueString())
+
+	apps, err := r.si.ApplicationClient.List(ctx, &application.ApplicationQuery{
+		Name:         &name,
+		AppNamespace: &namespace,
+	})
+	if err != nil {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("read", "application", name, err)...)
+		return
+	}
+
+	if len(apps.Items) != 1 {
+		resp.Diagnostics.AddError(fmt.Sprintf("found unexpected number of applications matching name '%s' and namespace '%s'. Items: %d", name, namespace, len(apps.Items)), "")
+		return
+	}
+
+	// Changes to `wait`, `cascade` etc. only affect the provider so there is
+	// no need to update the application in that case.
+	var state applicationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A state without spec (which should not happen) is treated as requiring
+	// an update rather than failing it.
+	previous, _ := state.toApplication()
+
+	// Both are merged with the default metadata so that changes to the latter
+	// are applied, even though they are not tracked.
+	app.ObjectMeta = r.si.withDefaultMetadata(app.ObjectMeta)
+
+	if previous != nil {
+		previous.ObjectMeta = r.si.withDefaultMetadata(previous.ObjectMeta)
+	}
+
+	if !resp.Diagnostics.HasError() && (applicationNeedsUpdate(previous, app) || !data.SpecOverrideJSON.Equal(state.SpecOverrideJSON)) {
+		if data.SpecOverrideJSON.IsNull() {
+			_, err = r.si.ApplicationClient.Update(ctx, &application.ApplicationUpdateRequest{
+				Application: app,
+			})
+		} else {
+			body, diags := data.applicationJSON(app)
+			resp.Diagnostics.Append(diags...)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			err = r.si.updateApplicationJSON(ctx, app.Name, body)
+		}
+
+		if err != nil {
+			resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("update", "application", name, err)...)
+			return
+		}
+
+		tflog.Trace(ctx, fmt.Sprintf("updated application %s", name))
+
+		if data.Wait.ValueBool() {
+			reconciledAt := apps.Items[0].Status.ReconciledAt
+			resp.Diagnostics.Append(waitForApplication(ctx, r.si, name, namespace, reconciledAt, timeout, data.WaitThroughSyncWindows.ValueBool(), "updated")...)
+		}
+	}
+
+	resp.Diagnostics.Append(readAppliedApplication(ctx, r.si, &data)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data applicationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := data.Timeouts.Delete(ctx, applicationDefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, namespace := applicationIDParts(data.ID.ValueString())
+	cascade := data.Cascade.ValueBool()
+
+	if _, err := r.si.ApplicationClient.Delete(ctx, &application.ApplicationDeleteRequest{
+		Name:         &name,
+		Cascade:      &cascade,
+		AppNamespace: &namespace,
+	}); err != nil && !diagnostics.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("delete", "application", name, err)...)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("deleted application %s", name))
+
+	if !data.Wait.ValueBool() {
+		return
+	}
+
+	if err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		apps, err := r.si.ApplicationClient.List(ctx, &application.ApplicationQuery{
+			Name:         &name,
+			AppNamespace: &namespace,
+		})
+
+		switch {
+		case err != nil && !diagnostics.IsNotFound(err):
+			return retry.NonRetryableError(err)
+		case err == nil && apps != nil && len(apps.Items) > 0:
+			return retry.RetryableError(fmt.Errorf("application %s is still present", name))
+		}
+
+		return nil
+	}); err != nil {
+		resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("error while waiting for application %s to be deleted", name), err)...)
+	}
+}
+
+func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, namespace := applicationIDParts(req.ID)
+	if name == "" {
+		resp.Diagnostics.AddError("invalid application import identifier", fmt.Sprintf("expected an identifier in the format `<name>:<namespace>`, got: %s", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata").AtListIndex(0).AtName("name"), name)...)
+
+	if namespace != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata").AtListIndex(0).AtName("namespace"), namespace)...)
+	}
+}
+
+// applicationIDParts returns the name and namespace of an application from its
+// identifier.
+func applicationIDParts(id string) (name, namespace string) {
+	name, namespace, _ = strings.Cut(id, ":")
+	return name, namespace
+}
+
+// applicationNeedsUpdate returns whether the application built from the plan
+// differs from the one built from the prior state.
+func applicationNeedsUpdate(previous, planned *v1alpha1.Application) bool {
+	return !reflect.DeepEqual(previous, planned)
+}
+
+// readAppliedApplication sets the computed attributes of the model after the
+// application has been created or updated. The spec is retained as planned,
+// any drift will be reported by the next refresh. Computed attributes are set
+// to null if the application cannot be read so that the state can be saved
+// regardless.
+func readAppliedApplication(ctx context.Context, si *ServerInterface, data *applicationResourceModel) diag.Diagnostics {
+	name, namespace := applicationIDParts(data.ID.ValueString())
+
+	// The metadata is set given that the application has been built from it.
+	metadata := &data.Metadata[0]
+
+	app, diags := getApplication(ctx, si, name, namespace)
+	if app == nil && !diags.HasError() {
+		diags.AddError(fmt.Sprintf("application %s could not be found after being applied", name), "")
+	}
+
+	if diags.HasError() {
+		if metadata.Namespace.IsUnknown() {
+			metadata.Namespace = types.StringValue(namespace)
+		}
+
+		metadata.Generation = types.Int64Null()
+		metadata.ResourceVersion = types.StringNull()
+		metadata.UID = types.StringNull()
+		data.Status = types.ListNull(applicationStatusBlockType())
+
+		return diags
+	}
+
+	metadata.Namespace = types.StringValue(app.Namespace)
+	metadata.Generation = types.Int64Value(app.Generation)
+	metadata.ResourceVersion = types.StringValue(app.ResourceVersion)
+	metadata.UID = types.StringValue(string(app.UID))
+
+	status, ds := newApplicationStatusList(ctx, app.Status)
+	diags.Append(ds...)
+
+	data.Status = status
+
+	return diags
+}
+
+// waitForApplication waits for an application to be synced and healthy. If
+// previouslyReconciledAt is set, then the application must also have been
+// reconciled since.
+func waitForApplication(ctx context.Context, si *ServerInterface, name, namespace string, previouslyReconciledAt *metav1.Time, timeout time.Duration, waitThroughSyncWindows bool, action string) diag.Diagnostics {
+	// The retry function may still be running in the background when the
+	// wait times out, hence the use of an atomic pointer.
+	var observed atomic.Pointer[v1alpha1.Application]
+
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		list, err := si.ApplicationClient.List(ctx, &application.ApplicationQuery{
+			Name:         &name,
+			AppNamespace: &namespace,
+		})
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("error while waiting for application %s to be synced and healthy: %s", name, err))
+		}
+
+		if len(list.Items) != 1 {
+			return retry.NonRetryableError(fmt.Errorf("found unexpected number of applications matching name '%s' and namespace '%s'. Items: %d", name, namespace, len(list.Items)))
+		}
+
+		app := &list.Items[0]
+
+		observed.Store(app)
+
+		if previouslyReconciledAt != nil && app.Status.ReconciledAt.Equal(previouslyReconciledAt) {
+			return retry.RetryableError(fmt.Errorf("reconciliation has not begun"))
+		}
+
+		if rerr := applicationSyncWindowsRetryError(ctx, si, app, waitThroughSyncWindows); rerr != nil {
+			return rerr
+		}
+
+		if app.Status.Health.Status != health.HealthStatusHealthy {
+			return retry.RetryableError(fmt.Errorf("expected application health status to be healthy but was %s", app.Status.Health.Status))
+		}
+
+		if app.Status.Sync.Status != v1alpha1.SyncStatusCodeSynced {
+			return retry.RetryableError(fmt.Errorf("expected application sync status to be synced but was %s", app.Status.Sync.Status))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return applicationWaitErrorToDiagnostics(si, observed.Load(), name, namespace, fmt.Sprintf("error while waiting for application %s to be %s", name, action), err)
+	}
+
+	return nil
+}
+
+// applicationSyncWindowsRetryError checks whether an application that is out of
+// sync, or whose last sync failed, is currently prevented from being synced
+// automatically by the sync windows of its project. If so, a non-retryable
+// error naming the blocking windows is returned, unless waitThroughSyncWindows
+// is set in which case a retryable error is returned so that we keep waiting
+// for the window(s) to close. Transient errors getting the sync windows are
+// retryable as well. Returns nil if the application is waiting on neither a
+// sync nor a sync window, e.g. while a synced application becomes healthy, in
+// which case the sync windows are not fetched.
+func applicationSyncWindowsRetryError(ctx context.Context, si *ServerInterface, app *v1alpha1.Application, waitThroughSyncWindows bool) *retry.RetryError {
+	outOfSync := app.Status.Sync.Status == v1alpha1.SyncStatusCodeOutOfSync
+	syncFailed := app.Status.OperationState != nil && app.Status.OperationState.Phase.Failed()
+
+	if !outOfSync && !syncFailed {
+		return nil
+	}
+
+	windows, err := si.ApplicationClient.GetApplicationSyncWindows(ctx, &application.ApplicationSyncWindowsQuery{
+		Name:         &app.Name,
+		AppNamespace: &app.Namespace,
+		Project:      &app.Spec.Project,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to get sync windows for application %s: %w", app.Name, err)
+
+		// Keep waiting on transient errors, until the wait times out
+		if isRetryableError(err) {
+			return retry.RetryableError(err)
+		}
+
+		return retry.NonRetryableError(err)
+	}
+
+	blocking := blockingSyncWindows(windows)
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	err = fmt.Errorf("application %s cannot be synced automatically due to the following sync window(s) of project %s: %s", app.Name, app.Spec.Project, strings.Join(blocking, "; "))
+
+	if waitThroughSyncWindows {
+		return retry.RetryableError(err)
+	}
+
+	return retry.NonRetryableError(fmt.Errorf("%w. Set `wait_through_sync_windows = true` to wait for the sync window(s) to close", err))
+}
+
+// blockingSyncWindows returns a description of each sync window that currently
+// prevents automated syncs. This mirrors the logic used by ArgoCD in
+// `SyncWindows.CanSync()` for non-manual syncs: any active `deny` window blocks
+// syncs, otherwise syncs are blocked if `allow` windows are assigned to the
+// application but none of them are currently active.
+func blockingSyncWindows(windows *application.ApplicationSyncWindowsResponse) []string {
+	var deny, activeAllow []string
+
+	for _, w := range windows.GetActiveWindows() {
+		switch w.GetKind() {
+		case "deny":
+			deny = append(deny, describeSyncWindow(w))
+		case "allow":
+			activeAllow = append(activeAllow, describeSyncWindow(w))
+		}
+	}
+
+	if len(deny) > 0 {
+		return deny
+	}
+
+	if len(activeAllow) > 0 {
+		return nil
+	}
+
+	var inactiveAllow []string
+
+	for _, w := range windows.GetAssignedWindows() {
+		if w.GetKind() == "allow" {
+			inactiveAllow = append(inactiveAllow, fmt.Sprintf("%s (inactive)", describeSyncWindow(w)))
+		}
+	}
+
+	return inactiveAllow
+}
+
+func describeSyncWindow(w *application.ApplicationSyncWindow) string {
+	return fmt.Sprintf("%s window with schedule '%s' and duration '%s'", w.GetKind(), w.GetSchedule(), w.GetDuration())
+}
+
+// applicationWaitErrorToDiagnostics converts an error encountered whilst waiting
+// for an application to be synced and healthy into diagnostics, enriched with
+// the last observed status of the application (if any) and its most recent
+// warning events, so that failed rollouts can be debugged from the Terraform
+// output alone.
+func applicationWaitErrorToDiagnostics(si *ServerInterface, observed *v1alpha1.Application, name, namespace, summary string, err error) diag.Diagnostics {
+	detail := err.Error()
+
+	if observed != nil {
+		if status := describeApplicationStatus(observed.Status); status != "" {
+			detail = fmt.Sprintf("%s\n\n%s", detail, status)
+		}
+	}
+
+	// The operation context has most likely expired at this point given that
+	// the wait is bounded by the same timeout, so use a fresh one.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var diags diag.Diagnostics
+
+	events, err := listApplicationEvents(ctx, si, &application.ApplicationResourceEventsQuery{
+		Name:         &name,
+		AppNamespace: &namespace,
+	})
+	if err != nil {
+		diags.AddWarning(fmt.Sprintf("failed to list events for application %s", name), err.Error())
+	}
+
+	if warnings := lastWarningEvents(events, applicationWaitMaxWarningEvents); len(warnings) > 0 {
+		lines := make([]string, len(warnings))
+		for i, e := range warnings {
+			lines[i] = fmt.Sprintf("  - %s", formatEvent(e))
+		}
+
+		detail = fmt.Sprintf("%s\n\nMost recent warning events:\n%s", detail, strings.Join(lines, "\n"))
+	}
+
+	diags.AddError(summary, detail)
+
+	return diags
+}
+
+// describeApplicationStatus returns a human-readable summary of the parts of an
+// application status that are relevant when debugging a failed rollout: the
+// resources that are not synced and/or healthy, the application conditions and
+// the message of the last operation.
+func describeApplicationStatus(status v1alpha1.ApplicationStatus) string {
+	var sections []string
+
+	var resources []string
+
+	for _, r := range status.Resources {
+		synced := r.Status == "" || r.Status == v1alpha1.SyncStatusCodeSynced
+		healthy := r.Health == nil || r.Health.Status == "" || r.Health.Status == health.HealthStatusHealthy
+
+		if synced && healthy {
+			continue
+		}
+
+		id := strings.TrimPrefix(fmt.Sprintf("%s/%s", r.Group, r.Kind), "/")
+		if r.Namespace != "" {
+			id = fmt.Sprintf("%s %s/%s", id, r.Namespace, r.Name)
+		} else {
+			id = fmt.Sprintf("%s %s", id, r.Name)
+		}
+
+		line := fmt.Sprintf("  - %s: sync status %s", id, r.Status)
+
+		if r.Health != nil {
+			line = fmt.Sprintf("%s, health status %s", line, r.Health.Status)
+
+			if r.Health.Message != "" {
+				line = fmt.Sprintf("%s (%s)", line, r.Health.Message)
+			}
+		}
+
+		resources = append(resources, line)
+	}
+
+	if len(resources) > 0 {
+		sections = append(sections, fmt.Sprintf("Resources that are not synced and healthy:\n%s", strings.Join(resources, "\n")))
+	}
+
+	if len(status.Conditions) > 0 {
+		conditions := make([]string, len(status.Conditions))
+		for i, c := range status.Conditions {
+			conditions[i] = fmt.Sprintf("  - %s: %s", c.Type, c.Message)
+		}
+
+		sections = append(sections, fmt.Sprintf("Application conditions:\n%s", strings.Join(conditions, "\n")))
+	}
+
+	if status.OperationState != nil && status.OperationState.Message != "" {
+		sections = append(sections, fmt.Sprintf("Last operation (%s): %s", status.OperationState.Phase, status.OperationState.Message))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// createApplicationJSON creates the JSON encoded application through the REST
+// API, so that fields unknown to the bundled ArgoCD API client are not dropped.
+func (si *ServerInterface) createApplicationJSON(ctx context.Context, body []byte) (*v1alpha1.Application, error) {
+	b, err := si.restRequest(ctx, http.MethodPost, "/api/v1/applications", nil, body)
+
+	// As for requests sent using the gRPC API (see readCacheUnaryClientInterceptor)
+	if si.readCache != nil {
+		si.readCache.applications.invalidate()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var app v1alpha1.Application
+	if err := json.Unmarshal(b, &app); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal application: %w", err)
+	}
+
+	return &app, nil
+}
+
+// updateApplicationJSON updates the application with the JSON encoded one
+// through the REST API, so that fields unknown to the bundled ArgoCD API
+// client are not dropped.
+func (si *ServerInterface) updateApplicationJSON(ctx context.Context, name string, body []byte) error {
+	_, err := si.restRequest(ctx, http.MethodPut, "/api/v1/applications/"+url.PathEscape(name), nil, body)
+
+	if si.readCache != nil {
+		si.readCache.applications.invalidate()
+	}
+
+	return err
+}
+
+// getApplicationSpecJSON returns the JSON encoded spec of the application, as
+// returned by the REST API, including any field unknown to the bundled ArgoCD
+// API client.
+func (si *ServerInterface) getApplicationSpecJSON(ctx context.Context, name, namespace string) (json.RawMessage, error) {
+	query := url.Values{}
+	if namespace != "" {
+		query.Set("appNamespace", namespace)
+	}
+
+	b, err := si.restRequest(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(name), query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var app struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+
+	if err := json.Unmarshal(b, &app); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal application: %w", err)
+	}
+
+	return app.Spec, nil
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/oboukili/terraform-provider-argocd/internal/utils"
+)
+
+func (r *applicationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgrader := resource.StateUpgrader{
+		StateUpgrader: r.upgradeSDKState,
+	}
+
+	// All versions of the state of the SDK implementation of the resource share
+	// the same layout, hence a single upgrader is used for each.
+	return map[int64]resource.StateUpgrader{
+		0: upgrader,
+		1: upgrader,
+		2: upgrader,
+		3: upgrader,
+		4: upgrader,
+	}
+}
+
+// upgradeSDKState upgrades the state of the SDK implementation of the resource,
+// which shares the block shape of the current schema but not the types of
+// some of its attributes (e.g. numbers stored as strings). The status is
+// dropped since it will be refreshed.
+func (r *applicationResource) upgradeSDKState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("failed to upgrade application state", "missing raw state")
+		return
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(req.RawState.JSON, &raw); err != nil {
+		resp.Diagnostics.AddError("failed to upgrade application state", err.Error())
+		return
+	}
+
+	if applicationSDKStateUsesKsonnet(raw) {
+		resp.Diagnostics.AddError("failed to upgrade application state", "'ksonnet' support has been removed")
+		return
+	}
+
+	sr := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, sr)
+
+	typ := sr.Schema.Type().TerraformType(ctx)
+
+	upgraded, err := utils.UpgradeSDKState(req.RawState.JSON, typ, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to upgrade application state", err.Error())
+		return
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(upgraded, &state); err != nil {
+		resp.Diagnostics.AddError("failed to upgrade application state", err.Error())
+		return
+	}
+
+	state["status"] = nil
+
+	// Unlike other boolean attributes, these are not nullable so their values
+	// need to be retained as is.
+	for _, k := range []string{"cascade", "wait", "wait_through_sync_windows"} {
+		if v, ok := raw[k].(bool); ok {
+			state[k] = v
+		}
+	}
+
+	// Versions prior to 3 did not include the namespace in the identifier.
+	if metadata, ok := firstSDKStateBlock(state["metadata"]); ok {
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+
+		if name != "" && namespace != "" {
+			state["id"] = fmt.Sprintf("%s:%s", name, namespace)
+		}
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to upgrade application state", err.Error())
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: b}
+}
+
+// applicationSDKStateUsesKsonnet returns whether any source of the application
+// uses ksonnet, support for which was removed in version 2 of the state.
+func applicationSDKStateUsesKsonnet(raw map[string]interface{}) bool {
+	s, ok := firstSDKStateBlock(raw["spec"])
+	if !ok {
+		return false
+	}
+
+	sources, _ := s["source"].([]interface{})
+	for _, source := range sources {
+		if src, ok := source.(map[string]interface{}); ok {
+			if ksonnet, ok := src["ksonnet"].([]interface{}); ok && len(ksonnet) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// firstSDKStateBlock returns the first of the blocks stored in `v`, if any.
+func firstSDKStateBlock(v interface{}) (map[string]interface{}, bool) {
+	blocks, ok := v.([]interface{})
+	if !ok || len(blocks) == 0 {
+		return nil, false
+	}
+
+	b, ok := blocks[0].(map[string]interface{})
+
+	return b, ok
+}
@@ -1,22 +1,761 @@
-package argocd
+package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/gitops-engine/pkg/sync/common"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	customtypes "github.com/oboukili/terraform-provider-argocd/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func TestBlockingSyncWindows(t *testing.T) {
+	t.Parallel()
+
+	window := func(kind string) *application.ApplicationSyncWindow {
+		schedule, duration := "0 22 * * *", "1h"
+
+		return &application.ApplicationSyncWindow{
+			Kind:     &kind,
+			Schedule: &schedule,
+			Duration: &duration,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		windows *application.ApplicationSyncWindowsResponse
+		want    []string
+	}{
+		{
+			name:    "no windows",
+			windows: &application.ApplicationSyncWindowsResponse{},
+			want:    nil,
+		},
+		{
+			name: "active deny window",
+			windows: &application.ApplicationSyncWindowsResponse{
+				ActiveWindows:   []*application.ApplicationSyncWindow{window("deny"), window("allow")},
+				AssignedWindows: []*application.ApplicationSyncWindow{window("deny"), window("allow")},
+			},
+			want: []string{"deny window with schedule '0 22 * * *' and duration '1h'"},
+		},
+		{
+			name: "active allow window",
+			windows: &application.ApplicationSyncWindowsResponse{
+				ActiveWindows:   []*application.ApplicationSyncWindow{window("allow")},
+				AssignedWindows: []*application.ApplicationSyncWindow{window("allow"), window("allow")},
+			},
+			want: nil,
+		},
+		{
+			name: "inactive allow window",
+			windows: &application.ApplicationSyncWindowsResponse{
+				AssignedWindows: []*application.ApplicationSyncWindow{window("allow"), window("deny")},
+			},
+			want: []string{"allow window with schedule '0 22 * * *' and duration '1h' (inactive)"},
+		},
+		{
+			name: "inactive deny window",
+			windows: &application.ApplicationSyncWindowsResponse{
+				AssignedWindows: []*application.ApplicationSyncWindow{window("deny")},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := blockingSyncWindows(tt.windows)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakeSyncWindowsApplicationClient fails to get the sync windows of
+// applications with `err`.
+type fakeSyncWindowsApplicationClient struct {
+	application.ApplicationServiceClient
+
+	err error
+}
+
+func (c fakeSyncWindowsApplicationClient) GetApplicationSyncWindows(context.Context, *application.ApplicationSyncWindowsQuery, ...grpc.CallOption) (*application.ApplicationSyncWindowsResponse, error) {
+	return nil, c.err
+}
+
+func TestApplicationSyncWindowsRetryError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		status        v1alpha1.ApplicationStatus
+		err           error
+		wantNil       bool
+		wantRetryable bool
+	}{
+		{
+			name:          "transient error",
+			status:        v1alpha1.ApplicationStatus{Sync: v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeOutOfSync}},
+			err:           status.Error(codes.Unavailable, "connection refused"),
+			wantRetryable: true,
+		},
+		{
+			name:          "permanent error",
+			status:        v1alpha1.ApplicationStatus{Sync: v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeOutOfSync}},
+			err:           status.Error(codes.PermissionDenied, "permission denied"),
+			wantRetryable: false,
+		},
+		{
+			name: "failed sync",
+			status: v1alpha1.ApplicationStatus{
+				Sync:           v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeSynced},
+				OperationState: &v1alpha1.OperationState{Phase: synccommon.OperationFailed},
+			},
+			err:           status.Error(codes.PermissionDenied, "permission denied"),
+			wantRetryable: false,
+		},
+		{
+			name:    "synced application",
+			status:  v1alpha1.ApplicationStatus{Sync: v1alpha1.SyncStatus{Status: v1alpha1.SyncStatusCodeSynced}},
+			err:     status.Error(codes.Unavailable, "connection refused"),
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			si := &ServerInterface{ApplicationClient: fakeSyncWindowsApplicationClient{err: tt.err}}
+			app := &v1alpha1.Application{Status: tt.status}
+			app.Name = "test"
+
+			rerr := applicationSyncWindowsRetryError(context.Background(), si, app, false)
+
+			if tt.wantNil {
+				assert.Nil(t, rerr)
+				return
+			}
+
+			require.NotNil(t, rerr)
+			assert.Equal(t, tt.wantRetryable, rerr.Retryable)
+			assert.ErrorIs(t, rerr.Err, tt.err)
+		})
+	}
+}
+
+func TestDescribeApplicationStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status v1alpha1.ApplicationStatus
+		want   string
+	}{
+		{
+			name:   "empty status",
+			status: v1alpha1.ApplicationStatus{},
+			want:   "",
+		},
+		{
+			name: "synced and healthy resources",
+			status: v1alpha1.ApplicationStatus{
+				Resources: []v1alpha1.ResourceStatus{
+					{
+						Group:     "apps",
+						Kind:      "Deployment",
+						Namespace: "default",
+						Name:      "foo",
+						Status:    v1alpha1.SyncStatusCodeSynced,
+						Health:    &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy},
+					},
+				},
+			},
+			want: "",
+		},
+		{
+			name: "unhealthy resources, conditions and operation message",
+			status: v1alpha1.ApplicationStatus{
+				Resources: []v1alpha1.ResourceStatus{
+					{
+						Group:     "apps",
+						Kind:      "Deployment",
+						Namespace: "default",
+						Name:      "foo",
+						Status:    v1alpha1.SyncStatusCodeSynced,
+						Health:    &v1alpha1.HealthStatus{Status: health.HealthStatusDegraded, Message: "Deployment exceeded its progress deadline"},
+					},
+					{
+						Kind:   "Namespace",
+						Name:   "bar",
+						Status: v1alpha1.SyncStatusCodeOutOfSync,
+					},
+					{
+						Kind:      "Service",
+						Namespace: "default",
+						Name:      "foo",
+						Status:    v1alpha1.SyncStatusCodeSynced,
+						Health:    &v1alpha1.HealthStatus{Status: health.HealthStatusHealthy},
+					},
+				},
+				Conditions: []v1alpha1.ApplicationCondition{
+					{Type: v1alpha1.ApplicationConditionSyncError, Message: "Failed sync attempt"},
+				},
+				OperationState: &v1alpha1.OperationState{
+					Phase:   "Failed",
+					Message: "one or more objects failed to apply",
+				},
+			},
+			want: `Resources that are not synced and healthy:
+  - apps/Deployment default/foo: sync status Synced, health status Degraded (Deployment exceeded its progress deadline)
+  - Namespace bar: sync status OutOfSync
+
+Application conditions:
+  - SyncError: Failed sync attempt
+
+Last operation (Failed): one or more objects failed to apply`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, describeApplicationStatus(tt.status))
+		})
+	}
+}
+
+func TestMergeApplicationSpecOverride(t *testing.T) {
+	t.Parallel()
+
+	spec := v1alpha1.ApplicationSpec{
+		Project: "default",
+		Source: &v1alpha1.ApplicationSource{
+			RepoURL:        "https://github.com/kubernetes-sigs/kustomize",
+			Path:           "examples/helloWorld",
+			TargetRevision: "release-kustomize-v3.7",
+			Kustomize: &v1alpha1.ApplicationSourceKustomize{
+				NamePrefix: "foo-",
+			},
+		},
+		SyncPolicy: &v1alpha1.SyncPolicy{
+			Automated: &v1alpha1.SyncPolicyAutomated{
+				Prune: true,
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{
+			name:     "no override",
+			override: "",
+			want:     `{"destination":{},"project":"default","source":{"repoURL":"https://github.com/kubernetes-sigs/kustomize","path":"examples/helloWorld","targetRevision":"release-kustomize-v3.7","kustomize":{"namePrefix":"foo-"}},"syncPolicy":{"automated":{"prune":true}}}`,
+		},
+		{
+			name:     "merges nested fields into single source",
+			override: `{"source":{"kustomize":{"forceCommonAnnotations":true}}}`,
+			want:     `{"destination":{},"project":"default","source":{"repoURL":"https://github.com/kubernetes-sigs/kustomize","path":"examples/helloWorld","targetRevision":"release-kustomize-v3.7","kustomize":{"namePrefix":"foo-","forceCommonAnnotations":true}},"syncPolicy":{"automated":{"prune":true}}}`,
+		},
+		{
+			name:     "null removes fields",
+			override: `{"syncPolicy":{"automated":null}}`,
+			want:     `{"destination":{},"project":"default","source":{"repoURL":"https://github.com/kubernetes-sigs/kustomize","path":"examples/helloWorld","targetRevision":"release-kustomize-v3.7","kustomize":{"namePrefix":"foo-"}},"syncPolicy":{}}`,
+		},
+		{
+			name:     "unknown fields are passed through",
+			override: `{"source":{"kustomize":{"notAField":true}},"alsoNotAField":{"foo":["bar"]}}`,
+			want:     `{"destination":{},"project":"default","source":{"repoURL":"https://github.com/kubernetes-sigs/kustomize","path":"examples/helloWorld","targetRevision":"release-kustomize-v3.7","kustomize":{"namePrefix":"foo-","notAField":true}},"syncPolicy":{"automated":{"prune":true}},"alsoNotAField":{"foo":["bar"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := mergeApplicationSpecOverride(spec, tt.override)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApplicationResourceModel_toApplication_MissingSpec(t *testing.T) {
+	t.Parallel()
+
+	app, diags := applicationResourceModel{}.toApplication()
+
+	assert.Nil(t, app)
+	assert.True(t, diags.HasError())
+}
+
+func TestApplicationResourceModel_applicationJSON(t *testing.T) {
+	t.Parallel()
+
+	m := applicationResourceModel{
+		SpecOverrideJSON: customtypes.JSONStringValue(`{"source":{"kustomize":{"notAField":true}}}`),
+	}
+
+	app := &v1alpha1.Application{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Application",
+			APIVersion: "argoproj.io/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "argocd",
+		},
+		Spec: v1alpha1.ApplicationSpec{
+			Project: "default",
+			Source: &v1alpha1.ApplicationSource{
+				RepoURL:   "https://github.com/kubernetes-sigs/kustomize",
+				Kustomize: &v1alpha1.ApplicationSourceKustomize{NamePrefix: "foo-"},
+			},
+		},
+	}
+
+	b, diags := m.applicationJSON(app)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &got))
+
+	assert.Equal(t, "Application", got["kind"])
+	assert.Equal(t, map[string]interface{}{"name": "foo", "namespace": "argocd", "creationTimestamp": nil}, got["metadata"])
+	assert.Equal(t, map[string]interface{}{
+		"destination": map[string]interface{}{},
+		"project":     "default",
+		"source": map[string]interface{}{
+			"repoURL": "https://github.com/kubernetes-sigs/kustomize",
+			"kustomize": map[string]interface{}{
+				"namePrefix": "foo-",
+				"notAField":  true,
+			},
+		},
+	}, got["spec"])
+}
+
+func TestProjectApplicationSpecOverride(t *testing.T) {
+	t.Parallel()
+
+	// As returned by the REST API, including fields unknown to the bundled
+	// ArgoCD API client.
+	spec := json.RawMessage(`{
+		"project": "default",
+		"source": {
+			"repoURL": "https://github.com/kubernetes-sigs/kustomize",
+			"path": "examples/helloWorld",
+			"kustomize": {
+				"namePrefix": "foo-",
+				"forceCommonAnnotations": true,
+				"notAField": "foo"
+			}
+		},
+		"syncPolicy": {
+			"syncOptions": ["CreateNamespace=true"]
+		}
+	}`)
+
+	tests := []struct {
+		name     string
+		override string
+		want     string
+	}{
+		{
+			name:     "only keys set in override",
+			override: `{"source":{"kustomize":{"forceCommonAnnotations":true}}}`,
+			want:     `{"source":{"kustomize":{"forceCommonAnnotations":true}}}`,
+		},
+		{
+			name:     "drift is reported",
+			override: `{"source":{"kustomize":{"namePrefix":"bar-"}}}`,
+			want:     `{"source":{"kustomize":{"namePrefix":"foo-"}}}`,
+		},
+		{
+			name:     "unknown fields are read back",
+			override: `{"source":{"kustomize":{"notAField":"bar"}}}`,
+			want:     `{"source":{"kustomize":{"notAField":"foo"}}}`,
+		},
+		{
+			name:     "omitted zero values are retained",
+			override: `{"source":{"kustomize":{"forceCommonLabels":false}},"syncPolicy":{"automated":null}}`,
+			want:     `{"source":{"kustomize":{"forceCommonLabels":false}},"syncPolicy":{"automated":null}}`,
+		},
+		{
+			name:     "missing values are dropped",
+			override: `{"syncPolicy":{"retry":{"limit":5}}}`,
+			want:     `{"syncPolicy":{}}`,
+		},
+		{
+			name:     "lists are returned as a whole",
+			override: `{"syncPolicy":{"syncOptions":["ServerSideApply=true"]}}`,
+			want:     `{"syncPolicy":{"syncOptions":["CreateNamespace=true"]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := projectApplicationSpecOverride(spec, tt.override)
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplicationResourceUpgradeState(t *testing.T) {
+	t.Parallel()
+
+	// State of version 4 of the SDK implementation of the resource.
+	rawState := `{
+  "cascade": false,
+  "id": "test:argocd",
+  "metadata": [
+    {
+      "annotations": {},
+      "generation": 2,
+      "labels": {"acceptance": "true"},
+      "name": "test",
+      "namespace": "argocd",
+      "resource_version": "1234",
+      "uid": "b8f8a0e8-5c5a-4c9b-9f5e-3e6f1a5f7f4b"
+    }
+  ],
+  "spec": [
+    {
+      "destination": [{"name": "", "namespace": "default", "server": "https://kubernetes.default.svc"}],
+      "ignore_difference": [{"group": "apps", "jq_path_expressions": [], "json_pointers": ["/spec/replicas"], "kind": "Deployment", "name": "", "namespace": ""}],
+      "info": [{"name": "foo", "value": "bar"}],
+      "project": "default",
+      "revision_history_limit": 10,
+      "source": [
+        {
+          "chart": "",
+          "directory": [],
+          "helm": [
+            {
+              "file_parameter": [],
+              "ignore_missing_value_files": false,
+              "parameter": [{"force_string": true, "name": "image.tag", "value": "1.2.3"}],
+              "pass_credentials": false,
+              "release_name": "test",
+              "skip_crds": false,
+              "value_files": ["values.yaml"],
+              "values": "",
+              "values_object": ""
+            }
+          ],
+          "kustomize": [],
+          "path": "charts/test",
+          "plugin": [],
+          "ref": "",
+          "repo_url": "https://github.com/argoproj/argo-cd",
+          "target_revision": "HEAD"
+        }
+      ],
+      "sync_policy": [
+        {
+          "automated": [{"allow_empty": false, "prune": true, "self_heal": true}],
+          "managed_namespace_metadata": [],
+          "retry": [{"backoff": [{"duration": "30s", "factor": "2", "max_duration": "2m"}], "limit": "5"}],
+          "sync_options": ["CreateNamespace=true"]
+        }
+      ]
+    }
+  ],
+  "spec_override_json": "",
+  "status": [{"health": [{"message": "", "status": "Healthy"}]}],
+  "timeouts": {"create": "10m", "delete": null, "update": null},
+  "wait": true,
+  "wait_through_sync_windows": null
+}`
+
+	ctx := context.Background()
+	r := &applicationResource{}
+
+	sr := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, sr)
+
+	upgrader, ok := r.UpgradeState(ctx)[4]
+	assert.True(t, ok)
+
+	resp := &fwresource.UpgradeStateResponse{}
+	upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(rawState)}}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Ensure the upgraded state conforms to the current schema.
+	_, err := resp.DynamicValue.Unmarshal(sr.Schema.Type().TerraformType(ctx))
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{
+  "cascade": false,
+  "id": "test:argocd",
+  "metadata": [
+    {
+      "annotations": null,
+      "generation": 2,
+      "labels": {"acceptance": "true"},
+      "name": "test",
+      "namespace": "argocd",
+      "resource_version": "1234",
+      "uid": "b8f8a0e8-5c5a-4c9b-9f5e-3e6f1a5f7f4b"
+    }
+  ],
+  "spec": [
+    {
+      "destination": [{"name": null, "namespace": "default", "server": "https://kubernetes.default.svc"}],
+      "ignore_difference": [{"group": "apps", "jq_path_expressions": null, "json_pointers": ["/spec/replicas"], "kind": "Deployment", "name": null, "namespace": null}],
+      "info": [{"name": "foo", "value": "bar"}],
+      "project": "default",
+      "revision_history_limit": 10,
+      "source": [
+        {
+          "chart": null,
+          "directory": null,
+          "helm": [
+            {
+              "api_versions": null,
+              "file_parameter": null,
+              "ignore_missing_value_files": null,
+              "kube_version": null,
+              "namespace": null,
+              "parameter": [{"force_string": true, "name": "image.tag", "value": "1.2.3"}],
+              "pass_credentials": null,
+              "release_name": "test",
+              "skip_crds": null,
+              "skip_tests": null,
+              "value_files": ["values.yaml"],
+              "values": null,
+              "values_object": null
+            }
+          ],
+          "kustomize": null,
+          "path": "charts/test",
+          "plugin": null,
+          "ref": null,
+          "repo_url": "https://github.com/argoproj/argo-cd",
+          "target_revision": "HEAD"
+        }
+      ],
+      "sync_policy": [
+        {
+          "automated": [{"allow_empty": null, "prune": true, "self_heal": true}],
+          "managed_namespace_metadata": null,
+          "retry": [{"backoff": [{"duration": "30s", "factor": 2, "max_duration": "2m"}], "limit": 5}],
+          "sync_options": ["CreateNamespace=true"]
+        }
+      ]
+    }
+  ],
+  "spec_override_json": null,
+  "status": null,
+  "timeouts": {"create": "10m", "delete": null, "update": null},
+  "wait": true,
+  "wait_through_sync_windows": null
+}`, string(resp.DynamicValue.JSON))
+}
+
+func TestApplicationResourceUpgradeState_Ksonnet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &applicationResource{}
+
+	resp := &fwresource.UpgradeStateResponse{}
+	r.UpgradeState(ctx)[1].StateUpgrader(ctx, fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(`{
+  "metadata": [{"name": "test", "namespace": "argocd"}],
+  "spec": [{"source": [{"repo_url": "https://github.com/argoproj/argo-cd", "ksonnet": [{"environment": "dev"}]}]}]
+}`)}}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func TestApplicationResourceUpgradeState_PriorVersions(t *testing.T) {
+	t.Parallel()
+
+	metadata := `[{"name": "test", "namespace": "argocd"}]`
+	source := `"repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "chart": "redis", "target_revision": "16.9.11"`
+	destination := `[{"server": "https://kubernetes.default.svc", "namespace": "default"}]`
+
+	tests := []struct {
+		name               string
+		version            int64
+		rawState           string
+		expectedID         string
+		expectedSource     string
+		expectedSyncPolicy string
+	}{
+		{
+			name:               "V0 without skip_crds",
+			version:            0,
+			rawState:           `{"id": "test", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `, "helm": [{"release_name": "testing"}]}], "destination": ` + destination + `}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "helm": [{"release_name": "testing"}], "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `null`,
+		},
+		{
+			name:               "V1 with skip_crds",
+			version:            1,
+			rawState:           `{"id": "test", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `, "helm": [{"release_name": "testing", "skip_crds": true}]}], "destination": ` + destination + `}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "helm": [{"release_name": "testing", "skip_crds": true}], "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `null`,
+		},
+		{
+			name:               "V2 identifier without namespace",
+			version:            2,
+			rawState:           `{"id": "test", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `}], "destination": ` + destination + `}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `null`,
+		},
+		{
+			name:               "V3 full sync policy",
+			version:            3,
+			rawState:           `{"id": "test:argocd", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `}], "destination": ` + destination + `, "sync_policy": [{"automated": {"prune": true, "self_heal": true, "allow_empty": true}, "sync_options": ["Validate=false"], "retry": [{"limit": "5", "backoff": {"duration": "30s", "max_duration": "2m", "factor": "2"}}]}]}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `[{"managed_namespace_metadata": null, "automated": [{"allow_empty": true, "prune": true, "self_heal": true}], "retry": [{"backoff": [{"duration": "30s", "factor": 2, "max_duration": "2m"}], "limit": 5}], "sync_options": ["Validate=false"]}]`,
+		},
+		{
+			name:               "V3 no automated block",
+			version:            3,
+			rawState:           `{"id": "test:argocd", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `}], "destination": ` + destination + `, "sync_policy": [{"sync_options": ["Validate=false"], "retry": [{"limit": "5", "backoff": {"duration": "30s", "max_duration": "2m", "factor": "2"}}]}]}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `[{"managed_namespace_metadata": null, "automated": null, "retry": [{"backoff": [{"duration": "30s", "factor": 2, "max_duration": "2m"}], "limit": 5}], "sync_options": ["Validate=false"]}]`,
+		},
+		{
+			name:               "V3 blank automated block",
+			version:            3,
+			rawState:           `{"id": "test:argocd", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `}], "destination": ` + destination + `, "sync_policy": [{"automated": {}, "sync_options": ["Validate=false"]}]}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `[{"managed_namespace_metadata": null, "automated": [{"allow_empty": null, "prune": null, "self_heal": null}], "retry": null, "sync_options": ["Validate=false"]}]`,
+		},
+		{
+			name:               "V3 no backoff",
+			version:            3,
+			rawState:           `{"id": "test:argocd", "metadata": ` + metadata + `, "spec": [{"source": [{` + source + `}], "destination": ` + destination + `, "sync_policy": [{"automated": {"prune": true, "self_heal": true, "allow_empty": true}, "sync_options": ["Validate=false"], "retry": [{"limit": "5"}]}]}]}`,
+			expectedID:         "test:argocd",
+			expectedSource:     `{"chart": "redis", "repo_url": "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami", "target_revision": "16.9.11"}`,
+			expectedSyncPolicy: `[{"managed_namespace_metadata": null, "automated": [{"allow_empty": true, "prune": true, "self_heal": true}], "retry": [{"backoff": null, "limit": 5}], "sync_options": ["Validate=false"]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			r := &applicationResource{}
+
+			sr := &fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, sr)
+
+			upgrader, ok := r.UpgradeState(ctx)[tt.version]
+			require.True(t, ok)
+
+			resp := &fwresource.UpgradeStateResponse{}
+			upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(tt.rawState)}}, resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			// Ensure the upgraded state conforms to the current schema.
+			_, err := resp.DynamicValue.Unmarshal(sr.Schema.Type().TerraformType(ctx))
+			require.NoError(t, err)
+
+			var state struct {
+				ID   string `json:"id"`
+				Spec []struct {
+					Source     []map[string]interface{} `json:"source"`
+					SyncPolicy json.RawMessage          `json:"sync_policy"`
+				} `json:"spec"`
+			}
+
+			require.NoError(t, json.Unmarshal(resp.DynamicValue.JSON, &state))
+			require.Len(t, state.Spec, 1)
+			require.Len(t, state.Spec[0].Source, 1)
+
+			// Only compare the attributes of the source that are set
+			source := make(map[string]interface{})
+
+			for k, v := range state.Spec[0].Source[0] {
+				if bs, ok := v.([]interface{}); ok {
+					for _, b := range bs {
+						if h, ok := b.(map[string]interface{}); ok {
+							for hk, hv := range h {
+								if hv == nil {
+									delete(h, hk)
+								}
+							}
+						}
+					}
+				}
+
+				if v != nil {
+					source[k] = v
+				}
+			}
+
+			b, err := json.Marshal(source)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedID, state.ID)
+			assert.JSONEq(t, tt.expectedSource, string(b))
+			assert.JSONEq(t, tt.expectedSyncPolicy, string(state.Spec[0].SyncPolicy))
+		})
+	}
+}
+
 func TestAccArgoCDApplication(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationSimple(name, "8.0.0", false),
@@ -111,8 +850,8 @@ ingress:
 `
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationHelm(
@@ -155,10 +894,60 @@ ingress:
 	})
 }
 
+func TestAccArgoCDApplication_HelmValuesObject(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-acc")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ApplicationHelmValuesObject) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccArgoCDApplicationHelmValuesObject(name, `jsonencode({
+          architecture = "standalone"
+          image = {
+            tag = "6.2.5"
+          }
+        })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"argocd_application.helm_values_object",
+						"metadata.0.uid",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.helm_values_object",
+						"spec.0.source.0.helm.0.values_object",
+						`{"architecture":"standalone","image":{"tag":"6.2.5"}}`,
+					),
+				),
+			},
+			{
+				// Semantically equivalent JSON should not result in a diff
+				Config: testAccArgoCDApplicationHelmValuesObject(name, `<<EOT
+{
+  "image": {"tag": "6.2.5"},
+  "architecture": "standalone"
+}
+EOT`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "argocd_application.helm_values_object",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait", "cascade", "metadata.0.generation", "metadata.0.resource_version", "status"},
+			},
+			{
+				Config:      testAccArgoCDApplicationHelmValuesObjectWithValues(name),
+				ExpectError: regexp.MustCompile("helm `values` and `values_object` are mutually exclusive"),
+			},
+		},
+	})
+}
+
 func TestAccArgoCDApplication_Helm_FileParameters(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationHelm_FileParameters(acctest.RandomWithPrefix("test-acc")),
@@ -171,8 +960,8 @@ func TestAccArgoCDApplication_Helm_FileParameters(t *testing.T) {
 
 func TestAccArgoCDApplication_Kustomize(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationKustomize(
@@ -204,10 +993,132 @@ func TestAccArgoCDApplication_Kustomize(t *testing.T) {
 	})
 }
 
+func TestAccArgoCDApplication_KustomizePatchesAndReplicas(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckFeatureSupported(t, features.ApplicationKustomizePatches)
+			testAccPreCheckFeatureSupported(t, features.ApplicationKustomizeReplicas)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccArgoCDApplicationKustomizePatchesAndReplicas(
+					acctest.RandomWithPrefix("test-acc")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"argocd_application.kustomize_patches",
+						"metadata.0.uid",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.kustomize_patches",
+						"spec.0.source.0.kustomize.0.namespace",
+						"kustomize-patches",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.kustomize_patches",
+						"spec.0.source.0.kustomize.0.force_common_labels",
+						"true",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.kustomize_patches",
+						"spec.0.source.0.kustomize.0.replicas.0.name",
+						"the-deployment",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.kustomize_patches",
+						"spec.0.source.0.kustomize.0.replicas.0.count",
+						"2",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.kustomize_patches",
+						"spec.0.source.0.kustomize.0.patches.0.target.0.kind",
+						"Deployment",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.kustomize_patches",
+						"spec.0.source.0.kustomize.0.patches.0.options.allowNameChange",
+						"true",
+					),
+				),
+			},
+			{
+				ResourceName:            "argocd_application.kustomize_patches",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait", "cascade", "metadata.0.generation", "metadata.0.resource_version", "status"},
+			},
+		},
+	})
+}
+
+func TestAccArgoCDApplication_SpecOverrideJSON(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-acc")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccArgoCDApplicationSpecOverrideJSON(name, `jsonencode({
+    source = {
+      kustomize = {
+        forceCommonAnnotations = true
+      }
+    }
+  })`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"argocd_application.spec_override_json",
+						"metadata.0.uid",
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.spec_override_json",
+						"spec_override_json",
+						`{"source":{"kustomize":{"forceCommonAnnotations":true}}}`,
+					),
+					resource.TestCheckResourceAttr(
+						"argocd_application.spec_override_json",
+						"spec.0.source.0.kustomize.0.name_suffix",
+						"-bar",
+					),
+				),
+			},
+			{
+				// Semantically equivalent JSON should not result in a diff
+				Config: testAccArgoCDApplicationSpecOverrideJSON(name, `<<EOT
+{ "source": { "kustomize": { "forceCommonAnnotations": true } } }
+EOT`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccArgoCDApplicationSpecOverrideJSON(name, `jsonencode({
+    source = {
+      kustomize = {
+        forceCommonAnnotations = false
+      }
+    }
+  })`),
+				Check: resource.TestCheckResourceAttr(
+					"argocd_application.spec_override_json",
+					"spec_override_json",
+					`{"source":{"kustomize":{"forceCommonAnnotations":false}}}`,
+				),
+			},
+			{
+				ResourceName:            "argocd_application.spec_override_json",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait", "cascade", "metadata.0.generation", "metadata.0.resource_version", "status", "spec_override_json"},
+			},
+		},
+	})
+}
+
 func TestAccArgoCDApplication_IgnoreDifferences(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationIgnoreDifferences(
@@ -269,8 +1180,8 @@ func TestAccArgoCDApplication_RevisionHistoryLimit(t *testing.T) {
 	revisionHistoryLimit := acctest.RandIntRange(0, 9)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationRevisionHistory(
@@ -301,8 +1212,8 @@ func TestAccArgoCDApplication_RevisionHistoryLimit(t *testing.T) {
 
 func TestAccArgoCDApplication_OptionalDestinationNamespace(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplication_OptionalDestinationNamespace(acctest.RandomWithPrefix("test-acc")),
@@ -330,8 +1241,8 @@ func TestAccArgoCDApplication_OptionalDestinationNamespace(t *testing.T) {
 
 func TestAccArgoCDApplication_DirectoryJsonnet(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplication_DirectoryJsonnet(
@@ -417,8 +1328,8 @@ func TestAccArgoCDApplication_Recurse(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationRecurseDirectory(name, true),
@@ -437,10 +1348,9 @@ func TestAccArgoCDApplication_Recurse(t *testing.T) {
 			{
 				Config: testAccArgoCDApplicationDirectoryImplicitNonRecurse(acctest.RandomWithPrefix("test-acc")),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(
+					resource.TestCheckNoResourceAttr(
 						"argocd_application.directory",
 						"spec.0.source.0.directory.0.recurse",
-						"false",
 					),
 					resource.TestCheckResourceAttr(
 						"argocd_application.directory",
@@ -497,8 +1407,8 @@ func TestAccArgoCDApplication_EmptyDirectory(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplication_EmptyDirectory(name),
@@ -523,8 +1433,8 @@ func TestAccArgoCDApplication_DirectoryIncludeExclude(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplication_DirectoryIncludeExclude(name),
@@ -553,8 +1463,8 @@ func TestAccArgoCDApplication_DirectoryIncludeExclude(t *testing.T) {
 
 func TestAccArgoCDApplication_SyncPolicy(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationSyncPolicy(
@@ -613,8 +1523,8 @@ func TestAccArgoCDApplication_SyncPolicy(t *testing.T) {
 
 func TestAccArgoCDApplication_NoSyncPolicyBlock(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationNoSyncPolicy(acctest.RandomWithPrefix("test-acc")),
@@ -639,8 +1549,8 @@ func TestAccArgoCDApplication_NoSyncPolicyBlock(t *testing.T) {
 
 func TestAccArgoCDApplication_EmptySyncPolicyBlock(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationEmptySyncPolicy(acctest.RandomWithPrefix("test-acc")),
@@ -665,8 +1575,8 @@ func TestAccArgoCDApplication_EmptySyncPolicyBlock(t *testing.T) {
 
 func TestAccArgoCDApplication_NoAutomatedBlock(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationNoAutomated(acctest.RandomWithPrefix("test-acc")),
@@ -691,8 +1601,8 @@ func TestAccArgoCDApplication_NoAutomatedBlock(t *testing.T) {
 
 func TestAccArgoCDApplication_EmptyAutomatedBlock(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationEmptyAutomated(acctest.RandomWithPrefix("test-acc")),
@@ -719,8 +1629,8 @@ func TestAccArgoCDApplication_OptionalPath(t *testing.T) {
 	app := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationDirectoryNoPath(app),
@@ -774,8 +1684,8 @@ func TestAccArgoCDApplication_Info(t *testing.T) {
 	value := acctest.RandString(8)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationInfo(name, info, value),
@@ -803,10 +1713,9 @@ func TestAccArgoCDApplication_Info(t *testing.T) {
 						"argocd_application.info",
 						"metadata.0.uid",
 					),
-					resource.TestCheckResourceAttr(
+					resource.TestCheckNoResourceAttr(
 						"argocd_application.info",
 						"spec.0.info.0.name",
-						"",
 					),
 					resource.TestCheckResourceAttr(
 						"argocd_application.info",
@@ -827,10 +1736,9 @@ func TestAccArgoCDApplication_Info(t *testing.T) {
 						"spec.0.info.0.name",
 						info,
 					),
-					resource.TestCheckResourceAttr(
+					resource.TestCheckNoResourceAttr(
 						"argocd_application.info",
 						"spec.0.info.0.value",
-						"",
 					),
 				),
 			},
@@ -868,7 +1776,7 @@ func TestAccArgoCDApplication_Info(t *testing.T) {
 			},
 			{
 				Config:      testAccArgoCDApplicationInfoEmpty(name),
-				ExpectError: regexp.MustCompile("info: cannot be empty."),
+				ExpectError: regexp.MustCompile("At least one attribute out of"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(
 						"argocd_application.info",
@@ -888,8 +1796,8 @@ func TestAccArgoCDApplication_SkipCrds(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc-crds")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationSkipCrds_NoSkip(name),
@@ -898,10 +1806,9 @@ func TestAccArgoCDApplication_SkipCrds(t *testing.T) {
 						"argocd_application.crds",
 						"metadata.0.uid",
 					),
-					resource.TestCheckResourceAttr(
+					resource.TestCheckNoResourceAttr(
 						"argocd_application.crds",
 						"spec.0.source.0.helm.0.skip_crds",
-						"false",
 					),
 				),
 			},
@@ -941,8 +1848,8 @@ func TestAccArgoCDApplication_CustomNamespace(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ProjectSourceNamespaces) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ProjectSourceNamespaces) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationCustomNamespace(name),
@@ -965,8 +1872,8 @@ func TestAccArgoCDApplication_CustomNamespace(t *testing.T) {
 
 func TestAccArgoCDApplication_MultipleSources(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.MultipleApplicationSources) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.MultipleApplicationSources) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationMultipleSources(),
@@ -999,8 +1906,8 @@ func TestAccArgoCDApplication_MultipleSources(t *testing.T) {
 
 func TestAccArgoCDApplication_HelmValuesFromExternalGitRepo(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.MultipleApplicationSources) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.MultipleApplicationSources) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationHelmValuesFromExternalGitRepo(),
@@ -1037,8 +1944,8 @@ func TestAccArgoCDApplication_HelmValuesFromExternalGitRepo(t *testing.T) {
 
 func TestAccArgoCDApplication_ManagedNamespaceMetadata(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ManagedNamespaceMetadata) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t); testAccPreCheckFeatureSupported(t, features.ManagedNamespaceMetadata) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplication_ManagedNamespaceMetadata(),
@@ -1063,8 +1970,8 @@ func TestAccArgoCDApplication_Wait(t *testing.T) {
 	name := acctest.RandomWithPrefix("test-acc")
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccArgoCDApplicationSimple(name, chartRevision, true),
@@ -1085,6 +1992,21 @@ func TestAccArgoCDApplication_Wait(t *testing.T) {
 	})
 }
 
+func TestAccArgoCDApplication_WaitSyncWindow(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-acc")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccArgoCDApplicationWaitSyncWindow(name),
+				ExpectError: regexp.MustCompile("deny window with schedule '\\* \\* \\* \\* \\*' and duration '24h'"),
+			},
+		},
+	})
+}
+
 func testAccArgoCDApplicationSimple(name, targetRevision string, wait bool) string {
 	return fmt.Sprintf(`
 resource "argocd_application" "%[1]s" {
@@ -1184,6 +2106,71 @@ EOT
 	`, name, helmValues)
 }
 
+func testAccArgoCDApplicationHelmValuesObject(name, valuesObject string) string {
+	return fmt.Sprintf(`
+resource "argocd_application" "helm_values_object" {
+  metadata {
+    name      = "%[1]s"
+    namespace = "argocd"
+  }
+
+  spec {
+    source {
+      repo_url        = "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami"
+      chart           = "redis"
+      target_revision = "16.9.11"
+      helm {
+        release_name  = "testing"
+        values_object = %[2]s
+      }
+    }
+
+    sync_policy {
+      sync_options = ["CreateNamespace=true"]
+    }
+
+    destination {
+      server    = "https://kubernetes.default.svc"
+      namespace = "%[1]s"
+    }
+  }
+}
+	`, name, valuesObject)
+}
+
+func testAccArgoCDApplicationHelmValuesObjectWithValues(name string) string {
+	return fmt.Sprintf(`
+resource "argocd_application" "helm_values_object" {
+  metadata {
+    name      = "%[1]s"
+    namespace = "argocd"
+  }
+
+  spec {
+    source {
+      repo_url        = "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami"
+      chart           = "redis"
+      target_revision = "16.9.11"
+      helm {
+        release_name  = "testing"
+        values        = "architecture: standalone"
+        values_object = jsonencode({ architecture = "replication" })
+      }
+    }
+
+    sync_policy {
+      sync_options = ["CreateNamespace=true"]
+    }
+
+    destination {
+      server    = "https://kubernetes.default.svc"
+      namespace = "%[1]s"
+    }
+  }
+}
+	`, name)
+}
+
 func testAccArgoCDApplicationHelm_FileParameters(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_application" "helm_file_parameters" {
@@ -1261,6 +2248,94 @@ resource "argocd_application" "kustomize" {
 	`, name)
 }
 
+func testAccArgoCDApplicationKustomizePatchesAndReplicas(name string) string {
+	return fmt.Sprintf(`
+resource "argocd_application" "kustomize_patches" {
+  metadata {
+    name      = "%s"
+    namespace = "argocd"
+  }
+
+  spec {
+    source {
+      repo_url        = "https://github.com/kubernetes-sigs/kustomize"
+      path            = "examples/helloWorld"
+      target_revision = "release-kustomize-v3.7"
+      kustomize {
+        namespace           = "kustomize-patches"
+        force_common_labels = true
+        common_labels = {
+          "app" = "kustomize-patches"
+        }
+
+        replicas {
+          name  = "the-deployment"
+          count = 2
+        }
+
+        patches {
+          target {
+            kind = "Deployment"
+            name = "the-deployment"
+          }
+          patch = <<-EOT
+            - op: add
+              path: /metadata/annotations
+              value:
+                patched: "true"
+          EOT
+          options = {
+            allowNameChange = true
+          }
+        }
+      }
+    }
+
+    sync_policy {
+      sync_options = ["CreateNamespace=true"]
+    }
+
+    destination {
+      server    = "https://kubernetes.default.svc"
+      namespace = "kustomize-patches"
+    }
+  }
+}
+	`, name)
+}
+
+func testAccArgoCDApplicationSpecOverrideJSON(name, override string) string {
+	return fmt.Sprintf(`
+resource "argocd_application" "spec_override_json" {
+  metadata {
+    name      = "%s"
+    namespace = "argocd"
+  }
+
+  spec {
+    source {
+      repo_url        = "https://github.com/kubernetes-sigs/kustomize"
+      path            = "examples/helloWorld"
+      target_revision = "release-kustomize-v3.7"
+      kustomize {
+        name_suffix = "-bar"
+        common_annotations = {
+          "this.is.a.common" = "anno-tation"
+        }
+      }
+    }
+
+    destination {
+      server    = "https://kubernetes.default.svc"
+      namespace = "default"
+    }
+  }
+
+  spec_override_json = %s
+}
+	`, name, override)
+}
+
 func testAccArgoCDApplicationDirectoryNoPath(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_application" "directory" {
@@ -2036,20 +3111,22 @@ resource "argocd_application" "crds" {
 func testAccArgoCDApplicationCustomNamespace(name string) string {
 	return fmt.Sprintf(`
 resource "argocd_project" "custom_namespace" {
-  metadata {
+  metadata = {
     name      = "%[1]s"
     namespace = "argocd"
   }
 
-  spec {
+  spec = {
     description  = "project with source namespace"
     source_repos = ["*"]
     source_namespaces = ["mynamespace-1"]
 
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "default"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "default"
+      },
+    ]
   }
 }
 
@@ -2060,7 +3137,7 @@ resource "argocd_application" "custom_namespace" {
   }
 
   spec {
-    project = argocd_project.custom_namespace.metadata[0].name
+    project = argocd_project.custom_namespace.metadata.name
     source {
       repo_url        = "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami"
       chart           = "redis"
@@ -2188,3 +3265,65 @@ resource "argocd_application" "helm_values_external" {
   }
 }`
 }
+
+func testAccArgoCDApplicationWaitSyncWindow(name string) string {
+	return fmt.Sprintf(`
+resource "argocd_project" "%[1]s" {
+  metadata = {
+    name      = "%[1]s"
+    namespace = "argocd"
+  }
+
+  spec = {
+    source_repos = ["*"]
+
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "*"
+      },
+    ]
+
+    sync_windows = [
+      {
+        kind         = "deny"
+        applications = ["*"]
+        clusters     = ["*"]
+        namespaces   = ["*"]
+        duration     = "24h"
+        schedule     = "* * * * *"
+      },
+    ]
+  }
+}
+
+resource "argocd_application" "%[1]s" {
+  metadata {
+    name      = "%[1]s"
+    namespace = "argocd"
+  }
+
+  spec {
+    project = argocd_project.%[1]s.metadata.name
+
+    source {
+      repo_url        = "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami"
+      chart           = "apache"
+      target_revision = "9.4.1"
+    }
+
+    sync_policy {
+      automated {}
+      sync_options = ["CreateNamespace=true"]
+    }
+
+    destination {
+      server    = "https://kubernetes.default.svc"
+      namespace = "%[1]s"
+    }
+  }
+
+  wait = true
+}
+`, name)
+}
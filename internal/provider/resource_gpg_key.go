@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/gpgkey"
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
@@ -13,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
-	"github.com/oboukili/terraform-provider-argocd/internal/sync"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -73,13 +71,13 @@ func (r *gpgKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Create GPG key
-	sync.GPGKeysMutex.Lock()
+	r.si.Locks.GPGKeys().Lock()
 
 	keys, err := r.si.GPGKeysClient.Create(ctx, &gpgkey.GnuPGPublicKeyCreateRequest{
 		Publickey: &v1alpha1.GnuPGPublicKey{KeyData: data.PublicKey.String()},
 	})
 
-	sync.GPGKeysMutex.Unlock()
+	r.si.Locks.GPGKeys().Unlock()
 
 	if err != nil {
 		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("create", "GPG key", "", err)...)
@@ -103,6 +101,12 @@ func (r *gpgKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	// The prior state is retained until the provider configuration is known
+	if !r.si.IsConfigKnown() {
+		tflog.Debug(ctx, fmt.Sprintf("provider configuration is unknown, skipping refresh of %s", data.ID.ValueString()))
+		return
+	}
+
 	// Initialize API clients
 	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
 
@@ -158,15 +162,15 @@ func (r *gpgKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	sync.GPGKeysMutex.Lock()
+	r.si.Locks.GPGKeys().Lock()
 
 	_, err := r.si.GPGKeysClient.Delete(ctx, &gpgkey.GnuPGPublicKeyQuery{
 		KeyID: data.ID.ValueString(),
 	})
 
-	sync.GPGKeysMutex.Unlock()
+	r.si.Locks.GPGKeys().Unlock()
 
-	if err != nil && !strings.Contains(err.Error(), "NotFound") {
+	if err != nil && !diagnostics.IsNotFound(err) {
 		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("delete", "GPG key", data.ID.ValueString(), err)...)
 		return
 	}
@@ -181,16 +185,16 @@ func (r *gpgKeyResource) ImportState(ctx context.Context, req resource.ImportSta
 func readGPGKey(ctx context.Context, si *ServerInterface, id string) (*gpgKeyModel, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	sync.GPGKeysMutex.RLock()
+	si.Locks.GPGKeys().RLock()
 
 	k, err := si.GPGKeysClient.Get(ctx, &gpgkey.GnuPGPublicKeyQuery{
 		KeyID: id,
 	})
 
-	sync.GPGKeysMutex.RUnlock()
+	si.Locks.GPGKeys().RUnlock()
 
 	if err != nil {
-		if !strings.Contains(err.Error(), "NotFound") {
+		if !diagnostics.IsNotFound(err) {
 			diags.Append(diagnostics.ArgoCDAPIError("read", "GPG key", id, err)...)
 		}
 
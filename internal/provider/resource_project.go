@@ -0,0 +1,556 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/account"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/v2/util/rbac"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
+	"github.com/oboukili/terraform-provider-argocd/internal/utils"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &projectResource{}
+	_ resource.ResourceWithImportState    = &projectResource{}
+	_ resource.ResourceWithModifyPlan     = &projectResource{}
+	_ resource.ResourceWithUpgradeState   = &projectResource{}
+	_ resource.ResourceWithValidateConfig = &projectResource{}
+)
+
+func NewProjectResource() resource.Resource {
+	return &projectResource{}
+}
+
+// projectResource defines the resource implementation.
+type projectResource struct {
+	si *ServerInterface
+}
+
+func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages [projects](https://argo-cd.readthedocs.io/en/stable/user-guide/projects/) within ArgoCD.\n\n" +
+			"**Note**: `metadata`, `spec` and the blocks nested within these are configured as attributes (e.g. `spec = { ... }`), with repeated blocks renamed to their plural form (e.g. `destination` is now `destinations`). State created by earlier versions of the provider is upgraded automatically.",
+		Version: 3,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ArgoCD project identifier (i.e. the name of the project).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": objectMetaSchemaAttribute("appprojects.argoproj.io", false),
+			"spec":     projectSpecSchemaAttribute(),
+		},
+	}
+}
+
+func (r *projectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	si, ok := req.ProviderData.(*ServerInterface)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *ServerInterface, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.si = si
+}
+
+// ValidateConfig validates the policies of each role against the name of the
+// project, so that diagnostics can be attached to the offending policy.
+func (r *projectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var name types.String
+
+	var roles types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("metadata").AtName("name"), &name)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec").AtName("roles"), &roles)...)
+
+	if resp.Diagnostics.HasError() || name.IsNull() || name.IsUnknown() || roles.IsNull() || roles.IsUnknown() {
+		return
+	}
+
+	for _, v := range roles.Elements() {
+		role, ok := v.(types.Object)
+		if !ok || role.IsNull() || role.IsUnknown() {
+			continue
+		}
+
+		roleName, _ := role.Attributes()["name"].(types.String)
+		policies, _ := role.Attributes()["policies"].(types.List)
+
+		if roleName.IsNull() || roleName.IsUnknown() || policies.IsNull() || policies.IsUnknown() {
+			continue
+		}
+
+		for i, p := range policies.Elements() {
+			policy, ok := p.(types.String)
+			if !ok || policy.IsNull() || policy.IsUnknown() {
+				continue
+			}
+
+			if err := validateProjectPolicy(name.ValueString(), roleName.ValueString(), policy.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("spec").AtName("roles").AtSetValue(v).AtName("policies").AtListIndex(i),
+					"Invalid policy",
+					err.Error(),
+				)
+			}
+		}
+	}
+}
+
+// ModifyPlan runs the validation performed by the ArgoCD API server (e.g. for
+// duplicate roles, policies or destinations) against the planned project, so
+// that invalid projects are reported at plan time rather than halfway through
+// an apply. The planned metadata is also validated once merged with the
+// default metadata of the provider, and the attributes guarded by features are
+// checked against the version of the server.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePlannedMetadata(ctx, r.si, req.Plan, path.Root("metadata"))...)
+	resp.Diagnostics.Append(checkFeatures(ctx, r.si, "argocd_project", req.Config)...)
+
+	var name types.String
+
+	var spec types.Object
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("metadata").AtName("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("spec"), &spec)...)
+
+	if resp.Diagnostics.HasError() || name.IsUnknown() || spec.IsNull() || !utils.IsFullyKnown(ctx, spec) {
+		return
+	}
+
+	var s projectSpec
+
+	resp.Diagnostics.Append(spec.As(ctx, &s, basetypes.ObjectAsOptions{})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p := &v1alpha1.AppProject{Spec: s.toAppProjectSpec()}
+	p.Name = name.ValueString()
+
+	if err := p.ValidateProject(); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("spec"), "Invalid project", status.Convert(err).Message())
+	}
+}
+
+// checkProjectPermission checks that the authenticated user is allowed to
+// perform `action` on the project named `name`, according to the RBAC policies
+// of the ArgoCD API server, so that a denied request is reported before any
+// change is made. Failing to check the permission only results in a warning,
+// since the server will enforce it regardless.
+func checkProjectPermission(ctx context.Context, client account.AccountServiceClient, action, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	resp, err := client.CanI(ctx, &account.CanIRequest{
+		Resource:    "projects",
+		Action:      action,
+		Subresource: name,
+	})
+	if err != nil {
+		diags.AddWarning(fmt.Sprintf("failed to check permission to %s project %s", action, name), status.Convert(err).Message())
+		return diags
+	}
+
+	if resp.Value != "yes" {
+		diags.AddAttributeError(
+			path.Root("metadata").AtName("name"),
+			"Permission denied",
+			fmt.Sprintf("the RBAC policies of the ArgoCD API server do not allow the authenticated user to %s project %s", action, name),
+		)
+	}
+
+	return diags
+}
+
+func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data projectModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p := data.toAppProject()
+	p.ObjectMeta = r.si.withDefaultMetadata(p.ObjectMeta)
+	projectName := p.Name
+
+	resp.Diagnostics.Append(checkFeatures(ctx, r.si, "argocd_project", req.Config)...)
+	resp.Diagnostics.Append(checkProjectPermission(ctx, r.si.AccountClient, "create", projectName)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.si.Locks.Project(projectName).Lock()
+
+	existing, err := r.si.ProjectClient.Get(ctx, &project.ProjectQuery{
+		Name: projectName,
+	})
+	if err != nil && !diagnostics.IsNotFound(err) {
+		r.si.Locks.Project(projectName).Unlock()
+
+		resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("failed to get existing project when creating project %s", projectName), err)...)
+
+		return
+	} else if existing != nil && existing.DeletionTimestamp != nil {
+		// Pre-existing project is still in Kubernetes soft deletion queue
+		if p := existing.DeletionGracePeriodSeconds; p != nil {
+			select {
+			case <-ctx.Done():
+				r.si.Locks.Project(projectName).Unlock()
+
+				resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("interrupted while waiting for the deletion of the existing project %s", projectName), ctx.Err())...)
+
+				return
+			case <-time.After(time.Duration(*p) * time.Second):
+			}
+		}
+	}
+
+	created, err := r.si.ProjectClient.Create(ctx, &project.ProjectCreateRequest{
+		Project: p,
+		Upsert:  false,
+	})
+
+	r.si.Locks.Project(projectName).Unlock()
+
+	if err != nil {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("create", "project", projectName, err)...)
+		return
+	} else if created == nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("project %s could not be created: unknown reason", projectName), "")
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created project %s", created.Name))
+
+	data.ID = types.StringValue(created.Name)
+	setAppliedProjectMetadata(&data, created)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data projectModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	// The prior state is retained until the provider configuration is known
+	if !r.si.IsConfigKnown() {
+		tflog.Debug(ctx, fmt.Sprintf("provider configuration is unknown, skipping refresh of %s", data.ID.ValueString()))
+		return
+	}
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p, diags := readProject(ctx, r.si, data.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if p == nil {
+		tflog.Trace(ctx, fmt.Sprintf("project %s no longer exists, removing from state", data.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	// Default and internal annotations and labels are only tracked if they are
+	// explicitly managed.
+	metadata := newObjectMeta(r.si.withoutDefaultMetadata(p.ObjectMeta, data.Metadata))
+
+	metadata.Annotations = metadataRemoveInternalKeys(metadata.Annotations, data.Metadata.Annotations)
+	metadata.Labels = metadataRemoveInternalKeys(metadata.Labels, data.Metadata.Labels)
+
+	// Distinguish values that are omitted by ArgoCD from those that have been
+	// explicitly set to their zero value.
+	spec := newProjectSpec(p.Spec)
+
+	utils.ReconcileWithPriorState(&metadata, &data.Metadata)
+	utils.ReconcileWithPriorState(spec, data.Spec)
+
+	data.ID = types.StringValue(p.Name)
+	data.Metadata = metadata
+	data.Spec = spec
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data projectModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p := data.toAppProject()
+	p.ObjectMeta = r.si.withDefaultMetadata(p.ObjectMeta)
+	projectName := p.Name
+
+	resp.Diagnostics.Append(checkFeatures(ctx, r.si, "argocd_project", req.Config)...)
+	resp.Diagnostics.Append(checkProjectPermission(ctx, r.si.AccountClient, "update", projectName)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.si.Locks.Project(projectName).Lock()
+
+	existing, err := r.si.ProjectClient.Get(ctx, &project.ProjectQuery{
+		Name: data.ID.ValueString(),
+	})
+	if err != nil {
+		r.si.Locks.Project(projectName).Unlock()
+
+		resp.Diagnostics.Append(diagnostics.Error(fmt.Sprintf("failed to get existing project when updating project %s", projectName), err)...)
+
+		return
+	}
+
+	// Kubernetes API requires providing the up-to-date correct ResourceVersion for updates
+	p.ResourceVersion = existing.ResourceVersion
+
+	// Preserve preexisting JWTs for managed roles
+	for i, role := range p.Spec.Roles {
+		pr, _, err := existing.GetRoleByName(role.Name)
+		if err != nil {
+			// The role does not exist yet, i.e. it was recently added
+			continue
+		}
+
+		p.Spec.Roles[i].JWTTokens = pr.JWTTokens
+	}
+
+	updated, err := r.si.ProjectClient.Update(ctx, &project.ProjectUpdateRequest{
+		Project: p,
+	})
+
+	r.si.Locks.Project(projectName).Unlock()
+
+	if err != nil {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("update", "project", projectName, err)...)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("updated project %s", projectName))
+
+	setAppliedProjectMetadata(&data, updated)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data projectModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	// Initialize API clients
+	resp.Diagnostics.Append(r.si.InitClients(ctx)...)
+
+	// Check for errors before proceeding
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectName := data.ID.ValueString()
+
+	r.si.Locks.Project(projectName).Lock()
+
+	_, err := r.si.ProjectClient.Delete(ctx, &project.ProjectQuery{Name: projectName})
+
+	r.si.Locks.Project(projectName).Unlock()
+
+	if err != nil && !diagnostics.IsNotFound(err) {
+		resp.Diagnostics.Append(diagnostics.ArgoCDAPIError("delete", "project", projectName, err)...)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("deleted project %s", projectName))
+}
+
+func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata").AtName("name"), req.ID)...)
+}
+
+// readProject returns the project with the given name, or nil if it does not
+// exist.
+func readProject(ctx context.Context, si *ServerInterface, name string) (*v1alpha1.AppProject, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	si.Locks.Project(name).RLock()
+	defer si.Locks.Project(name).RUnlock()
+
+	if p, ok := si.CachedProject(ctx, name); ok {
+		return p, diags
+	}
+
+	p, err := si.ProjectClient.Get(ctx, &project.ProjectQuery{
+		Name: name,
+	})
+
+	if err != nil {
+		if !diagnostics.IsNotFound(err) {
+			diags.Append(diagnostics.ArgoCDAPIError("read", "project", name, err)...)
+		}
+
+		return nil, diags
+	}
+
+	return p, diags
+}
+
+// setAppliedProjectMetadata sets the computed metadata attributes of the model
+// after the project has been created or updated. The spec is retained as
+// planned, any drift will be reported by the next refresh.
+func setAppliedProjectMetadata(data *projectModel, p *v1alpha1.AppProject) {
+	data.Metadata.Namespace = types.StringValue(p.Namespace)
+	data.Metadata.Generation = types.Int64Value(p.Generation)
+	data.Metadata.ResourceVersion = types.StringValue(p.ResourceVersion)
+	data.Metadata.UID = types.StringValue(string(p.UID))
+}
+
+// validateProjectPolicy validates that a policy of the given role is correctly
+// formatted and scoped to the project.
+func validateProjectPolicy(project string, role string, policy string) error {
+	policyComponents := strings.Split(policy, ",")
+	if len(policyComponents) != 6 || strings.Trim(policyComponents[0], " ") != "p" {
+		return fmt.Errorf("invalid policy rule '%s': must be of the form: 'p, sub, res, act, obj, eft'", policy)
+	}
+
+	// subject
+	subject := strings.Trim(policyComponents[1], " ")
+	expectedSubject := fmt.Sprintf("proj:%s:%s", project, role)
+
+	if subject != expectedSubject {
+		return fmt.Errorf("invalid policy rule '%s': policy subject must be: '%s', not '%s'", policy, expectedSubject, subject)
+	}
+
+	// resource
+	// https://github.com/argoproj/argo-cd/blob/c99669e088b5f25c8ce8faff6df25797a8beb5ba/pkg/apis/application/v1alpha1/types.go#L1554
+	validResources := map[string]bool{
+		rbac.ResourceApplications: true,
+		rbac.ResourceRepositories: true,
+		rbac.ResourceClusters:     true,
+		rbac.ResourceExec:         true,
+		rbac.ResourceLogs:         true,
+	}
+
+	resource := strings.Trim(policyComponents[2], " ")
+	if !validResources[resource] {
+		return fmt.Errorf("invalid policy rule '%s': resource '%s' not recognised", policy, resource)
+	}
+
+	// action
+	action := strings.Trim(policyComponents[3], " ")
+	if !isValidProjectPolicyAction(action) {
+		return fmt.Errorf("invalid policy rule '%s': invalid action '%s'", policy, action)
+	}
+
+	// object
+	object := strings.Trim(policyComponents[4], " ")
+
+	objectRegexp, err := regexp.Compile(fmt.Sprintf(`^%s/[*\w-.]+$`, project))
+	if err != nil || !objectRegexp.MatchString(object) {
+		return fmt.Errorf("invalid policy rule '%s': object must be of form '%s/*' or '%s/<APPNAME>', not '%s'", policy, project, project, object)
+	}
+
+	// effect
+	effect := strings.Trim(policyComponents[5], " ")
+	if effect != "allow" && effect != "deny" {
+		return fmt.Errorf("invalid policy rule '%s': effect must be: 'allow' or 'deny'", policy)
+	}
+
+	return nil
+}
+
+func isValidProjectPolicyAction(action string) bool {
+	validActions := map[string]bool{
+		rbac.ActionGet:      true,
+		rbac.ActionCreate:   true,
+		rbac.ActionUpdate:   true,
+		rbac.ActionDelete:   true,
+		rbac.ActionSync:     true,
+		rbac.ActionOverride: true,
+		"*":                 true,
+	}
+	validActionPatterns := []*regexp.Regexp{
+		regexp.MustCompile("action/.*"),
+	}
+
+	if validActions[action] {
+		return true
+	}
+
+	for i := range validActionPatterns {
+		if validActionPatterns[i].MatchString(action) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/oboukili/terraform-provider-argocd/internal/utils"
+)
+
+// projectSDKStateAliases maps attributes to the names of the blocks they
+// replace in the state of the SDK implementation of the resource.
+var projectSDKStateAliases = map[string]string{
+	"destinations": "destination",
+	"roles":        "role",
+	"sync_windows": "sync_window",
+}
+
+func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgrader := resource.StateUpgrader{
+		StateUpgrader: r.upgradeSDKState,
+	}
+
+	// The versions of the state of the SDK implementation of the resource only
+	// differ in how `orphaned_resources` was stored (a map, a set or a list of
+	// a single object), all of which are handled by the same upgrader.
+	return map[int64]resource.StateUpgrader{
+		0: upgrader,
+		1: upgrader,
+		2: upgrader,
+	}
+}
+
+// upgradeSDKState upgrades the state of the SDK implementation of the resource,
+// in which nested attributes were stored as lists of blocks.
+func (r *projectResource) upgradeSDKState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("failed to upgrade project state", "missing raw state")
+		return
+	}
+
+	sr := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, sr)
+
+	typ := sr.Schema.Type().TerraformType(ctx)
+
+	upgraded, err := utils.UpgradeSDKState(req.RawState.JSON, typ, projectSDKStateAliases)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to upgrade project state", err.Error())
+		return
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(upgraded, &state); err != nil {
+		resp.Diagnostics.AddError("failed to upgrade project state", err.Error())
+		return
+	}
+
+	// Sync windows created prior to the introduction of `timezone` are
+	// evaluated in UTC.
+	if spec, ok := state["spec"].(map[string]interface{}); ok {
+		windows, _ := spec["sync_windows"].([]interface{})
+		for _, w := range windows {
+			if window, ok := w.(map[string]interface{}); ok && window["timezone"] == nil {
+				window["timezone"] = "UTC"
+			}
+		}
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("failed to upgrade project state", err.Error())
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: b}
+}
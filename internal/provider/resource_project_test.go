@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/account"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateProjectPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy string
+		valid  bool
+	}{
+		{
+			name:   "valid policy",
+			policy: "p, proj:myproject:myrole, applications, get, myproject/*, allow",
+			valid:  true,
+		},
+		{
+			name:   "valid policy with custom action",
+			policy: "p, proj:myproject:myrole, applications, action/apps/Deployment/restart, myproject/myapp, deny",
+			valid:  true,
+		},
+		{
+			name:   "too few components",
+			policy: "p, proj:myproject:myrole, applications, get, myproject/*",
+		},
+		{
+			name:   "not a policy",
+			policy: "g, proj:myproject:myrole, applications, get, myproject/*, allow",
+		},
+		{
+			name:   "wrong subject",
+			policy: "p, proj:myproject:otherrole, applications, get, myproject/*, allow",
+		},
+		{
+			name:   "unknown resource",
+			policy: "p, proj:myproject:myrole, accounts, get, myproject/*, allow",
+		},
+		{
+			name:   "unknown action",
+			policy: "p, proj:myproject:myrole, applications, list, myproject/*, allow",
+		},
+		{
+			name:   "object from another project",
+			policy: "p, proj:myproject:myrole, applications, get, otherproject/*, allow",
+		},
+		{
+			name:   "invalid effect",
+			policy: "p, proj:myproject:myrole, applications, get, myproject/*, maybe",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateProjectPolicy("myproject", "myrole", tt.policy)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestProjectResourceUpgradeState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		version  int64
+		state    string
+		expected string
+	}{
+		{
+			name:    "v0 with orphaned_resources map",
+			version: 0,
+			state: `{
+  "id": "myproject:default",
+  "metadata": [{"name": "myproject", "namespace": "argocd", "generation": 1, "resource_version": "123", "uid": "abc", "labels": {}, "annotations": {}}],
+  "spec": [{
+    "description": "",
+    "source_repos": ["*"],
+    "destination": [{"server": "https://kubernetes.default.svc", "namespace": "*", "name": ""}],
+    "orphaned_resources": {"warn": true},
+    "role": [],
+    "signature_keys": [],
+    "sync_window": [],
+    "cluster_resource_whitelist": [],
+    "cluster_resource_blacklist": [],
+    "namespace_resource_whitelist": [],
+    "namespace_resource_blacklist": []
+  }]
+}`,
+			expected: `{
+  "id": "myproject:default",
+  "metadata": {"name": "myproject", "namespace": "argocd", "generation": 1, "resource_version": "123", "uid": "abc", "labels": null, "annotations": null},
+  "spec": {
+    "description": null,
+    "source_repos": ["*"],
+    "source_namespaces": null,
+    "destinations": [{"server": "https://kubernetes.default.svc", "namespace": "*", "name": null}],
+    "orphaned_resources": {"warn": true, "ignore": null},
+    "roles": null,
+    "signature_keys": null,
+    "sync_windows": null,
+    "cluster_resource_whitelist": null,
+    "cluster_resource_blacklist": null,
+    "namespace_resource_whitelist": null,
+    "namespace_resource_blacklist": null
+  }
+}`,
+		},
+		{
+			name:    "v1 with orphaned_resources set",
+			version: 1,
+			state: `{
+  "id": "myproject:default",
+  "metadata": [{"name": "myproject", "namespace": "argocd", "generation": 2, "resource_version": "456", "uid": "abc"}],
+  "spec": [{
+    "source_repos": ["*"],
+    "destination": [{"server": "https://kubernetes.default.svc", "namespace": "default"}],
+    "orphaned_resources": [{"warn": false, "ignore": [{"group": "apps", "kind": "Deployment", "name": "foo"}]}],
+    "role": [{"name": "myrole", "description": "", "groups": ["admins"], "policies": ["p, proj:myproject:myrole, applications, get, myproject/*, allow"], "jwt_tokens": [{"iat": 1620000000, "exp": 0}]}],
+    "sync_window": [{"kind": "allow", "applications": ["*"], "clusters": [], "namespaces": [], "duration": "1h", "schedule": "10 1 * * *", "manual_sync": false}]
+  }]
+}`,
+			expected: `{
+  "id": "myproject:default",
+  "metadata": {"name": "myproject", "namespace": "argocd", "generation": 2, "resource_version": "456", "uid": "abc", "labels": null, "annotations": null},
+  "spec": {
+    "description": null,
+    "source_repos": ["*"],
+    "source_namespaces": null,
+    "destinations": [{"server": "https://kubernetes.default.svc", "namespace": "default", "name": null}],
+    "orphaned_resources": {"warn": null, "ignore": [{"group": "apps", "kind": "Deployment", "name": "foo"}]},
+    "roles": [{"name": "myrole", "description": null, "groups": ["admins"], "policies": ["p, proj:myproject:myrole, applications, get, myproject/*, allow"]}],
+    "signature_keys": null,
+    "sync_windows": [{"kind": "allow", "applications": ["*"], "clusters": null, "namespaces": null, "duration": "1h", "schedule": "10 1 * * *", "manual_sync": null, "timezone": "UTC"}],
+    "cluster_resource_whitelist": null,
+    "cluster_resource_blacklist": null,
+    "namespace_resource_whitelist": null,
+    "namespace_resource_blacklist": null
+  }
+}`,
+		},
+		{
+			name:    "v2 with timezone",
+			version: 2,
+			state: `{
+  "id": "myproject:default",
+  "metadata": [{"name": "myproject", "namespace": "argocd", "generation": 3, "resource_version": "789", "uid": "abc"}],
+  "spec": [{
+    "source_repos": ["*"],
+    "source_namespaces": ["apps-*"],
+    "destination": [{"name": "in-cluster", "namespace": "default"}],
+    "orphaned_resources": [],
+    "sync_window": [{"kind": "deny", "applications": ["*"], "duration": "30m", "schedule": "0 22 * * *", "manual_sync": true, "timezone": "Europe/London"}]
+  }]
+}`,
+			expected: `{
+  "id": "myproject:default",
+  "metadata": {"name": "myproject", "namespace": "argocd", "generation": 3, "resource_version": "789", "uid": "abc", "labels": null, "annotations": null},
+  "spec": {
+    "description": null,
+    "source_repos": ["*"],
+    "source_namespaces": ["apps-*"],
+    "destinations": [{"server": null, "namespace": "default", "name": "in-cluster"}],
+    "orphaned_resources": null,
+    "roles": null,
+    "signature_keys": null,
+    "sync_windows": [{"kind": "deny", "applications": ["*"], "clusters": null, "namespaces": null, "duration": "30m", "schedule": "0 22 * * *", "manual_sync": true, "timezone": "Europe/London"}],
+    "cluster_resource_whitelist": null,
+    "cluster_resource_blacklist": null,
+    "namespace_resource_whitelist": null,
+    "namespace_resource_blacklist": null
+  }
+}`,
+		},
+	}
+
+	ctx := context.Background()
+	r := &projectResource{}
+
+	sr := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, sr)
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			upgrader, ok := r.UpgradeState(ctx)[tt.version]
+			assert.True(t, ok)
+
+			resp := &resource.UpgradeStateResponse{}
+			upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(tt.state)}}, resp)
+			assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// Ensure the upgraded state conforms to the current schema.
+			_, err := resp.DynamicValue.Unmarshal(sr.Schema.Type().TerraformType(ctx))
+			assert.NoError(t, err)
+
+			assert.JSONEq(t, tt.expected, string(resp.DynamicValue.JSON))
+		})
+	}
+}
+
+// fakeCanIAccountServer allows any action on the project named "allowed", and
+// fails to check permissions on the project named "unavailable".
+type fakeCanIAccountServer struct {
+	account.UnimplementedAccountServiceServer
+}
+
+func (s *fakeCanIAccountServer) CanI(_ context.Context, req *account.CanIRequest) (*account.CanIResponse, error) {
+	if req.Resource != "projects" {
+		return nil, status.Error(codes.InvalidArgument, "unexpected resource")
+	}
+
+	switch req.Subresource {
+	case "allowed":
+		return &account.CanIResponse{Value: "yes"}, nil
+	case "unavailable":
+		return nil, status.Error(codes.Unimplemented, "unknown method")
+	}
+
+	return &account.CanIResponse{Value: "no"}, nil
+}
+
+func TestCheckProjectPermission(t *testing.T) {
+	t.Parallel()
+
+	conn := startFakeServer(t, func(s *grpc.Server) {
+		account.RegisterAccountServiceServer(s, &fakeCanIAccountServer{})
+	})
+
+	client := account.NewAccountServiceClient(conn)
+
+	tests := []struct {
+		name          string
+		project       string
+		expectError   bool
+		expectWarning bool
+	}{
+		{
+			name:    "allowed",
+			project: "allowed",
+		},
+		{
+			name:        "denied",
+			project:     "denied",
+			expectError: true,
+		},
+		{
+			name:          "check failed",
+			project:       "unavailable",
+			expectWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := checkProjectPermission(context.Background(), client, "create", tt.project)
+
+			assert.Equal(t, tt.expectError, diags.HasError(), diags)
+			assert.Equal(t, tt.expectWarning, diags.WarningsCount() > 0, diags)
+		})
+	}
+}
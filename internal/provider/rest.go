@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// restClient sends requests to the REST API of the ArgoCD API server (i.e. its
+// gRPC gateway). It is only used where the gRPC API cannot be, e.g. to send
+// fields that are unknown to the bundled ArgoCD API client, which can only be
+// represented in JSON.
+type restClient struct {
+	baseURL string
+	client  *http.Client
+	creds   credentials.PerRPCCredentials
+}
+
+// newRESTClient returns a client of the REST API of the ArgoCD API server,
+// authenticating requests using `creds`.
+func (o *connOptions) newRESTClient(creds credentials.PerRPCCredentials) *restClient {
+	scheme := "https"
+	if o.plainText {
+		scheme = "http"
+	}
+
+	baseURL := fmt.Sprintf("%s://%s", scheme, o.serverAddr)
+	if rootPath := strings.Trim(o.grpcWebRootPath, "/"); rootPath != "" {
+		baseURL += "/" + rootPath
+	}
+
+	return &restClient{
+		baseURL: baseURL,
+		client:  o.httpClient,
+		creds:   creds,
+	}
+}
+
+// do sends a request to the REST API and returns the body of the response.
+// Errors are returned as gRPC status errors, as with the gRPC API.
+func (c *restClient) do(ctx context.Context, method, path string, query url.Values, body []byte) ([]byte, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := c.creds.GetRequestMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := md[apiclient.MetaDataTokenKey]; token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, restError(resp.StatusCode, b)
+	}
+
+	return b, nil
+}
+
+// restError returns the gRPC status error described by the body of an error
+// response of the REST API, falling back to a code derived from the HTTP
+// status code.
+func restError(statusCode int, body []byte) error {
+	var e struct {
+		Code    *int32 `json:"code"`
+		Message string `json:"message"`
+	}
+
+	if err := json.Unmarshal(body, &e); err == nil && e.Code != nil {
+		return status.Error(codes.Code(*e.Code), e.Message)
+	}
+
+	code := codes.Unknown
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		code = codes.Unavailable
+	}
+
+	return status.Errorf(code, "%s: %s", http.StatusText(statusCode), bytes.TrimSpace(body))
+}
+
+// restRequest sends a request to the REST API of the ArgoCD API server. As for
+// requests sent using the gRPC API, the authentication token is renewed once
+// the server rejects it, in which case the request is retried.
+func (si *ServerInterface) restRequest(ctx context.Context, method, path string, query url.Values, body []byte) ([]byte, error) {
+	var current *sessionToken
+	if si.session != nil {
+		current = si.session.current.Load()
+	}
+
+	b, err := si.rest.do(ctx, method, path, query, body)
+	if status.Code(err) != codes.Unauthenticated || si.session == nil {
+		return b, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("renewing authentication token after %s %s failed with: %s", method, path, err))
+
+	if rerr := si.renewSession(ctx, current); rerr != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to renew authentication token: %s", rerr))
+		return b, err
+	}
+
+	return si.rest.do(ctx, method, path, query, body)
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRestClient_do(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer foo" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":16,"message":"invalid session: token is expired"}`))
+
+			return
+		}
+
+		assert.Equal(t, "/argocd/api/v1/applications/foo", r.URL.Path)
+		assert.Equal(t, "argocd", r.URL.Query().Get("appNamespace"))
+		assert.Equal(t, "HiThere", r.Header.Get("Hello"))
+		assert.Equal(t, "terraform-provider-argocd/test", r.Header.Get("User-Agent"))
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		_, _ = w.Write(b)
+	}))
+	t.Cleanup(srv.Close)
+
+	o, err := newConnOptions(&apiclient.ClientOptions{
+		ServerAddr:      srv.Listener.Addr().String(),
+		PlainText:       true,
+		GRPCWebRootPath: "/argocd/",
+		Headers:         []string{"Hello:HiThere"},
+		UserAgent:       "terraform-provider-argocd/test",
+	}, clientTLSData{}, nil)
+	require.NoError(t, err)
+
+	for token, expectedCode := range map[string]codes.Code{"foo": codes.OK, "bar": codes.Unauthenticated} {
+		b, err := o.newRESTClient(tokenCredentials(token)).do(context.Background(), http.MethodPut, "/api/v1/applications/foo", url.Values{"appNamespace": {"argocd"}}, []byte(`{"spec":{"notAField":true}}`))
+		assert.Equal(t, expectedCode, status.Code(err), "%v", err)
+
+		if expectedCode == codes.OK {
+			assert.JSONEq(t, `{"spec":{"notAField":true}}`, string(b))
+		} else {
+			assert.Contains(t, err.Error(), "token is expired")
+		}
+	}
+}
+
+func TestRestError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         string
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "gateway error",
+			statusCode:   http.StatusNotFound,
+			body:         `{"code":5,"message":"applications.argoproj.io \"foo\" not found"}`,
+			expectedCode: codes.NotFound,
+			expectedMsg:  `applications.argoproj.io "foo" not found`,
+		},
+		{
+			name:         "gateway error with conflicting HTTP status",
+			statusCode:   http.StatusBadRequest,
+			body:         `{"code":9,"message":"existing application spec is different"}`,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "existing application spec is different",
+		},
+		{
+			name:         "proxy error",
+			statusCode:   http.StatusBadGateway,
+			body:         "upstream connect error\n",
+			expectedCode: codes.Unavailable,
+			expectedMsg:  "Bad Gateway: upstream connect error",
+		},
+		{
+			name:         "unknown status",
+			statusCode:   http.StatusTeapot,
+			body:         "",
+			expectedCode: codes.Unknown,
+			expectedMsg:  "I'm a teapot: ",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := restError(tt.statusCode, []byte(tt.body))
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedMsg, status.Convert(err).Message())
+		})
+	}
+}
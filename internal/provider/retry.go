@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 1 * time.Second
+	defaultRetryMaxBackoff     = 30 * time.Second
+)
+
+// retryableCodes are the gRPC status codes of errors that are considered to be
+// transient, e.g. the server being temporarily unreachable or overloaded.
+var retryableCodes = map[codes.Code]bool{
+	codes.Aborted:           true,
+	codes.ResourceExhausted: true,
+	codes.Unavailable:       true,
+}
+
+type retryOptions struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func defaultRetryOptions() retryOptions {
+	return retryOptions{
+		maxAttempts:    defaultRetryMaxAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     defaultRetryMaxBackoff,
+	}
+}
+
+func isRetryableError(err error) bool {
+	s, ok := status.FromError(err)
+
+	return ok && retryableCodes[s.Code()]
+}
+
+// isRetryableCall returns whether a call to `method` that failed with `err`
+// can safely be retried. Calls to read-only methods are idempotent, whereas
+// calls to other methods are only retried if they failed because the server was
+// unavailable before the request was sent, i.e. before a stream to the server
+// was established (in which case `p` holds no address).
+func isRetryableCall(method string, err error, p *peer.Peer) bool {
+	if !isRetryableError(err) {
+		return false
+	}
+
+	_, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if isReadOnlyMethod(name) {
+		return true
+	}
+
+	return status.Code(err) == codes.Unavailable && p.Addr == nil
+}
+
+// retryUnaryClientInterceptor returns a gRPC client interceptor that retries
+// calls failing with a transient error, as long as they can safely be retried
+// (see isRetryableCall), waiting for an exponentially increasing amount of time
+// between attempts.
+func retryUnaryClientInterceptor(o retryOptions) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := o.initialBackoff
+
+		for attempt := 1; ; attempt++ {
+			var p peer.Peer
+
+			err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+			if err == nil || attempt >= o.maxAttempts || !isRetryableCall(method, err, &p) {
+				return err
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("retrying %s in %s after transient error (attempt %d of %d): %s", method, backoff, attempt, o.maxAttempts, err))
+
+			t := time.NewTimer(backoff)
+
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return err
+			case <-t.C:
+			}
+
+			backoff *= 2
+			if backoff > o.maxBackoff {
+				backoff = o.maxBackoff
+			}
+		}
+	}
+}
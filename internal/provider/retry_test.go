@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVersionServer fails the first `failures` calls with `code`.
+type fakeVersionServer struct {
+	version.UnimplementedVersionServiceServer
+
+	code     codes.Code
+	failures int32
+	calls    atomic.Int32
+}
+
+func (s *fakeVersionServer) Version(context.Context, *empty.Empty) (*version.VersionMessage, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.code, "fake error")
+	}
+
+	return &version.VersionMessage{Version: "v2.9.9"}, nil
+}
+
+// startFakeVersionServer starts `srv` on a local port and returns a connection
+// to it, established with the given dial options.
+func startFakeVersionServer(t *testing.T, srv *fakeVersionServer, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+
+	return startFakeServer(t, func(s *grpc.Server) {
+		version.RegisterVersionServiceServer(s, srv)
+	}, opts...)
+}
+
+// startFakeServer starts a gRPC server, with the services registered by
+// `register`, on a local port and returns a connection to it, established with
+// the given dial options.
+func startFakeServer(t *testing.T, register func(*grpc.Server), opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	register(s)
+
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(l.Addr().String(), append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func TestRetryUnaryClientInterceptor(t *testing.T) {
+	t.Parallel()
+
+	opts := retryOptions{
+		maxAttempts:    3,
+		initialBackoff: time.Millisecond,
+		maxBackoff:     2 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name          string
+		code          codes.Code
+		failures      int32
+		expectedCalls int32
+		expectedCode  codes.Code
+	}{
+		{
+			name:          "succeeds without retries",
+			failures:      0,
+			expectedCalls: 1,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "retries unavailable",
+			code:          codes.Unavailable,
+			failures:      2,
+			expectedCalls: 3,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "retries resource exhausted",
+			code:          codes.ResourceExhausted,
+			failures:      1,
+			expectedCalls: 2,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "retries aborted",
+			code:          codes.Aborted,
+			failures:      2,
+			expectedCalls: 3,
+			expectedCode:  codes.OK,
+		},
+		{
+			name:          "gives up after max attempts",
+			code:          codes.Unavailable,
+			failures:      5,
+			expectedCalls: 3,
+			expectedCode:  codes.Unavailable,
+		},
+		{
+			name:          "does not retry not found",
+			code:          codes.NotFound,
+			failures:      1,
+			expectedCalls: 1,
+			expectedCode:  codes.NotFound,
+		},
+		{
+			name:          "does not retry invalid argument",
+			code:          codes.InvalidArgument,
+			failures:      1,
+			expectedCalls: 1,
+			expectedCode:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := &fakeVersionServer{code: tt.code, failures: tt.failures}
+			conn := startFakeVersionServer(t, srv, grpc.WithUnaryInterceptor(retryUnaryClientInterceptor(opts)))
+
+			_, err := version.NewVersionServiceClient(conn).Version(context.Background(), &empty.Empty{})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			assert.Equal(t, tt.expectedCalls, srv.calls.Load())
+		})
+	}
+}
+
+func TestRetryUnaryClientInterceptor_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	srv := &fakeVersionServer{code: codes.Unavailable, failures: 5}
+	conn := startFakeVersionServer(t, srv, grpc.WithUnaryInterceptor(retryUnaryClientInterceptor(retryOptions{
+		maxAttempts:    5,
+		initialBackoff: time.Hour,
+		maxBackoff:     time.Hour,
+	})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := version.NewVersionServiceClient(conn).Version(ctx, &empty.Empty{})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), srv.calls.Load())
+}
+
+// fakeDeleteSessionServer fails the first `failures` calls to delete the
+// session with `code`.
+type fakeDeleteSessionServer struct {
+	session.UnimplementedSessionServiceServer
+
+	code     codes.Code
+	failures int32
+	calls    atomic.Int32
+}
+
+func (s *fakeDeleteSessionServer) Delete(context.Context, *session.SessionDeleteRequest) (*session.SessionResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.code, "fake error")
+	}
+
+	return &session.SessionResponse{}, nil
+}
+
+func TestRetryUnaryClientInterceptor_NonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	opts := retryOptions{
+		maxAttempts:    3,
+		initialBackoff: time.Millisecond,
+		maxBackoff:     2 * time.Millisecond,
+	}
+
+	// Requests that reached the server are not retried
+	srv := &fakeDeleteSessionServer{code: codes.Unavailable, failures: 1}
+	conn := startFakeServer(t, func(s *grpc.Server) {
+		session.RegisterSessionServiceServer(s, srv)
+	}, grpc.WithUnaryInterceptor(retryUnaryClientInterceptor(opts)))
+
+	_, err := session.NewSessionServiceClient(conn).Delete(context.Background(), &session.SessionDeleteRequest{})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int32(1), srv.calls.Load())
+}
+
+func TestIsRetryableCall(t *testing.T) {
+	t.Parallel()
+
+	sent := &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 443}}
+	notSent := &peer.Peer{}
+
+	tests := []struct {
+		method   string
+		err      error
+		p        *peer.Peer
+		expected bool
+	}{
+		{"/application.ApplicationService/Get", status.Error(codes.Unavailable, ""), sent, true},
+		{"/application.ApplicationService/List", status.Error(codes.Aborted, ""), sent, true},
+		{"/version.VersionService/Version", status.Error(codes.ResourceExhausted, ""), sent, true},
+		{"/application.ApplicationService/Get", status.Error(codes.NotFound, ""), sent, false},
+		{"/application.ApplicationService/Create", status.Error(codes.Unavailable, ""), notSent, true},
+		{"/application.ApplicationService/Create", status.Error(codes.Unavailable, ""), sent, false},
+		{"/application.ApplicationService/Update", status.Error(codes.Aborted, ""), notSent, false},
+		{"/application.ApplicationService/Delete", status.Error(codes.ResourceExhausted, ""), sent, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, isRetryableCall(tt.method, tt.err, tt.p), "%s: %s (sent: %t)", tt.method, tt.err, tt.p.Addr != nil)
+	}
+}
+
+func TestArgoCDProviderConfig_getRetryOptions(t *testing.T) {
+	t.Parallel()
+
+	opts, diags := ArgoCDProviderConfig{}.getRetryOptions()
+	assert.False(t, diags.HasError())
+	assert.Equal(t, defaultRetryOptions(), opts)
+
+	opts, diags = ArgoCDProviderConfig{
+		Retry: []Retry{
+			{
+				MaxAttempts:    types.Int64Value(5),
+				InitialBackoff: types.StringValue("500ms"),
+				MaxBackoff:     types.StringNull(),
+			},
+		},
+	}.getRetryOptions()
+	assert.False(t, diags.HasError())
+	assert.Equal(t, retryOptions{
+		maxAttempts:    5,
+		initialBackoff: 500 * time.Millisecond,
+		maxBackoff:     defaultRetryMaxBackoff,
+	}, opts)
+}
@@ -19,21 +19,26 @@ import (
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repocreds"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/repository"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/settings"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
-	"github.com/argoproj/argo-cd/v2/util/io"
+	"github.com/argoproj/argo-cd/v2/util/localconfig"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/oboukili/terraform-provider-argocd/internal/diagnostics"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
+	argocdSync "github.com/oboukili/terraform-provider-argocd/internal/sync"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
-var runtimeErrorHandlers []func(error)
+var runtimeErrorHandlers []runtime.ErrorHandler
 
 type ServerInterface struct {
 	AccountClient        account.AccountServiceClient
-	ApiClient            apiclient.Client
 	ApplicationClient    application.ApplicationServiceClient
 	ApplicationSetClient applicationset.ApplicationSetServiceClient
 	CertificateClient    certificate.CertificateServiceClient
@@ -43,19 +48,51 @@ type ServerInterface struct {
 	RepoCredsClient      repocreds.RepoCredsServiceClient
 	RepositoryClient     repository.RepositoryServiceClient
 	SessionClient        session.SessionServiceClient
+	SettingsClient       settings.SettingsServiceClient
 
 	ServerVersion        *semver.Version
 	ServerVersionMessage *version.VersionMessage
 
+	// Locks is used to handle concurrent access to ArgoCD objects that are
+	// shared between resources. It is set by InitClients, once the target of
+	// the connection is known.
+	Locks *argocdSync.LockManager
+
 	config      ArgoCDProviderConfig
 	initialized bool
+	readCache   *readCache
+	rest        *restClient
+	session     *userSession
 	sync.RWMutex
+
+	// kubeClientConfig is the configuration of the Kubernetes API client, set
+	// in core mode or when port forwarding.
+	kubeClientConfig clientcmd.ClientConfig
+
+	// probeMu guards the results of the runtime feature probes and the
+	// settings of the server they rely on.
+	probeMu        sync.Mutex
+	probes         map[features.Feature]probeResult
+	serverSettings *settings.Settings
 }
 
 func NewServerInterface(c ArgoCDProviderConfig) *ServerInterface {
-	return &ServerInterface{
+	si := &ServerInterface{
 		config: c,
 	}
+
+	if c.ReadCache.ValueBool() {
+		si.readCache = newReadCache(readCacheTTL)
+	}
+
+	return si
+}
+
+// IsConfigKnown returns whether the provider configuration is fully known. It
+// is not while planning changes to resources that the configuration depends
+// on, in which case no request can be sent to the ArgoCD API server.
+func (si *ServerInterface) IsConfigKnown() bool {
+	return !si.config.Unknown
 }
 
 func (si *ServerInterface) InitClients(ctx context.Context) diag.Diagnostics {
@@ -66,104 +103,202 @@ func (si *ServerInterface) InitClients(ctx context.Context) diag.Diagnostics {
 		return nil
 	}
 
+	if si.config.Unknown {
+		var diags diag.Diagnostics
+
+		diags.AddError(
+			"Unknown provider configuration",
+			"The provider configuration depends on values that are not known yet (e.g. the address of an ArgoCD API server installed during the same apply), so the ArgoCD API server cannot be reached until apply. "+
+				"Data sources must depend on the resources that the provider configuration is derived from.",
+		)
+
+		return diags
+	}
+
 	opts, d := si.config.getApiClientOptions(ctx)
 	if d.HasError() {
 		return d
 	}
 
-	ac, err := apiclient.NewClient(opts)
+	if opts.Core || opts.PortForward || opts.PortForwardNamespace != "" {
+		overrides := opts.KubeOverrides
+		if overrides == nil {
+			overrides = &clientcmd.ConfigOverrides{}
+		}
+
+		si.kubeClientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), overrides)
+	}
+
+	// Locks are keyed by the target of the connection so that they are shared
+	// with any other provider instance targeting the same server.
+	target, err := si.lockTarget(opts)
 	if err != nil {
-		return diagnostics.Error("failed to create new API client", err)
+		return diagnostics.Error("failed to resolve ArgoCD API server", err)
 	}
 
-	var diags diag.Diagnostics
+	si.Locks = argocdSync.NewLockManager(target)
 
-	_, si.AccountClient, err = ac.NewAccountClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize account client", err)...)
+	tlsData, d := si.config.getClientTLSData()
+	if d.HasError() {
+		return d
 	}
 
-	_, si.ApplicationClient, err = ac.NewApplicationClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize application client", err)...)
+	proxyOpts, d := si.config.getProxyOptions()
+	if d.HasError() {
+		return d
 	}
 
-	_, si.ApplicationSetClient, err = ac.NewApplicationSetClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize application set client", err)...)
+	retryOpts, d := si.config.getRetryOptions()
+	if d.HasError() {
+		return d
 	}
 
-	_, si.CertificateClient, err = ac.NewCertClient()
+	connOpts, err := newConnOptions(opts, tlsData, proxyOpts)
 	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize certificate client", err)...)
+		return diagnostics.Error("failed to resolve ArgoCD API server connection options", err)
 	}
 
-	_, si.ClusterClient, err = ac.NewClusterClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize cluster client", err)...)
+	// Obtain the authentication token, either from the `auth_exec` command
+	// or in exchange for the username and password, which is renewed whenever
+	// it expires.
+	newToken, d := si.config.getTokenSource(ctx, opts, connOpts)
+	if d.HasError() {
+		return d
 	}
 
-	_, si.GPGKeysClient, err = ac.NewGPGKeyClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize GPG keys client", err)...)
+	// Requests are authenticated using the latest token of the session, if
+	// any.
+	var creds credentials.PerRPCCredentials = tokenCredentials(connOpts.authToken)
+
+	if newToken != nil {
+		token, expiresAt, err := newToken(ctx)
+		if err != nil {
+			return diagnostics.Error("failed to obtain authentication token", err)
+		}
+
+		si.session = newUserSession(newToken, token, expiresAt)
+		creds = si.session
 	}
 
-	_, si.ProjectClient, err = ac.NewProjectClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize project client", err)...)
+	// Interceptors are called in the order they are installed in.
+	var interceptors []grpc.UnaryClientInterceptor
+
+	// Discard snapshots of the read cache once objects have been modified,
+	// including after all retries.
+	if si.readCache != nil {
+		interceptors = append(interceptors, readCacheUnaryClientInterceptor(si.readCache))
 	}
 
-	_, si.RepositoryClient, err = ac.NewRepoClient()
-	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize repository client", err)...)
+	// Retry requests that fail with a transient error
+	if retryOpts.maxAttempts > 1 {
+		interceptors = append(interceptors, retryUnaryClientInterceptor(retryOpts))
+	}
+
+	// Limit the number and rate of requests sent to the server, including any
+	// retries.
+	if limitOpts := si.config.getRequestLimitOptions(); limitOpts.enabled() {
+		interceptors = append(interceptors, requestLimitUnaryClientInterceptor(limitOpts))
+	}
+
+	// Renew the authentication token once it has expired. It is called last
+	// so that each attempt uses the latest token.
+	if si.session != nil {
+		interceptors = append(interceptors, sessionUnaryClientInterceptor(si))
 	}
 
-	_, si.RepoCredsClient, err = ac.NewRepoCredsClient()
+	// The connection is used for the lifetime of the provider, hence never
+	// closed.
+	conn, _, err := connOpts.dialServer(ctx, creds, interceptors...)
 	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize repository credentials client", err)...)
+		return diagnostics.Error("failed to connect to ArgoCD API server", err)
 	}
 
-	_, si.SessionClient, err = ac.NewSessionClient()
+	si.rest = connOpts.newRESTClient(creds)
+
+	si.AccountClient = account.NewAccountServiceClient(conn)
+	si.ApplicationClient = application.NewApplicationServiceClient(conn)
+	si.ApplicationSetClient = applicationset.NewApplicationSetServiceClient(conn)
+	si.CertificateClient = certificate.NewCertificateServiceClient(conn)
+	si.ClusterClient = cluster.NewClusterServiceClient(conn)
+	si.GPGKeysClient = gpgkey.NewGPGKeyServiceClient(conn)
+	si.ProjectClient = project.NewProjectServiceClient(conn)
+	si.RepositoryClient = repository.NewRepositoryServiceClient(conn)
+	si.RepoCredsClient = repocreds.NewRepoCredsServiceClient(conn)
+	si.SessionClient = session.NewSessionServiceClient(conn)
+	si.SettingsClient = settings.NewSettingsServiceClient(conn)
+
+	versionClient := version.NewVersionServiceClient(conn)
+
+	var diags diag.Diagnostics
+
+	serverVersionMessage, err := versionClient.Version(ctx, &empty.Empty{})
 	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize session client", err)...)
+		return diagnostics.Error("failed to read server version", err)
+	}
+
+	if serverVersionMessage == nil {
+		return diagnostics.Error("could not get server version information", nil)
 	}
 
-	acCloser, versionClient, err := ac.NewVersionClient()
+	si.ServerVersionMessage = serverVersionMessage
+
+	serverVersion, err := semver.NewVersion(serverVersionMessage.Version)
 	if err != nil {
-		diags.Append(diagnostics.Error("failed to initialize version client", err)...)
-	} else {
-		defer io.Close(acCloser)
+		diags.Append(diagnostics.Error(fmt.Sprintf("could not parse server semantic version: %s", serverVersionMessage.Version), nil)...)
+	}
+
+	si.ServerVersion = serverVersion
+
+	si.initialized = !diags.HasError()
+
+	return diags
+}
 
-		serverVersionMessage, err := versionClient.Version(ctx, &empty.Empty{})
+// lockTarget returns the target of the connections to the ArgoCD API server
+// that locks are keyed by: the Kubernetes API server and namespace in core
+// mode and when port forwarding, and otherwise the address of the server,
+// which may be read from the local ArgoCD configuration.
+func (si *ServerInterface) lockTarget(opts *apiclient.ClientOptions) (string, error) {
+	if si.kubeClientConfig != nil {
+		restConfig, err := si.kubeClientConfig.ClientConfig()
 		if err != nil {
-			return diagnostics.Error("failed to read server version", err)
+			return "", err
 		}
 
-		if serverVersionMessage == nil {
-			return diagnostics.Error("could not get server version information", nil)
+		namespace := opts.PortForwardNamespace
+		if namespace == "" {
+			if namespace, _, err = si.kubeClientConfig.Namespace(); err != nil {
+				return "", err
+			}
 		}
 
-		si.ServerVersionMessage = serverVersionMessage
+		return fmt.Sprintf("kubernetes:%s/%s", restConfig.Host, namespace), nil
+	}
 
-		serverVersion, err := semver.NewVersion(serverVersionMessage.Version)
-		if err != nil {
-			diags.Append(diagnostics.Error(fmt.Sprintf("could not parse server semantic version: %s", serverVersionMessage.Version), nil)...)
-		}
+	if opts.ServerAddr != "" {
+		return opts.ServerAddr, nil
+	}
 
-		si.ServerVersion = serverVersion
+	localCfg, err := localconfig.ReadLocalConfig(opts.ConfigPath)
+	if err != nil || localCfg == nil {
+		return "", err
 	}
 
-	si.initialized = !diags.HasError()
+	configCtx, err := localCfg.ResolveContext(opts.Context)
+	if err != nil {
+		return "", err
+	}
 
-	return diags
+	return configCtx.Server.Server, nil
 }
 
 // Checks that a specific feature is available for the current ArgoCD server version.
 // 'feature' argument must match one of the predefined feature* constants.
+// See FeatureSupport for the reason why a feature is not available.
 func (si *ServerInterface) IsFeatureSupported(feature features.Feature) bool {
-	fc, ok := features.ConstraintsMap[feature]
+	supported, _, _ := si.FeatureSupport(context.Background(), feature)
 
-	return ok && fc.MinVersion.Compare(si.ServerVersion) != 1
+	return supported
 }
 
 func getDefaultString(s types.String, envKey string) string {
@@ -2,13 +2,18 @@ package provider
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
 	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/argoproj/argo-cd/v2/util/localconfig"
 	"github.com/oboukili/terraform-provider-argocd/internal/features"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 const (
@@ -102,3 +107,73 @@ func TestServerInterface_isFeatureSupported(t *testing.T) {
 		})
 	}
 }
+
+func TestServerInterface_lockTarget(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, localconfig.WriteLocalConfig(localconfig.LocalConfig{
+		CurrentContext: "local",
+		Contexts:       []localconfig.ContextRef{{Name: "local", Server: "local.example.com", User: "local"}},
+		Servers:        []localconfig.Server{{Server: "local.example.com"}},
+		Users:          []localconfig.User{{Name: "local", AuthToken: "foo"}},
+	}, configPath))
+
+	kubeClientConfig := func(namespace string) clientcmd.ClientConfig {
+		return clientcmd.NewDefaultClientConfig(api.Config{
+			Clusters:       map[string]*api.Cluster{"kind": {Server: "https://127.0.0.1:6443"}},
+			Contexts:       map[string]*api.Context{"kind": {Cluster: "kind", Namespace: namespace}},
+			CurrentContext: "kind",
+		}, &clientcmd.ConfigOverrides{})
+	}
+
+	tests := []struct {
+		name             string
+		opts             apiclient.ClientOptions
+		kubeClientConfig clientcmd.ClientConfig
+		expected         string
+	}{
+		{
+			name:     "server address",
+			opts:     apiclient.ClientOptions{ServerAddr: "argocd.example.com:443"},
+			expected: "argocd.example.com:443",
+		},
+		{
+			name:     "local config",
+			opts:     apiclient.ClientOptions{ConfigPath: configPath},
+			expected: "local.example.com",
+		},
+		{
+			name:             "core",
+			opts:             apiclient.ClientOptions{Core: true, ServerAddr: "kubernetes"},
+			kubeClientConfig: kubeClientConfig("argocd"),
+			expected:         "kubernetes:https://127.0.0.1:6443/argocd",
+		},
+		{
+			name:             "port forwarding",
+			opts:             apiclient.ClientOptions{PortForward: true, ServerAddr: "localhost"},
+			kubeClientConfig: kubeClientConfig("argocd"),
+			expected:         "kubernetes:https://127.0.0.1:6443/argocd",
+		},
+		{
+			name:             "port forwarding with namespace",
+			opts:             apiclient.ClientOptions{PortForward: true, PortForwardNamespace: "other", ServerAddr: "localhost"},
+			kubeClientConfig: kubeClientConfig("argocd"),
+			expected:         "kubernetes:https://127.0.0.1:6443/other",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			si := &ServerInterface{kubeClientConfig: tt.kubeClientConfig}
+
+			target, err := si.lockTarget(&tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, target)
+		})
+	}
+}
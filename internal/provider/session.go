@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokenExpiryLeeway is how long before its expiry an authentication token is
+// renewed.
+const tokenExpiryLeeway = 10 * time.Second
+
+// versionMethod is the full name of the gRPC method returning the version of
+// the ArgoCD API server.
+const versionMethod = "/version.VersionService/Version"
+
+// tokenSource obtains a new authentication token, along with its expiry time
+// (zero if unknown).
+type tokenSource func(ctx context.Context) (string, time.Time, error)
+
+// userSession holds what is needed to renew the authentication token, obtained
+// either in exchange for a username and password or from the `auth_exec`
+// command, once it has expired. It authenticates requests using the latest
+// token, so that renewing it does not require a new connection.
+type userSession struct {
+	newToken tokenSource
+	now      func() time.Time
+
+	current atomic.Pointer[sessionToken]
+}
+
+// sessionToken is an authentication token that expires at `expiresAt` (zero if
+// unknown).
+type sessionToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+func (t *sessionToken) expired(now time.Time) bool {
+	return !t.expiresAt.IsZero() && now.Add(tokenExpiryLeeway).After(t.expiresAt)
+}
+
+func newUserSession(newToken tokenSource, token string, expiresAt time.Time) *userSession {
+	s := &userSession{
+		newToken: newToken,
+		now:      time.Now,
+	}
+
+	s.current.Store(&sessionToken{token: token, expiresAt: expiresAt})
+
+	return s
+}
+
+func (s *userSession) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{apiclient.MetaDataTokenKey: s.current.Load().token}, nil
+}
+
+func (*userSession) RequireTransportSecurity() bool {
+	return false
+}
+
+// renewSession obtains a new token, unless the token has already been renewed
+// since `expired` was used.
+func (si *ServerInterface) renewSession(ctx context.Context, expired *sessionToken) error {
+	si.Lock()
+	defer si.Unlock()
+
+	s := si.session
+
+	if current := s.current.Load(); current != expired && !current.expired(s.now()) {
+		return nil
+	}
+
+	token, expiresAt, err := s.newToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.current.Store(&sessionToken{token: token, expiresAt: expiresAt})
+
+	return nil
+}
+
+// sessionUnaryClientInterceptor returns a gRPC client interceptor that renews
+// the authentication token shortly before it expires or, if its expiry is
+// unknown, once the server rejects it, in which case the request is
+// transparently retried.
+func sessionUnaryClientInterceptor(si *ServerInterface) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		// The version endpoint does not require authentication and is called
+		// whilst holding the lock required to renew the token.
+		if method == versionMethod {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		current := si.session.current.Load()
+
+		if current.expired(si.session.now()) {
+			if err := si.renewSession(ctx, current); err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("failed to renew expiring authentication token: %s", err))
+			}
+
+			current = si.session.current.Load()
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("renewing authentication token after %s failed with: %s", method, err))
+
+		if rerr := si.renewSession(ctx, current); rerr != nil {
+			tflog.Warn(ctx, fmt.Sprintf("failed to renew authentication token: %s", rerr))
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
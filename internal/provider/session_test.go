@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/session"
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient/version"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeSessionServer issues session tokens in exchange for the admin
+// credentials. Tokens remain valid until expired.
+type fakeSessionServer struct {
+	session.UnimplementedSessionServiceServer
+
+	mu       sync.Mutex
+	password string
+	tokens   map[string]bool
+	created  atomic.Int32
+}
+
+func (s *fakeSessionServer) Create(_ context.Context, req *session.SessionCreateRequest) (*session.SessionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Username != "admin" || req.Password != s.password {
+		return nil, status.Error(codes.Unauthenticated, "Invalid username or password")
+	}
+
+	token := fmt.Sprintf("token-%d", s.created.Add(1))
+	s.tokens[token] = true
+
+	return &session.SessionResponse{Token: token}, nil
+}
+
+func (s *fakeSessionServer) GetUserInfo(ctx context.Context, _ *session.GetUserInfoRequest) (*session.GetUserInfoResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, t := range md.Get(apiclient.MetaDataTokenKey) {
+		if s.tokens[t] {
+			return &session.GetUserInfoResponse{LoggedIn: true, Username: "admin"}, nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid session: token has invalid claims: token is expired")
+}
+
+func (s *fakeSessionServer) expireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]bool{}
+}
+
+func (s *fakeSessionServer) setPassword(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.password = password
+}
+
+func (s *fakeSessionServer) issue(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[token] = true
+}
+
+// startFakeSessionServer starts a fake session server and returns its address.
+func startFakeSessionServer(t *testing.T) (string, *fakeSessionServer) {
+	t.Helper()
+
+	// Ensure that the configured credentials are used
+	t.Setenv("ARGOCD_AUTH_TOKEN", "")
+
+	srv := &fakeSessionServer{password: "password", tokens: map[string]bool{}}
+	conn := startFakeServer(t, func(s *grpc.Server) {
+		session.RegisterSessionServiceServer(s, srv)
+		version.RegisterVersionServiceServer(s, &fakeVersionServer{})
+	})
+
+	return conn.Target(), srv
+}
+
+// newSessionServerInterface returns an initialized server interface
+// authenticating against a fake session server using a username and password.
+func newSessionServerInterface(t *testing.T) (*ServerInterface, *fakeSessionServer) {
+	t.Helper()
+
+	addr, srv := startFakeSessionServer(t)
+
+	si := NewServerInterface(ArgoCDProviderConfig{
+		ServerAddr: types.StringValue(addr),
+		Username:   types.StringValue("admin"),
+		Password:   types.StringValue("password"),
+		PlainText:  types.BoolValue(true),
+	})
+
+	diags := si.InitClients(context.Background())
+	require.False(t, diags.HasError(), "%v", diags)
+
+	return si, srv
+}
+
+func TestSessionUnaryClientInterceptor(t *testing.T) {
+	si, srv := newSessionServerInterface(t)
+
+	_, err := si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), srv.created.Load())
+
+	// The session is transparently renewed once expired
+	srv.expireTokens()
+
+	_, err = si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), srv.created.Load())
+
+	// Subsequent requests use the renewed session
+	_, err = si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), srv.created.Load())
+
+	// Concurrent requests share a single renewal
+	srv.expireTokens()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(3), srv.created.Load())
+}
+
+func TestSessionUnaryClientInterceptor_RenewalFailure(t *testing.T) {
+	si, srv := newSessionServerInterface(t)
+
+	srv.setPassword("changed")
+	srv.expireTokens()
+
+	// The original error is returned
+	_, err := si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, err.Error(), "token is expired")
+}
+
+func TestSessionUnaryClientInterceptor_Expiry(t *testing.T) {
+	si, srv := newSessionServerInterface(t)
+
+	// Tokens are renewed before they expire
+	current := si.session.current.Load()
+	si.session.current.Store(&sessionToken{token: current.token, expiresAt: time.Now().Add(tokenExpiryLeeway / 2)})
+
+	_, err := si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), srv.created.Load())
+
+	_, err = si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), srv.created.Load())
+}
+
+// countingListener counts the connections it accepted that are still open.
+type countingListener struct {
+	net.Listener
+
+	open atomic.Int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	l.open.Add(1)
+
+	return &countedConn{Conn: c, l: l}, nil
+}
+
+type countedConn struct {
+	net.Conn
+
+	l    *countingListener
+	once sync.Once
+}
+
+func (c *countedConn) Close() error {
+	c.once.Do(func() {
+		c.l.open.Add(-1)
+	})
+
+	return c.Conn.Close()
+}
+
+func TestSessionUnaryClientInterceptor_Connections(t *testing.T) {
+	t.Setenv("ARGOCD_AUTH_TOKEN", "")
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	cl := &countingListener{Listener: l}
+
+	srv := &fakeSessionServer{password: "password", tokens: map[string]bool{}}
+	s := grpc.NewServer()
+	session.RegisterSessionServiceServer(s, srv)
+	version.RegisterVersionServiceServer(s, &fakeVersionServer{})
+
+	go func() {
+		_ = s.Serve(cl)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	si := NewServerInterface(ArgoCDProviderConfig{
+		ServerAddr: types.StringValue(l.Addr().String()),
+		Username:   types.StringValue("admin"),
+		Password:   types.StringValue("password"),
+		PlainText:  types.BoolValue(true),
+	})
+
+	diags := si.InitClients(context.Background())
+	require.False(t, diags.HasError(), "%v", diags)
+
+	for i := 0; i < 5; i++ {
+		srv.expireTokens()
+
+		_, err := si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(6), srv.created.Load())
+
+	// Renewing the token does not leave any connection behind
+	assert.Eventually(t, func() bool {
+		return cl.open.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSessionUnaryClientInterceptor_AuthExec(t *testing.T) {
+	addr, srv := startFakeSessionServer(t)
+
+	dir := t.TempDir()
+
+	// The command outputs a new token each time it is executed
+	script := filepath.Join(dir, "token.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+n=$(($(cat "$COUNTER" 2>/dev/null || echo 0) + 1))
+echo $n > "$COUNTER"
+echo "exec-token-$n"
+`), 0o700))
+
+	env, diags := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"COUNTER": filepath.Join(dir, "counter")})
+	require.False(t, diags.HasError())
+
+	si := NewServerInterface(ArgoCDProviderConfig{
+		ServerAddr: types.StringValue(addr),
+		PlainText:  types.BoolValue(true),
+		AuthExec: []AuthExec{
+			{
+				Command:      types.StringValue(script),
+				Args:         types.ListNull(types.StringType),
+				Env:          env,
+				OutputFormat: types.StringNull(),
+			},
+		},
+	})
+
+	srv.issue("exec-token-1")
+
+	diags = si.InitClients(context.Background())
+	require.False(t, diags.HasError(), "%v", diags)
+
+	_, err := si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+
+	// The command is executed again once the token is rejected
+	srv.expireTokens()
+	srv.issue("exec-token-2")
+
+	_, err = si.SessionClient.GetUserInfo(context.Background(), &session.GetUserInfoRequest{})
+	require.NoError(t, err)
+
+	// No session is created in exchange for a username and password
+	assert.Equal(t, int32(0), srv.created.Load())
+}
@@ -2,6 +2,103 @@ package sync
 
 import "sync"
 
-// GPGKeysMutex is used to handle concurrent access to ArgoCD GPG keys which are
-// stored in the `argocd-gpg-keys-cm` ConfigMap resource
-var GPGKeysMutex = &sync.RWMutex{}
+// Objects within ArgoCD that are modified by more than one resource and to
+// which concurrent access must therefore be serialized.
+const (
+	// Repositories, repository credentials, repository certificates and
+	// accounts are stored in the `argocd-cm` ConfigMap resource.
+	objectConfigMap = "argocd-cm"
+
+	// Account tokens are stored in the `argocd-secret` Secret resource.
+	objectSecret = "argocd-secret"
+
+	// GPG keys are stored in the `argocd-gpg-keys-cm` ConfigMap resource.
+	objectGPGKeys = "argocd-gpg-keys-cm"
+
+	// Clusters are stored in Secret resources labelled as such, which are
+	// listed in order to detect duplicates when creating a cluster.
+	objectClusters = "clusters"
+
+	// Projects (and their tokens) are stored in AppProject resources.
+	objectProjectPrefix = "appproject/"
+)
+
+type lockKey struct {
+	server string
+	object string
+}
+
+// lockRegistry holds the locks of all objects of all ArgoCD servers.
+type lockRegistry struct {
+	mu    sync.Mutex
+	locks map[lockKey]*sync.RWMutex
+}
+
+func newLockRegistry() *lockRegistry {
+	return &lockRegistry{
+		locks: make(map[lockKey]*sync.RWMutex),
+	}
+}
+
+func (r *lockRegistry) get(key lockKey) *sync.RWMutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.locks[key]
+	if !ok {
+		l = &sync.RWMutex{}
+		r.locks[key] = l
+	}
+
+	return l
+}
+
+// defaultRegistry is shared by all lock managers so that provider instances
+// targeting the same server (e.g. both halves of the muxed provider, or
+// several aliases of the provider) serialize their access to the same objects.
+var defaultRegistry = newLockRegistry()
+
+// LockManager hands out the locks used to handle concurrent access to the
+// objects of a single ArgoCD server.
+type LockManager struct {
+	registry *lockRegistry
+	server   string
+}
+
+// NewLockManager returns a LockManager for the ArgoCD server identified by
+// `server`.
+func NewLockManager(server string) *LockManager {
+	return &LockManager{
+		registry: defaultRegistry,
+		server:   server,
+	}
+}
+
+func (m *LockManager) get(object string) *sync.RWMutex {
+	return m.registry.get(lockKey{server: m.server, object: object})
+}
+
+// ConfigMap returns the lock of the `argocd-cm` ConfigMap resource.
+func (m *LockManager) ConfigMap() *sync.RWMutex {
+	return m.get(objectConfigMap)
+}
+
+// Secret returns the lock of the `argocd-secret` Secret resource.
+func (m *LockManager) Secret() *sync.RWMutex {
+	return m.get(objectSecret)
+}
+
+// GPGKeys returns the lock of the `argocd-gpg-keys-cm` ConfigMap resource.
+func (m *LockManager) GPGKeys() *sync.RWMutex {
+	return m.get(objectGPGKeys)
+}
+
+// Clusters returns the lock of the cluster Secret resources.
+func (m *LockManager) Clusters() *sync.RWMutex {
+	return m.get(objectClusters)
+}
+
+// Project returns the lock of the AppProject resource named `name`.
+func (m *LockManager) Project(name string) *sync.RWMutex {
+	return m.get(objectProjectPrefix + name)
+}
@@ -0,0 +1,104 @@
+package sync
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLockManager(r *lockRegistry, server string) *LockManager {
+	return &LockManager{
+		registry: r,
+		server:   server,
+	}
+}
+
+func TestLockManager_Keys(t *testing.T) {
+	t.Parallel()
+
+	r := newLockRegistry()
+	m := newTestLockManager(r, "argocd.example.com:443")
+
+	assert.Same(t, m.ConfigMap(), m.ConfigMap())
+	assert.Same(t, m.Project("foo"), m.Project("foo"))
+
+	// Each object has its own lock
+	locks := []*sync.RWMutex{m.ConfigMap(), m.Secret(), m.GPGKeys(), m.Clusters(), m.Project("foo"), m.Project("bar")}
+	for i := range locks {
+		for j := range locks {
+			if i != j {
+				assert.NotSame(t, locks[i], locks[j])
+			}
+		}
+	}
+
+	// Managers targeting the same server share their locks
+	assert.Same(t, m.Secret(), newTestLockManager(r, "argocd.example.com:443").Secret())
+	assert.Same(t, m.Project("foo"), newTestLockManager(r, "argocd.example.com:443").Project("foo"))
+
+	// Managers targeting different servers do not
+	assert.NotSame(t, m.Secret(), newTestLockManager(r, "other.example.com:443").Secret())
+	assert.NotSame(t, m.Project("foo"), newTestLockManager(r, "other.example.com:443").Project("foo"))
+}
+
+func TestLockManager_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	const (
+		goroutines = 50
+		iterations = 100
+	)
+
+	r := newLockRegistry()
+	servers := []string{"a.example.com:443", "b.example.com:443"}
+
+	// The map itself is only read concurrently, the counters it points to are
+	// guarded by the lock of their project.
+	counters := make(map[string]*int)
+
+	for _, s := range servers {
+		for p := 0; p < 5; p++ {
+			counters[fmt.Sprintf("%s/%d", s, p)] = new(int)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			server := servers[g%len(servers)]
+
+			// Each goroutine gets its own manager, as each provider instance
+			// does, so that only the registry is shared.
+			m := newTestLockManager(r, server)
+
+			for i := 0; i < iterations; i++ {
+				key := fmt.Sprintf("%s/%d", server, i%5)
+				l := m.Project(fmt.Sprint(i % 5))
+
+				l.Lock()
+				*counters[key]++
+				l.Unlock()
+
+				l.RLock()
+				_ = *counters[key]
+				l.RUnlock()
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	total := 0
+	for _, c := range counters {
+		total += *c
+	}
+
+	assert.Equal(t, goroutines*iterations, total)
+}
@@ -0,0 +1,262 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type jsonStringType uint8
+
+const (
+	JSONStringType jsonStringType = iota
+)
+
+var (
+	_ basetypes.StringTypable = JSONStringType
+
+	_ basetypes.StringValuable                   = JSONString{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONString{}
+)
+
+// TerraformType returns the tftypes.Type that should be used to represent this
+// framework type.
+func (t jsonStringType) TerraformType(_ context.Context) tftypes.Type {
+	return tftypes.String
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t jsonStringType) ValueFromString(_ context.Context, in types.String) (basetypes.StringValuable, diag.Diagnostics) {
+	if in.IsUnknown() {
+		return JSONStringUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return JSONStringNull(), nil
+	}
+
+	return JSONString{
+		state: attr.ValueStateKnown,
+		value: in.ValueString(),
+	}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.  This is meant to
+// convert the tftypes.Value into a more convenient Go type for the provider to
+// consume the data with.
+func (t jsonStringType) ValueFromTerraform(_ context.Context, in tftypes.Value) (attr.Value, error) {
+	if !in.IsKnown() {
+		return JSONStringUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return JSONStringNull(), nil
+	}
+
+	var s string
+	err := in.As(&s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return JSONString{
+		state: attr.ValueStateKnown,
+		value: s,
+	}, nil
+}
+
+// ValueType returns the Value type.
+func (t jsonStringType) ValueType(context.Context) attr.Value {
+	return JSONString{}
+}
+
+// Equal returns true if `o` is also a JSONStringType.
+func (t jsonStringType) Equal(o attr.Type) bool {
+	_, ok := o.(jsonStringType)
+	return ok
+}
+
+// ApplyTerraform5AttributePathStep applies the given AttributePathStep to the
+// type.
+func (t jsonStringType) ApplyTerraform5AttributePathStep(step tftypes.AttributePathStep) (interface{}, error) {
+	return nil, fmt.Errorf("cannot apply AttributePathStep %T to %s", step, t.String())
+}
+
+// String returns a human-friendly description of the JSONStringType.
+func (t jsonStringType) String() string {
+	return "types.JSONStringType"
+}
+
+func (t jsonStringType) Description() string {
+	return `JSON document. Documents that are structurally equal are considered equal, regardless of formatting or key ordering.`
+}
+
+func JSONStringNull() JSONString {
+	return JSONString{
+		state: attr.ValueStateNull,
+	}
+}
+
+func JSONStringUnknown() JSONString {
+	return JSONString{
+		state: attr.ValueStateUnknown,
+	}
+}
+
+func JSONStringValue(value string) JSONString {
+	return JSONString{
+		state: attr.ValueStateKnown,
+		value: value,
+	}
+}
+
+type JSONString struct {
+	// state represents whether the value is null, unknown, or known. The
+	// zero-value is null.
+	state attr.ValueState
+
+	// value contains the original string representation.
+	value string
+}
+
+// Type returns a JSONStringType.
+func (j JSONString) Type(_ context.Context) attr.Type {
+	return JSONStringType
+}
+
+// ToStringValue should convert the value type to a String.
+func (j JSONString) ToStringValue(ctx context.Context) (types.String, diag.Diagnostics) {
+	switch j.state {
+	case attr.ValueStateKnown:
+		return types.StringValue(j.value), nil
+	case attr.ValueStateNull:
+		return types.StringNull(), nil
+	case attr.ValueStateUnknown:
+		return types.StringUnknown(), nil
+	default:
+		return types.StringUnknown(), diag.Diagnostics{
+			diag.NewErrorDiagnostic(fmt.Sprintf("unhandled JSONString state in ToStringValue: %s", j.state), ""),
+		}
+	}
+}
+
+// ToTerraformValue returns the data contained in the *String as a string. If
+// Unknown is true, it returns a tftypes.UnknownValue. If Null is true, it
+// returns nil.
+func (j JSONString) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
+	t := JSONStringType.TerraformType(ctx)
+
+	switch j.state {
+	case attr.ValueStateKnown:
+		if err := tftypes.ValidateValue(t, j.value); err != nil {
+			return tftypes.NewValue(t, tftypes.UnknownValue), err
+		}
+
+		return tftypes.NewValue(t, j.value), nil
+	case attr.ValueStateNull:
+		return tftypes.NewValue(t, nil), nil
+	case attr.ValueStateUnknown:
+		return tftypes.NewValue(t, tftypes.UnknownValue), nil
+	default:
+		return tftypes.NewValue(t, tftypes.UnknownValue), fmt.Errorf("unhandled JSONString state in ToTerraformValue: %s", j.state)
+	}
+}
+
+// Equal returns true if `other` is a *JSONString and has the same value as `j`.
+func (j JSONString) Equal(other attr.Value) bool {
+	o, ok := other.(JSONString)
+
+	if !ok {
+		return false
+	}
+
+	if j.state != o.state {
+		return false
+	}
+
+	if j.state != attr.ValueStateKnown {
+		return true
+	}
+
+	return j.value == o.value
+}
+
+// IsNull returns true if the Value is not set, or is explicitly set to null.
+func (j JSONString) IsNull() bool {
+	return j.state == attr.ValueStateNull
+}
+
+// IsUnknown returns true if the Value is not yet known.
+func (j JSONString) IsUnknown() bool {
+	return j.state == attr.ValueStateUnknown
+}
+
+// String returns a summary representation of either the underlying Value,
+// or UnknownValueString (`<unknown>`) when IsUnknown() returns true,
+// or NullValueString (`<null>`) when IsNull() return true.
+//
+// This is an intentionally lossy representation, that are best suited for
+// logging and error reporting, as they are not protected by
+// compatibility guarantees within the framework.
+func (j JSONString) String() string {
+	if j.IsUnknown() {
+		return attr.UnknownValueString
+	}
+
+	if j.IsNull() {
+		return attr.NullValueString
+	}
+
+	return j.value
+}
+
+// ValueJSONString returns the known string value. If JSONString is null or unknown, returns "".
+func (j JSONString) ValueJSONString() string {
+	return j.value
+}
+
+// StringSemanticEquals should return true if the given value is
+// semantically equal to the current value. This logic is used to prevent
+// Terraform data consistency errors and resource drift where a value change
+// may have inconsequential differences, such as whitespace or key ordering in
+// JSON documents.
+//
+// Only known values are compared with this method as changing a value's
+// state implicitly represents a different value.
+func (j JSONString) StringSemanticEquals(ctx context.Context, other basetypes.StringValuable) (bool, diag.Diagnostics) {
+	o, ok := other.(JSONString)
+	if !ok {
+		return false, nil
+	}
+
+	return JSONSemanticEquals(j.value, o.value), nil
+}
+
+// JSONSemanticEquals returns true if both strings are structurally equal JSON
+// documents. Strings that cannot be parsed as JSON are only equal if they are
+// identical.
+func JSONSemanticEquals(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var av, bv interface{}
+
+	if err := json.Unmarshal([]byte(a), &av); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(b), &bv); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
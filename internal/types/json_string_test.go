@@ -0,0 +1,77 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "identical",
+			a:    `{"foo":"bar"}`,
+			b:    `{"foo":"bar"}`,
+			want: true,
+		},
+		{
+			name: "whitespace",
+			a:    `{"foo":"bar"}`,
+			b:    "{ \"foo\": \"bar\" }\n",
+			want: true,
+		},
+		{
+			name: "key ordering",
+			a:    `{"a":1,"b":{"c":["foo","bar"]}}`,
+			b:    `{"b":{"c":["foo","bar"]},"a":1}`,
+			want: true,
+		},
+		{
+			name: "different values",
+			a:    `{"foo":"bar"}`,
+			b:    `{"foo":"baz"}`,
+			want: false,
+		},
+		{
+			name: "different list ordering",
+			a:    `{"foo":["a","b"]}`,
+			b:    `{"foo":["b","a"]}`,
+			want: false,
+		},
+		{
+			name: "different scalar types",
+			a:    `{"foo":1}`,
+			b:    `{"foo":"1"}`,
+			want: false,
+		},
+		{
+			name: "YAML",
+			a:    `{"foo":"bar"}`,
+			b:    "foo: bar",
+			want: false,
+		},
+		{
+			name: "invalid JSON",
+			a:    `{"foo":`,
+			b:    `{"foo": `,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, JSONSemanticEquals(tt.a, tt.b))
+		})
+	}
+}
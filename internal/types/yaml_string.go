@@ -0,0 +1,280 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"sigs.k8s.io/yaml"
+)
+
+type yamlStringType uint8
+
+const (
+	YAMLStringType yamlStringType = iota
+)
+
+var (
+	_ basetypes.StringTypable = YAMLStringType
+
+	_ basetypes.StringValuable                   = YAMLString{}
+	_ basetypes.StringValuableWithSemanticEquals = YAMLString{}
+)
+
+// TerraformType returns the tftypes.Type that should be used to represent this
+// framework type.
+func (t yamlStringType) TerraformType(_ context.Context) tftypes.Type {
+	return tftypes.String
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t yamlStringType) ValueFromString(_ context.Context, in types.String) (basetypes.StringValuable, diag.Diagnostics) {
+	if in.IsUnknown() {
+		return YAMLStringUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return YAMLStringNull(), nil
+	}
+
+	return YAMLString{
+		state: attr.ValueStateKnown,
+		value: in.ValueString(),
+	}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.  This is meant to
+// convert the tftypes.Value into a more convenient Go type for the provider to
+// consume the data with.
+func (t yamlStringType) ValueFromTerraform(_ context.Context, in tftypes.Value) (attr.Value, error) {
+	if !in.IsKnown() {
+		return YAMLStringUnknown(), nil
+	}
+
+	if in.IsNull() {
+		return YAMLStringNull(), nil
+	}
+
+	var s string
+	err := in.As(&s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return YAMLString{
+		state: attr.ValueStateKnown,
+		value: s,
+	}, nil
+}
+
+// ValueType returns the Value type.
+func (t yamlStringType) ValueType(context.Context) attr.Value {
+	return YAMLString{}
+}
+
+// Equal returns true if `o` is also a YAMLStringType.
+func (t yamlStringType) Equal(o attr.Type) bool {
+	_, ok := o.(yamlStringType)
+	return ok
+}
+
+// ApplyTerraform5AttributePathStep applies the given AttributePathStep to the
+// type.
+func (t yamlStringType) ApplyTerraform5AttributePathStep(step tftypes.AttributePathStep) (interface{}, error) {
+	return nil, fmt.Errorf("cannot apply AttributePathStep %T to %s", step, t.String())
+}
+
+// String returns a human-friendly description of the YAMLStringType.
+func (t yamlStringType) String() string {
+	return "types.YAMLStringType"
+}
+
+func (t yamlStringType) Description() string {
+	return `YAML document. Documents that are structurally equal are considered equal, regardless of formatting, comments or key ordering.`
+}
+
+func YAMLStringNull() YAMLString {
+	return YAMLString{
+		state: attr.ValueStateNull,
+	}
+}
+
+func YAMLStringUnknown() YAMLString {
+	return YAMLString{
+		state: attr.ValueStateUnknown,
+	}
+}
+
+func YAMLStringValue(value string) YAMLString {
+	return YAMLString{
+		state: attr.ValueStateKnown,
+		value: value,
+	}
+}
+
+type YAMLString struct {
+	// state represents whether the value is null, unknown, or known. The
+	// zero-value is null.
+	state attr.ValueState
+
+	// value contains the original string representation.
+	value string
+}
+
+// Type returns a YAMLStringType.
+func (y YAMLString) Type(_ context.Context) attr.Type {
+	return YAMLStringType
+}
+
+// ToStringValue should convert the value type to a String.
+func (y YAMLString) ToStringValue(ctx context.Context) (types.String, diag.Diagnostics) {
+	switch y.state {
+	case attr.ValueStateKnown:
+		return types.StringValue(y.value), nil
+	case attr.ValueStateNull:
+		return types.StringNull(), nil
+	case attr.ValueStateUnknown:
+		return types.StringUnknown(), nil
+	default:
+		return types.StringUnknown(), diag.Diagnostics{
+			diag.NewErrorDiagnostic(fmt.Sprintf("unhandled YAMLString state in ToStringValue: %s", y.state), ""),
+		}
+	}
+}
+
+// ToTerraformValue returns the data contained in the *String as a string. If
+// Unknown is true, it returns a tftypes.UnknownValue. If Null is true, it
+// returns nil.
+func (y YAMLString) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
+	t := YAMLStringType.TerraformType(ctx)
+
+	switch y.state {
+	case attr.ValueStateKnown:
+		if err := tftypes.ValidateValue(t, y.value); err != nil {
+			return tftypes.NewValue(t, tftypes.UnknownValue), err
+		}
+
+		return tftypes.NewValue(t, y.value), nil
+	case attr.ValueStateNull:
+		return tftypes.NewValue(t, nil), nil
+	case attr.ValueStateUnknown:
+		return tftypes.NewValue(t, tftypes.UnknownValue), nil
+	default:
+		return tftypes.NewValue(t, tftypes.UnknownValue), fmt.Errorf("unhandled YAMLString state in ToTerraformValue: %s", y.state)
+	}
+}
+
+// Equal returns true if `other` is a *YAMLString and has the same value as `y`.
+func (y YAMLString) Equal(other attr.Value) bool {
+	o, ok := other.(YAMLString)
+
+	if !ok {
+		return false
+	}
+
+	if y.state != o.state {
+		return false
+	}
+
+	if y.state != attr.ValueStateKnown {
+		return true
+	}
+
+	return y.value == o.value
+}
+
+// IsNull returns true if the Value is not set, or is explicitly set to null.
+func (y YAMLString) IsNull() bool {
+	return y.state == attr.ValueStateNull
+}
+
+// IsUnknown returns true if the Value is not yet known.
+func (y YAMLString) IsUnknown() bool {
+	return y.state == attr.ValueStateUnknown
+}
+
+// String returns a summary representation of either the underlying Value,
+// or UnknownValueString (`<unknown>`) when IsUnknown() returns true,
+// or NullValueString (`<null>`) when IsNull() return true.
+//
+// This is an intentionally lossy representation, that are best suited for
+// logging and error reporting, as they are not protected by
+// compatibility guarantees within the framework.
+func (y YAMLString) String() string {
+	if y.IsUnknown() {
+		return attr.UnknownValueString
+	}
+
+	if y.IsNull() {
+		return attr.NullValueString
+	}
+
+	return y.value
+}
+
+// ValueYAMLString returns the known string value. If YAMLString is null or unknown, returns "".
+func (y YAMLString) ValueYAMLString() string {
+	return y.value
+}
+
+// StringSemanticEquals should return true if the given value is
+// semantically equal to the current value. This logic is used to prevent
+// Terraform data consistency errors and resource drift where a value change
+// may have inconsequential differences, such as trailing newlines, comments or
+// key ordering in YAML documents.
+//
+// Only known values are compared with this method as changing a value's
+// state implicitly represents a different value.
+func (y YAMLString) StringSemanticEquals(ctx context.Context, other basetypes.StringValuable) (bool, diag.Diagnostics) {
+	o, ok := other.(YAMLString)
+	if !ok {
+		return false, nil
+	}
+
+	return YAMLSemanticEquals(y.value, o.value), nil
+}
+
+// YAMLSemanticEquals returns true if both strings are structurally equal YAML
+// documents. Strings that cannot be parsed as YAML are only equal if they are
+// identical.
+func YAMLSemanticEquals(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	av, err := unmarshalYAML(a)
+	if err != nil {
+		return false
+	}
+
+	bv, err := unmarshalYAML(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
+
+// unmarshalYAML converts the YAML document to JSON before unmarshalling so
+// that map keys are normalised to strings and can be compared regardless of
+// the YAML representation used.
+func unmarshalYAML(s string) (interface{}, error) {
+	j, err := yaml.YAMLToJSON([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(j, &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYAMLSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "identical",
+			a:    "foo: bar",
+			b:    "foo: bar",
+			want: true,
+		},
+		{
+			name: "trailing newlines",
+			a:    "foo: bar",
+			b:    "foo: bar\n\n",
+			want: true,
+		},
+		{
+			name: "comments",
+			a:    "# Some comment\nfoo: bar # inline comment\n",
+			b:    "foo: bar\n",
+			want: true,
+		},
+		{
+			name: "key ordering and flow style",
+			a:    "a: 1\nb:\n  c: [foo, bar]\n",
+			b:    "\"b\": {\"c\": [\"foo\", \"bar\"]}\n\"a\": 1\n",
+			want: true,
+		},
+		{
+			name: "empty documents",
+			a:    "",
+			b:    "\n",
+			want: true,
+		},
+		{
+			name: "different values",
+			a:    "foo: bar",
+			b:    "foo: baz",
+			want: false,
+		},
+		{
+			name: "different list ordering",
+			a:    "foo: [a, b]",
+			b:    "foo: [b, a]",
+			want: false,
+		},
+		{
+			name: "different scalar types",
+			a:    "foo: 1",
+			b:    "foo: \"1\"",
+			want: false,
+		},
+		{
+			name: "invalid YAML",
+			a:    "foo: [",
+			b:    "foo: [ ",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, YAMLSemanticEquals(tt.a, tt.b))
+		})
+	}
+}
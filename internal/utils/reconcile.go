@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var attrValueType = reflect.TypeOf((*attr.Value)(nil)).Elem()
+
+// ReconcileWithPriorState reconciles a model built from an API response with
+// the prior state of the same model, so that values omitted by the API can be
+// distinguished from values that have been explicitly set to their zero value.
+// Both arguments must be pointers to values of the same type, built from
+// framework types (e.g. `types.String`), pointers to structs, structs, slices
+// and maps thereof. `current` is modified in place such that:
+//   - zero values (e.g. `""`, `false` or `0`) are set to null, unless the prior
+//     value was known and not null;
+//   - empty slices and maps are set to nil (i.e. null), unless they were empty
+//     in the prior state and, conversely, nil slices and maps are set to empty
+//     if they were empty in the prior state;
+//   - pointers to structs that only contain zero values are set to nil, unless
+//     they were set in the prior state and, conversely, nil pointers are set to
+//     the prior value if the latter only contains zero values.
+//
+// A nil prior (e.g. on import) results in all zero values being set to null.
+func ReconcileWithPriorState(current, prior interface{}) {
+	c := reflect.ValueOf(current)
+	if c.Kind() != reflect.Ptr || c.IsNil() {
+		return
+	}
+
+	p := reflect.ValueOf(prior)
+	if p.Kind() != reflect.Ptr || p.IsNil() {
+		p = reflect.Value{}
+	} else {
+		p = p.Elem()
+	}
+
+	reconcileValue(c.Elem(), p)
+}
+
+func reconcileValue(current, prior reflect.Value) {
+	if prior.IsValid() && prior.Type() != current.Type() {
+		return
+	}
+
+	if current.Type().Implements(attrValueType) {
+		if isZeroValue(current) && (!prior.IsValid() || prior.Interface().(attr.Value).IsNull()) {
+			current.Set(nullValue(current))
+		}
+
+		return
+	}
+
+	switch current.Kind() {
+	case reflect.Ptr:
+		switch {
+		case current.IsNil() && prior.IsValid() && !prior.IsNil() && isZeroValue(prior.Elem()):
+			current.Set(prior)
+		case current.IsNil():
+			return
+		case (!prior.IsValid() || prior.IsNil()) && isZeroValue(current.Elem()):
+			current.Set(reflect.Zero(current.Type()))
+		case !prior.IsValid() || prior.IsNil():
+			reconcileValue(current.Elem(), reflect.Value{})
+		default:
+			reconcileValue(current.Elem(), prior.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < current.NumField(); i++ {
+			if !current.Field(i).CanSet() {
+				continue
+			}
+
+			var p reflect.Value
+			if prior.IsValid() {
+				p = prior.Field(i)
+			}
+
+			reconcileValue(current.Field(i), p)
+		}
+	case reflect.Slice, reflect.Map:
+		priorIsEmpty := prior.IsValid() && !prior.IsNil() && prior.Len() == 0
+
+		switch {
+		case current.Len() == 0 && priorIsEmpty:
+			switch {
+			case !current.IsNil():
+			case current.Kind() == reflect.Map:
+				current.Set(reflect.MakeMap(current.Type()))
+			default:
+				current.Set(reflect.MakeSlice(current.Type(), 0, 0))
+			}
+		case current.Len() == 0:
+			current.Set(reflect.Zero(current.Type()))
+		case current.Kind() == reflect.Slice:
+			for i := 0; i < current.Len(); i++ {
+				var p reflect.Value
+				if prior.IsValid() && i < prior.Len() {
+					p = prior.Index(i)
+				}
+
+				reconcileValue(current.Index(i), p)
+			}
+		}
+	}
+}
+
+// isZeroValue returns whether the value is null or contains only zero values.
+func isZeroValue(v reflect.Value) bool {
+	if v.Type().Implements(attrValueType) {
+		av := v.Interface().(attr.Value)
+		if av.IsNull() {
+			return true
+		}
+
+		if av.IsUnknown() {
+			return false
+		}
+
+		tv, err := av.ToTerraformValue(context.Background())
+		if err != nil {
+			return false
+		}
+
+		switch {
+		case tv.Type().Is(tftypes.String):
+			var s string
+			return tv.As(&s) == nil && s == ""
+		case tv.Type().Is(tftypes.Bool):
+			var b bool
+			return tv.As(&b) == nil && !b
+		case tv.Type().Is(tftypes.Number):
+			n := new(big.Float)
+			return tv.As(&n) == nil && n.Sign() == 0
+		default:
+			return false
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		return v.IsNil() || isZeroValue(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanInterface() && !isZeroValue(v.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// nullValue returns the null value of the same type as the given framework
+// value.
+func nullValue(v reflect.Value) reflect.Value {
+	av := v.Interface().(attr.Value)
+
+	nv, err := av.Type(context.Background()).ValueFromTerraform(context.Background(), tftypes.NewValue(av.Type(context.Background()).TerraformType(context.Background()), nil))
+	if err != nil {
+		return v
+	}
+
+	n := reflect.ValueOf(nv)
+	if !n.Type().AssignableTo(v.Type()) {
+		return v
+	}
+
+	return n
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type reconcileTestNested struct {
+	Enabled types.Bool  `tfsdk:"enabled"`
+	Count   types.Int64 `tfsdk:"count"`
+}
+
+type reconcileTestModel struct {
+	Name   types.String            `tfsdk:"name"`
+	List   []types.String          `tfsdk:"list"`
+	Map    map[string]types.String `tfsdk:"map"`
+	Nested *reconcileTestNested    `tfsdk:"nested"`
+	Items  []reconcileTestNested   `tfsdk:"items"`
+}
+
+func TestReconcileWithPriorState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current reconcileTestModel
+		prior   *reconcileTestModel
+		want    reconcileTestModel
+	}{
+		{
+			name: "zero values are null without prior state",
+			current: reconcileTestModel{
+				Name:   types.StringValue(""),
+				List:   []types.String{},
+				Map:    map[string]types.String{},
+				Nested: &reconcileTestNested{Enabled: types.BoolValue(false), Count: types.Int64Value(0)},
+				Items:  []reconcileTestNested{{Enabled: types.BoolValue(true), Count: types.Int64Value(0)}},
+			},
+			want: reconcileTestModel{
+				Name:  types.StringNull(),
+				Items: []reconcileTestNested{{Enabled: types.BoolValue(true), Count: types.Int64Null()}},
+			},
+		},
+		{
+			name: "explicit zero values are retained",
+			current: reconcileTestModel{
+				Name:   types.StringValue(""),
+				Nested: &reconcileTestNested{Enabled: types.BoolValue(false), Count: types.Int64Value(0)},
+			},
+			prior: &reconcileTestModel{
+				Name:   types.StringValue(""),
+				List:   []types.String{},
+				Map:    map[string]types.String{},
+				Nested: &reconcileTestNested{Enabled: types.BoolValue(false), Count: types.Int64Null()},
+			},
+			want: reconcileTestModel{
+				Name:   types.StringValue(""),
+				List:   []types.String{},
+				Map:    map[string]types.String{},
+				Nested: &reconcileTestNested{Enabled: types.BoolValue(false), Count: types.Int64Null()},
+			},
+		},
+		{
+			name: "empty objects omitted by the API are retained",
+			current: reconcileTestModel{
+				Name: types.StringValue("foo"),
+			},
+			prior: &reconcileTestModel{
+				Name:   types.StringValue("foo"),
+				Nested: &reconcileTestNested{Enabled: types.BoolNull(), Count: types.Int64Null()},
+			},
+			want: reconcileTestModel{
+				Name:   types.StringValue("foo"),
+				Nested: &reconcileTestNested{Enabled: types.BoolNull(), Count: types.Int64Null()},
+			},
+		},
+		{
+			name: "drift is reported",
+			current: reconcileTestModel{
+				Name: types.StringValue("bar"),
+				List: []types.String{types.StringValue("a")},
+			},
+			prior: &reconcileTestModel{
+				Name:   types.StringValue("foo"),
+				Nested: &reconcileTestNested{Enabled: types.BoolValue(true), Count: types.Int64Null()},
+			},
+			want: reconcileTestModel{
+				Name: types.StringValue("bar"),
+				List: []types.String{types.StringValue("a")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ReconcileWithPriorState(&tt.current, tt.prior)
+
+			assert.Equal(t, tt.want, tt.current)
+		})
+	}
+}
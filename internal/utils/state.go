@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UpgradeSDKState converts the raw JSON state of a resource that was previously
+// implemented using the SDK into JSON state matching the given (framework)
+// Terraform type, such that:
+//   - blocks limited to a single element (stored by the SDK as lists or sets of
+//     a single object) are converted to objects, and objects stored by earlier
+//     versions of the SDK implementation in place of such blocks are converted
+//     to lists of a single object where blocks are retained;
+//   - attributes are looked up by name or, failing that, by the name given in
+//     aliases (e.g. to support renaming `role` to `roles`), whilst attributes
+//     that no longer exist are dropped;
+//   - numbers stored as strings are converted to numbers;
+//   - zero values (`""`, `false`, empty lists and maps), which the SDK stores
+//     in place of null, are converted to null.
+func UpgradeSDKState(raw []byte, typ tftypes.Type, aliases map[string]string) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+
+	upgraded, err := upgradeSDKStateValue(v, typ, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(upgraded)
+}
+
+func upgradeSDKStateValue(v interface{}, typ tftypes.Type, aliases map[string]string) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t := typ.(type) {
+	case tftypes.Object:
+		if l, ok := v.([]interface{}); ok {
+			switch len(l) {
+			case 0:
+				return nil, nil
+			case 1:
+				v = l[0]
+			default:
+				return nil, fmt.Errorf("expected at most one element, got %d", len(l))
+			}
+		}
+
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %T", v)
+		}
+
+		result := make(map[string]interface{}, len(t.AttributeTypes))
+
+		for name, at := range t.AttributeTypes {
+			av, ok := m[name]
+			if !ok {
+				av = m[aliases[name]]
+			}
+
+			uv, err := upgradeSDKStateValue(av, at, aliases)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			result[name] = uv
+		}
+
+		return result, nil
+	case tftypes.List, tftypes.Set:
+		var et tftypes.Type
+
+		switch t := t.(type) {
+		case tftypes.List:
+			et = t.ElementType
+		case tftypes.Set:
+			et = t.ElementType
+		}
+
+		if m, ok := v.(map[string]interface{}); ok {
+			if _, ok := et.(tftypes.Object); ok {
+				v = []interface{}{m}
+			}
+		}
+
+		l, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a list, got %T", v)
+		}
+
+		if len(l) == 0 {
+			return nil, nil
+		}
+
+		result := make([]interface{}, len(l))
+
+		for i, e := range l {
+			ue, err := upgradeSDKStateValue(e, et, aliases)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+
+			result[i] = ue
+		}
+
+		return result, nil
+	case tftypes.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map, got %T", v)
+		}
+
+		if len(m) == 0 {
+			return nil, nil
+		}
+
+		result := make(map[string]interface{}, len(m))
+
+		for k, e := range m {
+			ue, err := upgradeSDKStateValue(e, t.ElementType, aliases)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+
+			result[k] = ue
+		}
+
+		return result, nil
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		switch s := v.(type) {
+		case string:
+			if s == "" {
+				return nil, nil
+			}
+
+			return s, nil
+		case json.Number:
+			return s.String(), nil
+		}
+	case typ.Is(tftypes.Number):
+		switch n := v.(type) {
+		case json.Number:
+			return n, nil
+		case string:
+			if n == "" {
+				return nil, nil
+			}
+
+			if _, err := strconv.ParseFloat(n, 64); err != nil {
+				return nil, fmt.Errorf("expected a number, got %q", n)
+			}
+
+			return json.Number(n), nil
+		}
+	case typ.Is(tftypes.Bool):
+		if b, ok := v.(bool); ok {
+			if !b {
+				return nil, nil
+			}
+
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected value of type %T for %s", v, typ)
+}
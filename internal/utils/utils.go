@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
@@ -31,6 +34,16 @@ func OptionalTimeString(value *metav1.Time) basetypes.StringValue {
 	return types.StringValue(value.String())
 }
 
+// IsFullyKnown returns whether the value, including any nested values, is known.
+func IsFullyKnown(ctx context.Context, value attr.Value) bool {
+	v, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return false
+	}
+
+	return v.IsFullyKnown()
+}
+
 // MapMap will return a new map where each element has been mapped (transformed).
 // The number of elements returned will always be the same as the input.
 //
@@ -51,3 +64,60 @@ func MapMap[T comparable, U any, V any](ss map[T]U, fn func(U) V) map[T]V {
 
 	return ss2
 }
+
+// ProjectJSON returns the parts of the decoded JSON value that are present in
+// shape. Objects are projected recursively whilst all other values (including
+// arrays) are returned as is. Keys of shape that are missing from value are
+// only retained when their value in shape is a zero value (e.g. `false` or
+// `null`), since these are omitted when the value is encoded.
+func ProjectJSON(value, shape interface{}) interface{} {
+	s, ok := shape.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	v, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	result := make(map[string]interface{}, len(s))
+
+	for k, sv := range s {
+		vv, ok := v[k]
+
+		switch {
+		case ok:
+			result[k] = ProjectJSON(vv, sv)
+		case isZeroJSON(sv):
+			result[k] = sv
+		}
+	}
+
+	return result
+}
+
+func isZeroJSON(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !t
+	case float64:
+		return t == 0
+	case string:
+		return t == ""
+	case []interface{}:
+		return len(t) == 0
+	case map[string]interface{}:
+		for _, e := range t {
+			if !isZeroJSON(e) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
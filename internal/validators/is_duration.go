@@ -0,0 +1,42 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	argocdtime "github.com/argoproj/pkg/time"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = (*isDurationValidator)(nil)
+
+type isDurationValidator struct{}
+
+func IsDuration() isDurationValidator {
+	return isDurationValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isDurationValidator) Description(ctx context.Context) string {
+	return "ensures that attribute is a valid duration (e.g. `1h` or `1d`)"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v isDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := argocdtime.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("cannot parse duration %q: %s", req.ConfigValue.ValueString(), err))
+	}
+}
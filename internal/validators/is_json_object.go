@@ -0,0 +1,43 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = (*isJSONObjectValidator)(nil)
+
+type isJSONObjectValidator struct{}
+
+func IsJSONObject() isJSONObjectValidator {
+	return isJSONObjectValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isJSONObjectValidator) Description(ctx context.Context) string {
+	return "ensures that attribute is a JSON encoded object"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isJSONObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v isJSONObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &m); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON object",
+			fmt.Sprintf("must be a JSON encoded object: %s", err))
+	}
+}
@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = (*isPEMCertificateValidator)(nil)
+
+type isPEMCertificateValidator struct{}
+
+func IsPEMCertificate() isPEMCertificateValidator {
+	return isPEMCertificateValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isPEMCertificateValidator) Description(ctx context.Context) string {
+	return "ensures that attribute holds one or more PEM encoded certificates"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isPEMCertificateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v isPEMCertificateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(req.ConfigValue.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid certificate",
+			"must hold one or more PEM encoded certificates")
+	}
+}
@@ -0,0 +1,44 @@
+package validators
+
+import (
+	"context"
+	"encoding/pem"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = (*isPEMPrivateKeyValidator)(nil)
+
+type isPEMPrivateKeyValidator struct{}
+
+func IsPEMPrivateKey() isPEMPrivateKeyValidator {
+	return isPEMPrivateKeyValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isPEMPrivateKeyValidator) Description(ctx context.Context) string {
+	return "ensures that attribute holds a PEM encoded private key"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v isPEMPrivateKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v isPEMPrivateKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	// The value is sensitive so it must not be included in the diagnostic
+	block, _ := pem.Decode([]byte(req.ConfigValue.ValueString()))
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid private key",
+			"must hold a PEM encoded private key")
+	}
+}
@@ -11,7 +11,7 @@ resource "argocd_application" "foo" {
   }
 
   spec {
-    project = argocd_project.foo.metadata[0].name
+    project = argocd_project.foo.metadata.name
 
     source {
       repo_url        = "https://raw.githubusercontent.com/bitnami/charts/archive-full-index/bitnami"
@@ -1,5 +1,5 @@
 resource "argocd_project" "foo" {
-  metadata {
+  metadata = {
     name      = "foo"
     namespace = "argocd"
     labels = {
@@ -10,76 +10,84 @@ resource "argocd_project" "foo" {
     }
   }
 
-  spec {
+  spec = {
     description  = "simple project"
     source_repos = ["*"]
 
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "default"
-    }
-
-    destination {
-      server    = "https://kubernetes.default.svc"
-      namespace = "foo"
-    }
-
-    destination {
-      server    = argocd_cluster.kind_secondary.server
-      namespace = "default"
-    }
-
-    cluster_resource_whitelist {
-      group = "rbac.authorization.k8s.io"
-      kind  = "ClusterRoleBinding"
-    }
+    destinations = [
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "default"
+      },
+      {
+        server    = "https://kubernetes.default.svc"
+        namespace = "foo"
+      },
+      {
+        server    = argocd_cluster.kind_secondary.server
+        namespace = "default"
+      },
+    ]
 
-    cluster_resource_whitelist {
-      group = "rbac.authorization.k8s.io"
-      kind  = "ClusterRole"
-    }
+    cluster_resource_whitelist = [
+      {
+        group = "rbac.authorization.k8s.io"
+        kind  = "ClusterRoleBinding"
+      },
+      {
+        group = "rbac.authorization.k8s.io"
+        kind  = "ClusterRole"
+      },
+    ]
 
-    namespace_resource_blacklist {
-      group = "networking.k8s.io"
-      kind  = "Ingress"
-    }
+    namespace_resource_blacklist = [
+      {
+        group = "networking.k8s.io"
+        kind  = "Ingress"
+      },
+    ]
 
-    namespace_resource_whitelist {
-      group = "*"
-      kind  = "*"
-    }
+    namespace_resource_whitelist = [
+      {
+        group = "*"
+        kind  = "*"
+      },
+    ]
 
-    role {
-      name = "foo"
-      policies = [
-        "p, proj:foo:foo, applications, get, foo/*, allow",
-        "p, proj:foo:foo, applications, sync, foo/*, deny",
-      ]
-    }
+    roles = [
+      {
+        name = "foo"
+        policies = [
+          "p, proj:foo:foo, applications, get, foo/*, allow",
+          "p, proj:foo:foo, applications, sync, foo/*, deny",
+        ]
+      },
+    ]
 
-    orphaned_resources {
+    orphaned_resources = {
       warn = true
     }
 
-    sync_window {
-      kind         = "allow"
-      applications = ["api-*"]
-      clusters     = ["*"]
-      namespaces   = ["*"]
-      duration     = "3600s"
-      schedule     = "10 1 * * *"
-      manual_sync  = true
-    }
-
-    sync_window {
-      kind         = "deny"
-      applications = ["foo"]
-      clusters     = ["in-cluster"]
-      namespaces   = ["default"]
-      duration     = "12h"
-      schedule     = "22 1 5 * *"
-      manual_sync  = false
-      timezone     = "Europe/London"
-    }
+    sync_windows = [
+      {
+        kind         = "allow"
+        applications = ["api-*"]
+        clusters     = ["*"]
+        namespaces   = ["*"]
+        duration     = "3600s"
+        schedule     = "10 1 * * *"
+        manual_sync  = true
+      },
+      {
+        kind         = "deny"
+        applications = ["foo"]
+        clusters     = ["in-cluster"]
+        namespaces   = ["default"]
+        duration     = "12h"
+        schedule     = "22 1 5 * *"
+        manual_sync  = false
+        timezone     = "Europe/London"
+      },
+    ]
   }
 }